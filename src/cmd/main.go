@@ -4,12 +4,19 @@ import (
 	"context"
 	"effective-mobile/internal/config"
 	"effective-mobile/internal/http/api"
+	"effective-mobile/internal/http/middleware"
+	"effective-mobile/internal/jobs"
 	"effective-mobile/internal/service"
 	storage "effective-mobile/internal/storage/postgresql"
 	"effective-mobile/internal/storage/postgresql/migrations"
+	"effective-mobile/pkg/jwt"
 	"effective-mobile/pkg/logger/sl"
+	"effective-mobile/pkg/ratelimit"
 	"effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -40,16 +47,104 @@ func main() {
 	}
 
 	subStorage := storage.NewSubscriptionStorage(pgClient, log)
+	analyticsStorage := storage.NewAnalyticsStorage(pgClient, log)
+	servicesStorage := storage.NewServicesStorage(pgClient, log)
+	categoriesStorage := storage.NewCategoriesStorage(pgClient, log)
+	usersStorage := storage.NewUsersStorage(pgClient, log)
+	remindersStorage := storage.NewRemindersStorage(pgClient, log)
+	webhooksStorage := storage.NewWebhooksStorage(pgClient, log)
+	outboxStorage := storage.NewOutboxStorage(pgClient, log)
+	apiKeysStorage := storage.NewAPIKeysStorage(pgClient, log)
 
 	log.Info("Initializing service")
-	service := service.NewSubscriptionService(subStorage, log)
+	rates, err := setupExchangeRates(cfg, pgClient, log)
+	if err != nil {
+		log.Error("failed to initialize exchange rates", sl.Err(err))
+		return
+	}
+	unknownServices := service.UnknownServicePolicy(cfg.CatalogConfig.UnknownServices)
+	if !unknownServices.IsValid() {
+		log.Error("unknown catalog policy for unknown services", slog.String("policy", string(unknownServices)))
+		return
+	}
+	catalog := service.NewServiceCatalog(servicesStorage, unknownServices, log)
+	categories := service.NewServiceCategories(categoriesStorage, catalog, log)
+	analytics := service.NewAnalyticsService(analyticsStorage, catalog, log)
+	users := service.NewUserService(usersStorage, log)
+	notifier, err := setupNotifier(cfg, log)
+	if err != nil {
+		log.Error("failed to initialize notifier", sl.Err(err))
+		return
+	}
+	renewals := service.NewRenewalService(subStorage, usersStorage, remindersStorage, notifier, log)
+	webhooks := service.NewWebhookService(webhooksStorage, cfg.WebhooksConfig.AllowPrivateTargets, log)
+	apiKeys := service.NewAPIKeyService(apiKeysStorage, log)
+	dispatcher := service.NewWebhookDispatcher(webhooksStorage, service.WebhookDeliveryConfig{
+		MaxAttempts: cfg.WebhooksConfig.MaxAttempts,
+		Timeout:     cfg.WebhooksConfig.Timeout,
+		Workers:     cfg.WebhooksConfig.Workers,
+		// deliveries are checked again when connecting, a host may resolve differently than on creation
+		AllowPrivateTargets: cfg.WebhooksConfig.AllowPrivateTargets,
+	}, log)
+	// deliveries run within the relay job, stopping the scheduler interrupts them
+	relay := service.NewOutboxRelay(outboxStorage, dispatcher, service.OutboxRelayConfig{
+		Lease:          cfg.OutboxConfig.Lease,
+		InitialBackoff: cfg.WebhooksConfig.InitialBackoff,
+		MaxBackoff:     cfg.WebhooksConfig.MaxBackoff,
+		Workers:        cfg.WebhooksConfig.Workers,
+	}, log)
+	service := service.NewSubscriptionService(subStorage, usersStorage, catalog, rates, log)
+
+	scheduler := jobs.NewScheduler(log)
+	scheduler.Every("relay-outbox", cfg.OutboxConfig.Interval, jobs.RelayOutbox(relay, cfg.OutboxConfig.BatchSize))
+	if retention := cfg.OutboxConfig.Retention; retention > 0 {
+		scheduler.Every("purge-dispatched-events", cfg.OutboxConfig.PurgeInterval, jobs.PurgeDispatchedEvents(relay, retention))
+	}
+	if ttl := cfg.RetentionConfig.DeletedTTL; ttl > 0 {
+		scheduler.Every("purge-deleted-subscriptions", cfg.RetentionConfig.PurgeInterval, jobs.PurgeDeletedSubscriptions(service, ttl))
+	}
+	if days := cfg.RemindersConfig.DaysBefore; days > 0 {
+		scheduler.Every("send-renewal-reminders", cfg.RemindersConfig.Interval, jobs.SendRenewalReminders(renewals, days))
+	}
+	limits, err := setupRateLimits(cfg, pgClient, scheduler, log)
+	if err != nil {
+		log.Error("failed to initialize rate limiting", sl.Err(err))
+		return
+	}
+
+	log.Info("Starting background jobs")
+	if err := scheduler.Start(); err != nil {
+		log.Error("failed to start background jobs", sl.Err(err))
+		return
+	}
+	defer func() {
+		log.Info("Stopping background jobs")
+		scheduler.Stop()
+	}()
 
 	log.Info("Setting up http server")
 	deps := api.HandlersDependencies{
 		Log:                 log,
 		SubscriptionService: service,
+		AnalyticsService:    analytics,
+		ServiceCatalog:      catalog,
+		ServiceCategories:   categories,
+		UserService:         users,
+		RenewalService:      renewals,
+		WebhookService:      webhooks,
+		APIKeyService:       apiKeys,
 	}
-	srv := api.NewHTTPServer(log, deps, cfg)
+	verifier, err := setupTokenVerifier(cfg)
+	if err != nil {
+		log.Error("failed to initialize authentication", sl.Err(err))
+		return
+	}
+	extractIP, err := middleware.NewIPExtractor(cfg.HTTPServerConfig.TrustedProxies)
+	if err != nil {
+		log.Error("failed to initialize client address extraction", sl.Err(err))
+		return
+	}
+	srv := api.NewHTTPServer(log, deps, verifier, limits, extractIP, cfg)
 
 	done := make(chan os.Signal, 1)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
@@ -88,6 +183,83 @@ func setupLogger() *slog.Logger {
 	return log
 }
 
+func setupExchangeRates(cfg *config.CRUDConfig, client postgresql.Client, log *slog.Logger) (service.ExchangeRates, error) {
+	switch cfg.ExchangeRatesConfig.Source {
+	case "":
+		log.Info("exchange rates are not configured, currency conversion disabled")
+		return nil, nil
+	case "file":
+		return service.NewFileExchangeRates(cfg.ExchangeRatesConfig.Path)
+	case "storage":
+		return service.NewStorageExchangeRates(storage.NewExchangeRatesStorage(client, log)), nil
+	default:
+		return nil, fmt.Errorf("unknown exchange rates source %q", cfg.ExchangeRatesConfig.Source)
+	}
+}
+
+func setupNotifier(cfg *config.CRUDConfig, log *slog.Logger) (service.Notifier, error) {
+	rCfg := cfg.RemindersConfig
+	switch rCfg.Notifier {
+	case "", "log":
+		return service.NewLogNotifier(log), nil
+	case "smtp":
+		return service.NewSMTPNotifier(service.SMTPNotifierConfig{
+			Host:     rCfg.SMTP.Host,
+			Port:     rCfg.SMTP.Port,
+			User:     rCfg.SMTP.User,
+			Password: rCfg.SMTP.Password,
+			From:     rCfg.SMTP.From,
+		})
+	case "webhook":
+		return service.NewWebhookNotifier(rCfg.WebhookURL, &http.Client{Timeout: rCfg.WebhookTimeout})
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", rCfg.Notifier)
+	}
+}
+
+func setupRateLimits(cfg *config.CRUDConfig, client postgresql.Client, scheduler *jobs.Scheduler, log *slog.Logger) (ratelimit.Store, error) {
+	rCfg := cfg.HTTPServerConfig.RateLimit
+	switch rCfg.Store {
+	case "", "none":
+		log.Info("rate limiting is disabled")
+		return nil, nil
+	case "memory":
+		return ratelimit.NewMemoryStore(), nil
+	case "postgres":
+		s := storage.NewRateLimitStorage(client, log)
+		scheduler.Every("purge-idle-rate-limits", rCfg.PurgeInterval, jobs.PurgeIdleRateLimits(s, rCfg.IdleTTL))
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit store %q", rCfg.Store)
+	}
+}
+
+func setupTokenVerifier(cfg *config.CRUDConfig) (middleware.TokenVerifier, error) {
+	aCfg := cfg.HTTPServerConfig.Auth
+	opts := jwt.Options{
+		Issuer:   aCfg.Issuer,
+		Audience: aCfg.Audience,
+		Leeway:   aCfg.Leeway,
+	}
+
+	switch aCfg.Method {
+	case "":
+		return nil, errors.New(`auth method is not set, use "none" to leave the API open`)
+	case "none", "gateway":
+		return nil, nil
+	case "hs256":
+		return jwt.NewHS256Verifier([]byte(aCfg.Secret), opts)
+	case "rs256":
+		keys, err := jwt.LoadJWKS(aCfg.JWKSPath)
+		if err != nil {
+			return nil, err
+		}
+		return jwt.NewRS256Verifier(keys, opts)
+	default:
+		return nil, fmt.Errorf("unknown auth method %q", aCfg.Method)
+	}
+}
+
 func mustInitStorage(log *slog.Logger, cfg *config.CRUDConfig) (postgresql.Client, postgresql.PostgresConfig) {
 	sCfg := cfg.StorageConfig
 	pCfg := postgresql.PostgresConfig{
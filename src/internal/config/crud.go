@@ -3,6 +3,7 @@ package config
 import (
 	"log"
 	"os"
+	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
 )
@@ -18,13 +19,138 @@ type StorageConfig struct {
 }
 
 type CRUDConfig struct {
-	StorageConfig    `yaml:"storage" env-required:"true"`
-	HTTPServerConfig `yaml:"http"`
+	StorageConfig       `yaml:"storage" env-required:"true"`
+	HTTPServerConfig    `yaml:"http"`
+	ExchangeRatesConfig `yaml:"exchange-rates"`
+	RetentionConfig     `yaml:"retention"`
+	CatalogConfig       `yaml:"catalog"`
+	RemindersConfig     `yaml:"reminders"`
+	WebhooksConfig      `yaml:"webhooks"`
+	OutboxConfig        `yaml:"outbox"`
 }
 
+// OutboxConfig controls the relay publishing lifecycle events from the outbox every Interval in batches of BatchSize.
+// A relay claims the events it publishes for Lease, another instance takes over events not published by then.
+// Dispatched events are removed every PurgeInterval once they are older than Retention, a zero Retention keeps them.
+type OutboxConfig struct {
+	Interval      time.Duration `yaml:"interval" env-default:"1s"`
+	BatchSize     int           `yaml:"batch-size" env-default:"100"`
+	Lease         time.Duration `yaml:"lease" env-default:"1m"`
+	Retention     time.Duration `yaml:"retention" env-default:"168h"`
+	PurgeInterval time.Duration `yaml:"purge-interval" env-default:"1h"`
+}
+
+// WebhooksConfig controls delivery of subscription events to webhooks. A failed delivery is retried by a later
+// outbox relay run after InitialBackoff, doubling the pause up to MaxBackoff, and gives up after MaxAttempts.
+// Workers deliver events of different subscriptions and an event to different webhooks in parallel. Webhooks may only point at public addresses
+// unless AllowPrivateTargets is set, which is meant for local development.
+type WebhooksConfig struct {
+	MaxAttempts         int           `yaml:"max-attempts" env-default:"5"`
+	InitialBackoff      time.Duration `yaml:"initial-backoff" env-default:"1s"`
+	MaxBackoff          time.Duration `yaml:"max-backoff" env-default:"1m"`
+	Timeout             time.Duration `yaml:"timeout" env-default:"10s"`
+	Workers             int           `yaml:"workers" env-default:"4"`
+	AllowPrivateTargets bool          `yaml:"allow-private-targets" env-default:"false"`
+}
+
+// RemindersConfig controls renewal reminders sent DaysBefore days ahead of a charge, a zero DaysBefore disables them.
+// Notifier selects the delivery: "log" only writes reminders to the log, "smtp" mails them to users
+// and "webhook" posts them to WebhookURL, giving up on a post after WebhookTimeout.
+type RemindersConfig struct {
+	DaysBefore     int           `yaml:"days-before" env-default:"0"`
+	Interval       time.Duration `yaml:"interval" env-default:"1h"`
+	Notifier       string        `yaml:"notifier" env-default:"log"`
+	WebhookURL     string        `yaml:"webhook-url" env-default:""`
+	WebhookTimeout time.Duration `yaml:"webhook-timeout" env-default:"10s"`
+	SMTP           SMTPConfig    `yaml:"smtp"`
+}
+
+type SMTPConfig struct {
+	Host     string `yaml:"host" env-default:""`
+	Port     int    `yaml:"port" env-default:"25"`
+	User     string `yaml:"user" env-default:""`
+	Password string `yaml:"pass" env-default:""`
+	From     string `yaml:"from" env-default:""`
+}
+
+// CatalogConfig decides what happens to a subscription naming a service missing from the catalog:
+// "create" adds the name to the catalog, "reject" refuses the subscription.
+type CatalogConfig struct {
+	UnknownServices string `yaml:"unknown-services" env-default:"create"`
+}
+
+// RetentionConfig controls purging of soft-deleted subscriptions.
+// A zero DeletedTTL keeps deleted subscriptions forever.
+type RetentionConfig struct {
+	DeletedTTL    time.Duration `yaml:"deleted-ttl" env-default:"0"`
+	PurgeInterval time.Duration `yaml:"purge-interval" env-default:"1h"`
+}
+
+// ExchangeRatesConfig selects where currency conversion rates come from:
+// "file" reads fixed rates from Path, "storage" uses the historical daily rates table
+// and an empty source disables conversion.
+type ExchangeRatesConfig struct {
+	Source string `yaml:"source" env-default:""`
+	Path   string `yaml:"path" env-default:"config/rates.yaml"`
+}
+
+// HTTPServerConfig configures the API server. TrustedProxies lists the CIDR ranges of reverse proxies whose
+// X-Forwarded-For header tells the client address, without them the address of the connection is the client's.
 type HTTPServerConfig struct {
-	Address string `yaml:"address" env-default:"localhost:8080"`
-	Swagger SwaggerConfig
+	Address        string `yaml:"address" env-default:"localhost:8080"`
+	Swagger        SwaggerConfig
+	Auth           AuthConfig      `yaml:"auth"`
+	Tenancy        TenancyConfig   `yaml:"tenancy"`
+	RateLimit      RateLimitConfig `yaml:"rate-limit"`
+	TrustedProxies []string        `yaml:"trusted-proxies"`
+}
+
+// RateLimitConfig limits the requests every client makes. Store selects where the buckets are kept: "none" disables
+// rate limiting, "memory" limits clients on every instance separately and "postgres" shares the buckets between
+// instances, removing buckets idle for IdleTTL every PurgeInterval. Routes get their own limits, requests to
+// other routes are limited by Default whose Method and Path are ignored.
+type RateLimitConfig struct {
+	Store         string           `yaml:"store" env-default:"none"`
+	Default       RouteRateLimit   `yaml:"default"`
+	Routes        []RouteRateLimit `yaml:"routes"`
+	IdleTTL       time.Duration    `yaml:"idle-ttl" env-default:"1h"`
+	PurgeInterval time.Duration    `yaml:"purge-interval" env-default:"10m"`
+}
+
+// RouteRateLimit allows Requests per Per on average and bursts of up to Burst requests to the route Path,
+// written as the echo route pattern such as "/subscriptions/:id". An empty Method matches every method.
+type RouteRateLimit struct {
+	Method   string        `yaml:"method"`
+	Path     string        `yaml:"path"`
+	Requests int           `yaml:"requests"`
+	Per      time.Duration `yaml:"per"`
+	Burst    int           `yaml:"burst"`
+}
+
+// TenancyConfig tells which tenant a request acts for: api keys are bound to theirs, tokens carry it in the claim
+// TenantClaim and a gateway authenticating callers sets it in Header. Only operators may use Header to act for
+// another tenant. With authentication disabled the tenant is the one requested in Header, else Default.
+type TenancyConfig struct {
+	Header      string `yaml:"header" env-default:"X-Tenant-ID"`
+	TenantClaim string `yaml:"tenant-claim" env-default:"tenant"`
+	Default     string `yaml:"default" env-default:"default"`
+}
+
+// AuthConfig selects how callers are authenticated: "none" leaves the API open, "hs256" checks bearer tokens
+// with Secret and "rs256" with the keys of the JWKS file at JWKSPath, "gateway" trusts the identity a gateway
+// puts into UserHeader and RoleHeader. Non-empty Issuer and Audience must match the token claims,
+// Leeway tolerates clock skew on expiry and RoleClaim names the claim carrying the caller role.
+// Method has no default, so the API is never left open by accident.
+type AuthConfig struct {
+	Method     string        `yaml:"method" env-default:""`
+	Secret     string        `yaml:"secret" env-default:""`
+	JWKSPath   string        `yaml:"jwks-path" env-default:""`
+	Issuer     string        `yaml:"issuer" env-default:""`
+	Audience   string        `yaml:"audience" env-default:""`
+	Leeway     time.Duration `yaml:"leeway" env-default:"30s"`
+	RoleClaim  string        `yaml:"role-claim" env-default:"role"`
+	UserHeader string        `yaml:"user-header" env-default:"X-User-ID"`
+	RoleHeader string        `yaml:"role-header" env-default:"X-User-Role"`
 }
 
 type SwaggerConfig struct {
@@ -7,21 +7,220 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
+	"github.com/oapi-codegen/nullable"
 	"github.com/oapi-codegen/runtime"
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+const (
+	ApiKeyAuthScopes = "apiKeyAuth.Scopes"
+	BearerAuthScopes = "bearerAuth.Scopes"
+)
+
+// Defines values for APIKeyScope.
+const (
+	Admin APIKeyScope = "admin"
+	Read  APIKeyScope = "read"
+	Write APIKeyScope = "write"
+)
+
+// Defines values for BillingPeriod.
+const (
+	Monthly BillingPeriod = "monthly"
+	OneOff  BillingPeriod = "one_off"
+	Weekly  BillingPeriod = "weekly"
+	Yearly  BillingPeriod = "yearly"
+)
+
+// Defines values for EventType.
+const (
+	SubscriptionCreated EventType = "subscription.created"
+	SubscriptionDeleted EventType = "subscription.deleted"
+	SubscriptionUpdated EventType = "subscription.updated"
+)
+
+// Defines values for ImportLineStatus.
+const (
+	Accepted ImportLineStatus = "accepted"
+	Rejected ImportLineStatus = "rejected"
+	Skipped  ImportLineStatus = "skipped"
+)
+
+// Defines values for SpendingDimension.
+const (
+	Category SpendingDimension = "category"
+	Month    SpendingDimension = "month"
+	Service  SpendingDimension = "service"
+	User     SpendingDimension = "user"
+)
+
+// Defines values for SubscriptionChangeAction.
+const (
+	Created  SubscriptionChangeAction = "created"
+	Deleted  SubscriptionChangeAction = "deleted"
+	Restored SubscriptionChangeAction = "restored"
+	Updated  SubscriptionChangeAction = "updated"
+)
+
+// Defines values for SubscriptionsSort.
+const (
+	SubscriptionsSortId             SubscriptionsSort = "id"
+	SubscriptionsSortMinusPrice     SubscriptionsSort = "-price"
+	SubscriptionsSortMinusStartDate SubscriptionsSort = "-start_date"
+	SubscriptionsSortPrice          SubscriptionsSort = "price"
+	SubscriptionsSortStartDate      SubscriptionsSort = "start_date"
+)
+
+// Defines values for GetSubscriptionsParamsSort.
+const (
+	GetSubscriptionsParamsSortId             GetSubscriptionsParamsSort = "id"
+	GetSubscriptionsParamsSortMinusPrice     GetSubscriptionsParamsSort = "-price"
+	GetSubscriptionsParamsSortMinusStartDate GetSubscriptionsParamsSort = "-start_date"
+	GetSubscriptionsParamsSortPrice          GetSubscriptionsParamsSort = "price"
+	GetSubscriptionsParamsSortStartDate      GetSubscriptionsParamsSort = "start_date"
+)
+
+// Defines values for GetSubscriptionsDeletedParamsSort.
+const (
+	GetSubscriptionsDeletedParamsSortId             GetSubscriptionsDeletedParamsSort = "id"
+	GetSubscriptionsDeletedParamsSortMinusPrice     GetSubscriptionsDeletedParamsSort = "-price"
+	GetSubscriptionsDeletedParamsSortMinusStartDate GetSubscriptionsDeletedParamsSort = "-start_date"
+	GetSubscriptionsDeletedParamsSortPrice          GetSubscriptionsDeletedParamsSort = "price"
+	GetSubscriptionsDeletedParamsSortStartDate      GetSubscriptionsDeletedParamsSort = "start_date"
+)
+
+// Defines values for GetSubscriptionsExportParamsFormat.
+const (
+	Csv    GetSubscriptionsExportParamsFormat = "csv"
+	Ndjson GetSubscriptionsExportParamsFormat = "ndjson"
+	Xlsx   GetSubscriptionsExportParamsFormat = "xlsx"
+)
+
+// Defines values for GetSubscriptionsExportParamsSort.
+const (
+	GetSubscriptionsExportParamsSortId             GetSubscriptionsExportParamsSort = "id"
+	GetSubscriptionsExportParamsSortMinusPrice     GetSubscriptionsExportParamsSort = "-price"
+	GetSubscriptionsExportParamsSortMinusStartDate GetSubscriptionsExportParamsSort = "-start_date"
+	GetSubscriptionsExportParamsSortPrice          GetSubscriptionsExportParamsSort = "price"
+	GetSubscriptionsExportParamsSortStartDate      GetSubscriptionsExportParamsSort = "start_date"
+)
+
+// Defines values for PostSubscriptionsImportParamsMode.
+const (
+	Atomic     PostSubscriptionsImportParamsMode = "atomic"
+	BestEffort PostSubscriptionsImportParamsMode = "best_effort"
+)
+
+// Defines values for GetUsersIdSubscriptionsParamsSort.
+const (
+	Id             GetUsersIdSubscriptionsParamsSort = "id"
+	MinusPrice     GetUsersIdSubscriptionsParamsSort = "-price"
+	MinusStartDate GetUsersIdSubscriptionsParamsSort = "-start_date"
+	Price          GetUsersIdSubscriptionsParamsSort = "price"
+	StartDate      GetUsersIdSubscriptionsParamsSort = "start_date"
+)
+
+// APIKey The key itself is never returned after it is issued, hint holds its first characters.
+type APIKey struct {
+	CreatedAt  time.Time          `json:"created_at"`
+	ExpiresAt  *time.Time         `json:"expires_at,omitempty"`
+	Hint       string             `json:"hint"`
+	Id         openapi_types.UUID `json:"id"`
+	LastUsedAt *time.Time         `json:"last_used_at,omitempty"`
+	Name       string             `json:"name"`
+	RevokedAt  *time.Time         `json:"revoked_at,omitempty"`
+	Scopes     []APIKeyScope      `json:"scopes"`
+
+	// TenantId Tenant the key is bound to, the tenant of the request that issued it.
+	TenantId string             `json:"tenant_id"`
+	UserId   openapi_types.UUID `json:"user_id"`
+}
+
+// APIKeyScope read only allows GET requests, write also allows changes and admin grants the admin role.
+type APIKeyScope string
+
+// AddOrUpdateCatalogService defines model for AddOrUpdateCatalogService.
+type AddOrUpdateCatalogService struct {
+	Aliases      *[]string `json:"aliases,omitempty"`
+	Category     *string   `json:"category,omitempty"`
+	DefaultPrice *Money    `json:"default_price,omitempty"`
+	Name         string    `json:"name"`
+}
+
 // AddOrUpdateSubscription defines model for AddOrUpdateSubscription.
 type AddOrUpdateSubscription struct {
-	EndDate     *openapi_types.Date `json:"end_date"`
-	Price       int64               `json:"price"`
-	ServiceName string              `json:"service_name"`
-	StartDate   openapi_types.Date  `json:"start_date"`
-	UserId      openapi_types.UUID  `json:"user_id"`
+	// BillingPeriod How often the subscription is charged. Defaults to monthly when omitted.
+	BillingPeriod *BillingPeriod `json:"billing_period,omitempty"`
+
+	// Currency ISO 4217 currency code. Defaults to RUB when omitted.
+	Currency *Currency           `json:"currency,omitempty"`
+	EndDate  *openapi_types.Date `json:"end_date"`
+
+	// Price Price in whole units of the currency. Either price or price_minor is required.
+	Price *int64 `json:"price,omitempty"`
+
+	// PriceMinor Price in minor units of the currency (e.g. kopecks for RUB), use it for prices with a fraction.
+	PriceMinor  *int64             `json:"price_minor,omitempty"`
+	ServiceName string             `json:"service_name"`
+	StartDate   openapi_types.Date `json:"start_date"`
+	UserId      openapi_types.UUID `json:"user_id"`
+}
+
+// AddOrUpdateUser defines model for AddOrUpdateUser.
+type AddOrUpdateUser struct {
+	DisplayName string              `json:"display_name"`
+	Email       openapi_types.Email `json:"email"`
+
+	// Timezone IANA timezone name, defaults to UTC.
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// AddOrUpdateWebhook defines model for AddOrUpdateWebhook.
+type AddOrUpdateWebhook struct {
+	Events []EventType `json:"events"`
+
+	// Secret Key of the HMAC-SHA256 signature of every delivery.
+	Secret string `json:"secret"`
+
+	// Url An http or https URL whose host resolves to public addresses only, loopback and private network targets are rejected.
+	Url string `json:"url"`
+}
+
+// BillingPeriod How often the subscription is charged. Defaults to monthly when omitted.
+type BillingPeriod string
+
+// CatalogService defines model for CatalogService.
+type CatalogService struct {
+	// Aliases Other spellings resolved to this service, case and spacing are ignored.
+	Aliases      []string           `json:"aliases"`
+	Category     *string            `json:"category,omitempty"`
+	DefaultPrice *Money             `json:"default_price,omitempty"`
+	Id           openapi_types.UUID `json:"id"`
+
+	// Name Canonical name stored on subscriptions.
+	Name string `json:"name"`
+}
+
+// Currency ISO 4217 currency code. Defaults to RUB when omitted.
+type Currency = string
+
+// DeadLetter defines model for DeadLetter.
+type DeadLetter struct {
+	Attempts  int                `json:"attempts"`
+	EventId   openapi_types.UUID `json:"event_id"`
+	EventType EventType          `json:"event_type"`
+	FailedAt  time.Time          `json:"failed_at"`
+	Id        int64              `json:"id"`
+	LastError string             `json:"last_error"`
+
+	// Payload Body of the failed delivery.
+	Payload map[string]interface{} `json:"payload"`
 }
 
 // ErrorResponse defines model for ErrorResponse.
@@ -29,55 +228,654 @@ type ErrorResponse struct {
 	Error string `json:"error"`
 }
 
+// EventType defines model for EventType.
+type EventType string
+
+// ImportLine defines model for ImportLine.
+type ImportLine struct {
+	Error *string `json:"error,omitempty"`
+
+	// Line Line number in the uploaded file, starting at 1.
+	Line int `json:"line"`
+
+	// Status skipped marks valid records left out because the atomic import was rejected.
+	Status         ImportLineStatus    `json:"status"`
+	SubscriptionId *openapi_types.UUID `json:"subscription_id,omitempty"`
+}
+
+// ImportLineStatus skipped marks valid records left out because the atomic import was rejected.
+type ImportLineStatus string
+
+// ImportReport defines model for ImportReport.
+type ImportReport struct {
+	// Accepted Number of stored records.
+	Accepted int          `json:"accepted"`
+	Lines    []ImportLine `json:"lines"`
+
+	// Rejected Number of invalid records.
+	Rejected int `json:"rejected"`
+}
+
+// IssueAPIKey defines model for IssueAPIKey.
+type IssueAPIKey struct {
+	// ExpiresAt Omit for a key that never expires.
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"`
+	Name      string        `json:"name"`
+	Scopes    []APIKeyScope `json:"scopes"`
+
+	// UserId User the client acts as.
+	UserId openapi_types.UUID `json:"user_id"`
+}
+
+// IssuedAPIKey defines model for IssuedAPIKey.
+type IssuedAPIKey struct {
+	CreatedAt time.Time          `json:"created_at"`
+	ExpiresAt *time.Time         `json:"expires_at,omitempty"`
+	Hint      string             `json:"hint"`
+	Id        openapi_types.UUID `json:"id"`
+
+	// Key Secret to send in the X-API-Key header, it is shown only once.
+	Key        string        `json:"key"`
+	LastUsedAt *time.Time    `json:"last_used_at,omitempty"`
+	Name       string        `json:"name"`
+	RevokedAt  *time.Time    `json:"revoked_at,omitempty"`
+	Scopes     []APIKeyScope `json:"scopes"`
+
+	// TenantId Tenant the key is bound to, the tenant of the request that issued it.
+	TenantId string             `json:"tenant_id"`
+	UserId   openapi_types.UUID `json:"user_id"`
+}
+
+// Money defines model for Money.
+type Money struct {
+	// Amount Amount in minor units of the currency.
+	Amount int64 `json:"amount"`
+
+	// Currency ISO 4217 currency code. Defaults to RUB when omitted.
+	Currency Currency `json:"currency"`
+}
+
+// ServiceCategory defines model for ServiceCategory.
+type ServiceCategory struct {
+	Category string `json:"category"`
+
+	// ServiceName Canonical name of the service.
+	ServiceName string `json:"service_name"`
+}
+
+// SetServiceCategory defines model for SetServiceCategory.
+type SetServiceCategory struct {
+	Category string `json:"category"`
+}
+
+// SpendingDimension defines model for SpendingDimension.
+type SpendingDimension string
+
+// SpendingGroup Only the dimensions the report is grouped by are set.
+type SpendingGroup struct {
+	// Amount Amount in minor units of the currency.
+	Amount   int64   `json:"amount"`
+	Category *string `json:"category,omitempty"`
+
+	// Charges Number of charges summed up in the amount.
+	Charges int64 `json:"charges"`
+
+	// Currency ISO 4217 currency code. Defaults to RUB when omitted.
+	Currency Currency `json:"currency"`
+
+	// Month First day of the billed month.
+	Month       *openapi_types.Date `json:"month,omitempty"`
+	ServiceName *string             `json:"service_name,omitempty"`
+	UserId      *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// SpendingReport defines model for SpendingReport.
+type SpendingReport struct {
+	EndDate   openapi_types.Date  `json:"end_date"`
+	GroupBy   []SpendingDimension `json:"group_by"`
+	Items     []SpendingGroup     `json:"items"`
+	StartDate *openapi_types.Date `json:"start_date"`
+}
+
 // Subscription defines model for Subscription.
 type Subscription struct {
-	EndDate     *openapi_types.Date `json:"end_date"`
-	Id          openapi_types.UUID  `json:"id"`
-	Price       int64               `json:"price"`
-	ServiceName string              `json:"service_name"`
-	StartDate   openapi_types.Date  `json:"start_date"`
-	UserId      openapi_types.UUID  `json:"user_id"`
+	// BillingPeriod How often the subscription is charged. Defaults to monthly when omitted.
+	BillingPeriod BillingPeriod `json:"billing_period"`
+
+	// Currency ISO 4217 currency code. Defaults to RUB when omitted.
+	Currency Currency `json:"currency"`
+
+	// DeletedAt Set only for soft-deleted subscriptions.
+	DeletedAt *time.Time          `json:"deleted_at"`
+	EndDate   *openapi_types.Date `json:"end_date"`
+	Id        openapi_types.UUID  `json:"id"`
+
+	// Price Price in whole units of the currency, the fraction of a unit is dropped.
+	Price int64 `json:"price"`
+
+	// PriceMinor Price in minor units of the currency (e.g. kopecks for RUB).
+	PriceMinor  int64              `json:"price_minor"`
+	ServiceName string             `json:"service_name"`
+	StartDate   openapi_types.Date `json:"start_date"`
+
+	// TenantId Tenant the subscription belongs to.
+	TenantId string             `json:"tenant_id"`
+	UserId   openapi_types.UUID `json:"user_id"`
+
+	// Version Incremented on every change, the same value is sent in the ETag header.
+	Version int64 `json:"version"`
+}
+
+// SubscriptionChange defines model for SubscriptionChange.
+type SubscriptionChange struct {
+	Action    SubscriptionChangeAction `json:"action"`
+	ChangedAt time.Time                `json:"changed_at"`
+	ChangedBy *string                  `json:"changed_by"`
+	Id        int64                    `json:"id"`
+
+	// NewValue Subscription record after the change.
+	NewValue *map[string]interface{} `json:"new_value"`
+
+	// OldValue Subscription record before the change.
+	OldValue       *map[string]interface{} `json:"old_value"`
+	RequestId      *string                 `json:"request_id"`
+	SubscriptionId openapi_types.UUID      `json:"subscription_id"`
+}
+
+// SubscriptionChangeAction defines model for SubscriptionChange.Action.
+type SubscriptionChangeAction string
+
+// SubscriptionCost defines model for SubscriptionCost.
+type SubscriptionCost struct {
+	// BilledMonths Number of charges within the window, kept for existing clients. Use billed_periods instead.
+	// Deprecated:
+	BilledMonths int `json:"billed_months"`
+
+	// BilledPeriods Number of charges within the window according to the billing period.
+	BilledPeriods int `json:"billed_periods"`
+
+	// BillingPeriod How often the subscription is charged. Defaults to monthly when omitted.
+	BillingPeriod BillingPeriod `json:"billing_period"`
+
+	// Cost Cost within the window in whole units of the currency.
+	Cost int64 `json:"cost"`
+
+	// CostMinor Cost within the window in minor units of the currency.
+	CostMinor int64 `json:"cost_minor"`
+
+	// Currency ISO 4217 currency code. Defaults to RUB when omitted.
+	Currency Currency `json:"currency"`
+
+	// Price Price in whole units of the currency.
+	Price int64 `json:"price"`
+
+	// PriceMinor Price in minor units of the currency.
+	PriceMinor     int64              `json:"price_minor"`
+	ServiceName    string             `json:"service_name"`
+	SubscriptionId openapi_types.UUID `json:"subscription_id"`
+}
+
+// SubscriptionMergePatch defines model for SubscriptionMergePatch.
+type SubscriptionMergePatch struct {
+	// BillingPeriod How often the subscription is charged. Defaults to monthly when omitted.
+	BillingPeriod *BillingPeriod `json:"billing_period,omitempty"`
+
+	// Currency ISO 4217 currency code. Defaults to RUB when omitted.
+	Currency *Currency `json:"currency,omitempty"`
+
+	// EndDate Null removes the end date.
+	EndDate nullable.Nullable[openapi_types.Date] `json:"end_date"`
+
+	// Price Price in whole units of the currency.
+	Price *int64 `json:"price,omitempty"`
+
+	// PriceMinor Price in minor units of the currency (e.g. kopecks for RUB), it can not be combined with price.
+	PriceMinor  *int64              `json:"price_minor,omitempty"`
+	ServiceName *string             `json:"service_name,omitempty"`
+	StartDate   *openapi_types.Date `json:"start_date,omitempty"`
+	UserId      *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// SubscriptionsPage defines model for SubscriptionsPage.
+type SubscriptionsPage struct {
+	Items []Subscription `json:"items"`
+
+	// NextCursor Cursor of the next page, absent on the last page.
+	NextCursor *string `json:"next_cursor"`
 }
 
 // TotalCostResponse defines model for TotalCostResponse.
 type TotalCostResponse struct {
+	Subscriptions *[]SubscriptionCost `json:"subscriptions,omitempty"`
+
+	// TotalCost Total in whole units of the currency. Only set when all billed subscriptions share one currency, use totals instead.
+	// Deprecated:
 	TotalCost *int64 `json:"total_cost,omitempty"`
+
+	// Totals Total cost per currency.
+	Totals *[]Money `json:"totals,omitempty"`
+}
+
+// UpcomingCharge defines model for UpcomingCharge.
+type UpcomingCharge struct {
+	Amount Money `json:"amount"`
+
+	// BillingPeriod How often the subscription is charged. Defaults to monthly when omitted.
+	BillingPeriod  BillingPeriod      `json:"billing_period"`
+	ChargeDate     openapi_types.Date `json:"charge_date"`
+	ServiceName    string             `json:"service_name"`
+	SubscriptionId openapi_types.UUID `json:"subscription_id"`
+}
+
+// UserAccount defines model for UserAccount.
+type UserAccount struct {
+	CreatedAt   time.Time `json:"created_at"`
+	DisplayName string    `json:"display_name"`
+
+	// Email Empty for users created from owners of subscriptions stored before users existed.
+	Email string             `json:"email"`
+	Id    openapi_types.UUID `json:"id"`
+
+	// Timezone IANA timezone name.
+	Timezone string `json:"timezone"`
+}
+
+// UsersPage defines model for UsersPage.
+type UsersPage struct {
+	Items []UserAccount `json:"items"`
+
+	// NextCursor Cursor of the next page, absent on the last page.
+	NextCursor *string `json:"next_cursor"`
+}
+
+// Webhook The secret is never returned.
+type Webhook struct {
+	CreatedAt time.Time          `json:"created_at"`
+	Events    []EventType        `json:"events"`
+	Id        openapi_types.UUID `json:"id"`
+	Url       string             `json:"url"`
+}
+
+// ActiveAtFilter defines model for ActiveAtFilter.
+type ActiveAtFilter = openapi_types.Date
+
+// CategoryFilter defines model for CategoryFilter.
+type CategoryFilter = string
+
+// CurrencyFilter ISO 4217 currency code. Defaults to RUB when omitted.
+type CurrencyFilter = Currency
+
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// MaxPriceFilter defines model for MaxPriceFilter.
+type MaxPriceFilter = int64
+
+// MinPriceFilter defines model for MinPriceFilter.
+type MinPriceFilter = int64
+
+// ServiceNameFilter defines model for ServiceNameFilter.
+type ServiceNameFilter = string
+
+// SubscriptionsSort defines model for SubscriptionsSort.
+type SubscriptionsSort string
+
+// UserIdFilter defines model for UserIdFilter.
+type UserIdFilter = openapi_types.UUID
+
+// GetAnalyticsSpendingParams defines parameters for GetAnalyticsSpending.
+type GetAnalyticsSpendingParams struct {
+	UserId      *UserIdFilter      `form:"user_id,omitempty" json:"user_id,omitempty"`
+	ServiceName *ServiceNameFilter `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Only subscriptions to services of this category.
+	Category  *CategoryFilter     `form:"category,omitempty" json:"category,omitempty"`
+	StartDate *openapi_types.Date `form:"start_date,omitempty" json:"start_date,omitempty"`
+
+	// EndDate Defaults to today.
+	EndDate *openapi_types.Date `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// GroupBy Comma-separated dimensions, e.g. group_by=service,month.
+	GroupBy *[]SpendingDimension `form:"group_by,omitempty" json:"group_by,omitempty"`
+}
+
+// GetSubscriptionsParams defines parameters for GetSubscriptions.
+type GetSubscriptionsParams struct {
+	UserId      *UserIdFilter      `form:"user_id,omitempty" json:"user_id,omitempty"`
+	ServiceName *ServiceNameFilter `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Only subscriptions to services of this category.
+	Category *CategoryFilter `form:"category,omitempty" json:"category,omitempty"`
+
+	// ActiveAt Only subscriptions that are active on this date.
+	ActiveAt *ActiveAtFilter `form:"active_at,omitempty" json:"active_at,omitempty"`
+
+	// MinPrice Lower price bound in minor units of currency, inclusive. Requires currency.
+	MinPrice *MinPriceFilter `form:"min_price,omitempty" json:"min_price,omitempty"`
+
+	// MaxPrice Upper price bound in minor units of currency, inclusive. Requires currency.
+	MaxPrice *MaxPriceFilter `form:"max_price,omitempty" json:"max_price,omitempty"`
+
+	// Currency Only subscriptions priced in this currency. Required with min_price or max_price.
+	Currency *CurrencyFilter `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// Sort Sort key, a leading minus sorts in descending order. Defaults to id.
+	Sort *GetSubscriptionsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Cursor next_cursor of the previous page.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *Limit  `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetSubscriptionsParamsSort defines parameters for GetSubscriptions.
+type GetSubscriptionsParamsSort string
+
+// GetSubscriptionsDeletedParams defines parameters for GetSubscriptionsDeleted.
+type GetSubscriptionsDeletedParams struct {
+	UserId      *UserIdFilter      `form:"user_id,omitempty" json:"user_id,omitempty"`
+	ServiceName *ServiceNameFilter `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Sort Sort key, a leading minus sorts in descending order. Defaults to id.
+	Sort *GetSubscriptionsDeletedParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Cursor next_cursor of the previous page.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *Limit  `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetSubscriptionsDeletedParamsSort defines parameters for GetSubscriptionsDeleted.
+type GetSubscriptionsDeletedParamsSort string
+
+// GetSubscriptionsExportParams defines parameters for GetSubscriptionsExport.
+type GetSubscriptionsExportParams struct {
+	Format      *GetSubscriptionsExportParamsFormat `form:"format,omitempty" json:"format,omitempty"`
+	UserId      *UserIdFilter                       `form:"user_id,omitempty" json:"user_id,omitempty"`
+	ServiceName *ServiceNameFilter                  `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Only subscriptions to services of this category.
+	Category *CategoryFilter `form:"category,omitempty" json:"category,omitempty"`
+
+	// ActiveAt Only subscriptions that are active on this date.
+	ActiveAt *ActiveAtFilter `form:"active_at,omitempty" json:"active_at,omitempty"`
+
+	// MinPrice Lower price bound in minor units of currency, inclusive. Requires currency.
+	MinPrice *MinPriceFilter `form:"min_price,omitempty" json:"min_price,omitempty"`
+
+	// MaxPrice Upper price bound in minor units of currency, inclusive. Requires currency.
+	MaxPrice *MaxPriceFilter `form:"max_price,omitempty" json:"max_price,omitempty"`
+
+	// Currency Only subscriptions priced in this currency. Required with min_price or max_price.
+	Currency *CurrencyFilter `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// Sort Sort key, a leading minus sorts in descending order. Defaults to id.
+	Sort   *GetSubscriptionsExportParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+	Accept *string                           `json:"Accept,omitempty"`
+}
+
+// GetSubscriptionsExportParamsFormat defines parameters for GetSubscriptionsExport.
+type GetSubscriptionsExportParamsFormat string
+
+// GetSubscriptionsExportParamsSort defines parameters for GetSubscriptionsExport.
+type GetSubscriptionsExportParamsSort string
+
+// PostSubscriptionsImportParams defines parameters for PostSubscriptionsImport.
+type PostSubscriptionsImportParams struct {
+	Mode *PostSubscriptionsImportParamsMode `form:"mode,omitempty" json:"mode,omitempty"`
 }
 
+// PostSubscriptionsImportParamsMode defines parameters for PostSubscriptionsImport.
+type PostSubscriptionsImportParamsMode string
+
 // GetSubscriptionsTotalCostParams defines parameters for GetSubscriptionsTotalCost.
 type GetSubscriptionsTotalCostParams struct {
-	UserId      openapi_types.UUID  `form:"user_id" json:"user_id"`
-	ServiceName string              `form:"service_name" json:"service_name"`
-	StartDate   *openapi_types.Date `form:"start_date,omitempty" json:"start_date,omitempty"`
-	EndDate     *openapi_types.Date `form:"end_date,omitempty" json:"end_date,omitempty"`
+	UserId      *UserIdFilter      `form:"user_id,omitempty" json:"user_id,omitempty"`
+	ServiceName *ServiceNameFilter `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Only subscriptions to services of this category.
+	Category  *CategoryFilter     `form:"category,omitempty" json:"category,omitempty"`
+	StartDate *openapi_types.Date `form:"start_date,omitempty" json:"start_date,omitempty"`
+	EndDate   *openapi_types.Date `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// Currency Convert every charge to this currency using the exchange rate of its billing date.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// DeleteSubscriptionsIdParams defines parameters for DeleteSubscriptionsId.
+type DeleteSubscriptionsIdParams struct {
+	Hard *bool `form:"hard,omitempty" json:"hard,omitempty"`
+
+	// IfMatch ETag of the subscription the change is based on, the request fails with 412 if it is stale.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PatchSubscriptionsIdParams defines parameters for PatchSubscriptionsId.
+type PatchSubscriptionsIdParams struct {
+	// IfMatch ETag of the subscription the change is based on, the request fails with 412 if it is stale.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// PutSubscriptionsIdParams defines parameters for PutSubscriptionsId.
+type PutSubscriptionsIdParams struct {
+	// IfMatch ETag of the subscription the change is based on, the request fails with 412 if it is stale.
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Cursor next_cursor of the previous page.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *Limit  `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersIdSubscriptionsParams defines parameters for GetUsersIdSubscriptions.
+type GetUsersIdSubscriptionsParams struct {
+	ServiceName *ServiceNameFilter `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Only subscriptions to services of this category.
+	Category *CategoryFilter `form:"category,omitempty" json:"category,omitempty"`
+
+	// ActiveAt Only subscriptions that are active on this date.
+	ActiveAt *ActiveAtFilter `form:"active_at,omitempty" json:"active_at,omitempty"`
+
+	// MinPrice Lower price bound in minor units of currency, inclusive. Requires currency.
+	MinPrice *MinPriceFilter `form:"min_price,omitempty" json:"min_price,omitempty"`
+
+	// MaxPrice Upper price bound in minor units of currency, inclusive. Requires currency.
+	MaxPrice *MaxPriceFilter `form:"max_price,omitempty" json:"max_price,omitempty"`
+
+	// Currency Only subscriptions priced in this currency. Required with min_price or max_price.
+	Currency *CurrencyFilter `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// Sort Sort key, a leading minus sorts in descending order. Defaults to id.
+	Sort *GetUsersIdSubscriptionsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Cursor next_cursor of the previous page.
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit  *Limit  `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetUsersIdSubscriptionsParamsSort defines parameters for GetUsersIdSubscriptions.
+type GetUsersIdSubscriptionsParamsSort string
+
+// GetUsersIdTotalCostParams defines parameters for GetUsersIdTotalCost.
+type GetUsersIdTotalCostParams struct {
+	ServiceName *ServiceNameFilter `form:"service_name,omitempty" json:"service_name,omitempty"`
+
+	// Category Only subscriptions to services of this category.
+	Category  *CategoryFilter     `form:"category,omitempty" json:"category,omitempty"`
+	StartDate *openapi_types.Date `form:"start_date,omitempty" json:"start_date,omitempty"`
+	EndDate   *openapi_types.Date `form:"end_date,omitempty" json:"end_date,omitempty"`
+
+	// Currency Convert every charge to this currency using the exchange rate of its billing date.
+	Currency *Currency `form:"currency,omitempty" json:"currency,omitempty"`
+}
+
+// GetUsersIdUpcomingChargesParams defines parameters for GetUsersIdUpcomingCharges.
+type GetUsersIdUpcomingChargesParams struct {
+	Days *int `form:"days,omitempty" json:"days,omitempty"`
 }
 
+// PostApiKeysJSONRequestBody defines body for PostApiKeys for application/json ContentType.
+type PostApiKeysJSONRequestBody = IssueAPIKey
+
+// PutServiceCategoriesServiceNameJSONRequestBody defines body for PutServiceCategoriesServiceName for application/json ContentType.
+type PutServiceCategoriesServiceNameJSONRequestBody = SetServiceCategory
+
+// PostServicesJSONRequestBody defines body for PostServices for application/json ContentType.
+type PostServicesJSONRequestBody = AddOrUpdateCatalogService
+
+// PutServicesIdJSONRequestBody defines body for PutServicesId for application/json ContentType.
+type PutServicesIdJSONRequestBody = AddOrUpdateCatalogService
+
 // PostSubscriptionsJSONRequestBody defines body for PostSubscriptions for application/json ContentType.
 type PostSubscriptionsJSONRequestBody = AddOrUpdateSubscription
 
-// PatchSubscriptionsIdJSONRequestBody defines body for PatchSubscriptionsId for application/json ContentType.
-type PatchSubscriptionsIdJSONRequestBody = AddOrUpdateSubscription
+// PatchSubscriptionsIdApplicationMergePatchPlusJSONRequestBody defines body for PatchSubscriptionsId for application/merge-patch+json ContentType.
+type PatchSubscriptionsIdApplicationMergePatchPlusJSONRequestBody = SubscriptionMergePatch
+
+// PutSubscriptionsIdJSONRequestBody defines body for PutSubscriptionsId for application/json ContentType.
+type PutSubscriptionsIdJSONRequestBody = AddOrUpdateSubscription
+
+// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
+type PostUsersJSONRequestBody = AddOrUpdateUser
+
+// PutUsersIdJSONRequestBody defines body for PutUsersId for application/json ContentType.
+type PutUsersIdJSONRequestBody = AddOrUpdateUser
+
+// PostWebhooksJSONRequestBody defines body for PostWebhooks for application/json ContentType.
+type PostWebhooksJSONRequestBody = AddOrUpdateWebhook
+
+// PutWebhooksIdJSONRequestBody defines body for PutWebhooksId for application/json ContentType.
+type PutWebhooksIdJSONRequestBody = AddOrUpdateWebhook
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
-	// List all subscriptions
+	// Spending time series grouped by service, user, category and month
+	// (GET /analytics/spending)
+	GetAnalyticsSpending(ctx echo.Context, params GetAnalyticsSpendingParams) error
+	// List api keys, revoked ones included
+	// (GET /api-keys)
+	GetApiKeys(ctx echo.Context) error
+	// Issue an api key for a machine client
+	// (POST /api-keys)
+	PostApiKeys(ctx echo.Context) error
+	// Revoke api key
+	// (DELETE /api-keys/{id})
+	DeleteApiKeysId(ctx echo.Context, id openapi_types.UUID) error
+	// Get api key by ID
+	// (GET /api-keys/{id})
+	GetApiKeysId(ctx echo.Context, id openapi_types.UUID) error
+	// List service categories ordered by category
+	// (GET /service-categories)
+	GetServiceCategories(ctx echo.Context) error
+	// Remove the category of a service
+	// (DELETE /service-categories/{service_name})
+	DeleteServiceCategoriesServiceName(ctx echo.Context, serviceName string) error
+	// Get the category of a service
+	// (GET /service-categories/{service_name})
+	GetServiceCategoriesServiceName(ctx echo.Context, serviceName string) error
+	// Assign a category to a service
+	// (PUT /service-categories/{service_name})
+	PutServiceCategoriesServiceName(ctx echo.Context, serviceName string) error
+	// List the service catalog ordered by name
+	// (GET /services)
+	GetServices(ctx echo.Context) error
+	// Add a service to the catalog
+	// (POST /services)
+	PostServices(ctx echo.Context) error
+	// Remove service from the catalog
+	// (DELETE /services/{id})
+	DeleteServicesId(ctx echo.Context, id openapi_types.UUID) error
+	// Get catalog service by ID
+	// (GET /services/{id})
+	GetServicesId(ctx echo.Context, id openapi_types.UUID) error
+	// Replace catalog service
+	// (PUT /services/{id})
+	PutServicesId(ctx echo.Context, id openapi_types.UUID) error
+	// List subscriptions page by page
 	// (GET /subscriptions)
-	GetSubscriptions(ctx echo.Context) error
+	GetSubscriptions(ctx echo.Context, params GetSubscriptionsParams) error
 	// Add a new subscription
 	// (POST /subscriptions)
 	PostSubscriptions(ctx echo.Context) error
+	// List soft-deleted subscriptions page by page
+	// (GET /subscriptions/deleted)
+	GetSubscriptionsDeleted(ctx echo.Context, params GetSubscriptionsDeletedParams) error
+	// Export subscriptions as a file
+	// (GET /subscriptions/export)
+	GetSubscriptionsExport(ctx echo.Context, params GetSubscriptionsExportParams) error
+	// Import subscriptions in bulk
+	// (POST /subscriptions/import)
+	PostSubscriptionsImport(ctx echo.Context, params PostSubscriptionsImportParams) error
 	// Calculate total cost of subscriptions
 	// (GET /subscriptions/total-cost)
 	GetSubscriptionsTotalCost(ctx echo.Context, params GetSubscriptionsTotalCostParams) error
 	// Delete subscription
 	// (DELETE /subscriptions/{id})
-	DeleteSubscriptionsId(ctx echo.Context, id openapi_types.UUID) error
+	DeleteSubscriptionsId(ctx echo.Context, id openapi_types.UUID, params DeleteSubscriptionsIdParams) error
 	// Get subscription by ID
 	// (GET /subscriptions/{id})
 	GetSubscriptionsId(ctx echo.Context, id openapi_types.UUID) error
-	// Update subscription
+	// Partially update subscription
 	// (PATCH /subscriptions/{id})
-	PatchSubscriptionsId(ctx echo.Context, id openapi_types.UUID) error
+	PatchSubscriptionsId(ctx echo.Context, id openapi_types.UUID, params PatchSubscriptionsIdParams) error
+	// Replace subscription
+	// (PUT /subscriptions/{id})
+	PutSubscriptionsId(ctx echo.Context, id openapi_types.UUID, params PutSubscriptionsIdParams) error
+	// Get the change history of a subscription, oldest first
+	// (GET /subscriptions/{id}/history)
+	GetSubscriptionsIdHistory(ctx echo.Context, id openapi_types.UUID) error
+	// Restore a soft-deleted subscription
+	// (POST /subscriptions/{id}/restore)
+	PostSubscriptionsIdRestore(ctx echo.Context, id openapi_types.UUID) error
+	// List users page by page ordered by id
+	// (GET /users)
+	GetUsers(ctx echo.Context, params GetUsersParams) error
+	// Create user
+	// (POST /users)
+	PostUsers(ctx echo.Context) error
+	// Remove user
+	// (DELETE /users/{id})
+	DeleteUsersId(ctx echo.Context, id openapi_types.UUID) error
+	// Get user by ID
+	// (GET /users/{id})
+	GetUsersId(ctx echo.Context, id openapi_types.UUID) error
+	// Replace user
+	// (PUT /users/{id})
+	PutUsersId(ctx echo.Context, id openapi_types.UUID) error
+	// List subscriptions of the user page by page
+	// (GET /users/{id}/subscriptions)
+	GetUsersIdSubscriptions(ctx echo.Context, id openapi_types.UUID, params GetUsersIdSubscriptionsParams) error
+	// Calculate total cost of the user's subscriptions
+	// (GET /users/{id}/total-cost)
+	GetUsersIdTotalCost(ctx echo.Context, id openapi_types.UUID, params GetUsersIdTotalCostParams) error
+	// List charges of the user's subscriptions due soon
+	// (GET /users/{id}/upcoming-charges)
+	GetUsersIdUpcomingCharges(ctx echo.Context, id openapi_types.UUID, params GetUsersIdUpcomingChargesParams) error
+	// List webhooks
+	// (GET /webhooks)
+	GetWebhooks(ctx echo.Context) error
+	// Subscribe a webhook to subscription events
+	// (POST /webhooks)
+	PostWebhooks(ctx echo.Context) error
+	// Remove webhook together with its dead letters
+	// (DELETE /webhooks/{id})
+	DeleteWebhooksId(ctx echo.Context, id openapi_types.UUID) error
+	// Get webhook by ID
+	// (GET /webhooks/{id})
+	GetWebhooksId(ctx echo.Context, id openapi_types.UUID) error
+	// Replace webhook
+	// (PUT /webhooks/{id})
+	PutWebhooksId(ctx echo.Context, id openapi_types.UUID) error
+	// List events that could not be delivered to the webhook, the latest first
+	// (GET /webhooks/{id}/dead-letters)
+	GetWebhooksIdDeadLetters(ctx echo.Context, id openapi_types.UUID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -85,44 +883,37 @@ type ServerInterfaceWrapper struct {
 	Handler ServerInterface
 }
 
-// GetSubscriptions converts echo context to params.
-func (w *ServerInterfaceWrapper) GetSubscriptions(ctx echo.Context) error {
-	var err error
-
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetSubscriptions(ctx)
-	return err
-}
-
-// PostSubscriptions converts echo context to params.
-func (w *ServerInterfaceWrapper) PostSubscriptions(ctx echo.Context) error {
+// GetAnalyticsSpending converts echo context to params.
+func (w *ServerInterfaceWrapper) GetAnalyticsSpending(ctx echo.Context) error {
 	var err error
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostSubscriptions(ctx)
-	return err
-}
+	ctx.Set(BearerAuthScopes, []string{})
 
-// GetSubscriptionsTotalCost converts echo context to params.
-func (w *ServerInterfaceWrapper) GetSubscriptionsTotalCost(ctx echo.Context) error {
-	var err error
+	ctx.Set(ApiKeyAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetSubscriptionsTotalCostParams
-	// ------------- Required query parameter "user_id" -------------
+	var params GetAnalyticsSpendingParams
+	// ------------- Optional query parameter "user_id" -------------
 
-	err = runtime.BindQueryParameter("form", true, true, "user_id", ctx.QueryParams(), &params.UserId)
+	err = runtime.BindQueryParameter("form", true, false, "user_id", ctx.QueryParams(), &params.UserId)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
 	}
 
-	// ------------- Required query parameter "service_name" -------------
+	// ------------- Optional query parameter "service_name" -------------
 
-	err = runtime.BindQueryParameter("form", true, true, "service_name", ctx.QueryParams(), &params.ServiceName)
+	err = runtime.BindQueryParameter("form", true, false, "service_name", ctx.QueryParams(), &params.ServiceName)
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
 	}
 
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", ctx.QueryParams(), &params.Category)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter category: %s", err))
+	}
+
 	// ------------- Optional query parameter "start_date" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "start_date", ctx.QueryParams(), &params.StartDate)
@@ -137,29 +928,46 @@ func (w *ServerInterfaceWrapper) GetSubscriptionsTotalCost(ctx echo.Context) err
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end_date: %s", err))
 	}
 
+	// ------------- Optional query parameter "group_by" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "group_by", ctx.QueryParams(), &params.GroupBy)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter group_by: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetSubscriptionsTotalCost(ctx, params)
+	err = w.Handler.GetAnalyticsSpending(ctx, params)
 	return err
 }
 
-// DeleteSubscriptionsId converts echo context to params.
-func (w *ServerInterfaceWrapper) DeleteSubscriptionsId(ctx echo.Context) error {
+// GetApiKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) GetApiKeys(ctx echo.Context) error {
 	var err error
-	// ------------- Path parameter "id" -------------
-	var id openapi_types.UUID
 
-	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
-	}
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteSubscriptionsId(ctx, id)
+	err = w.Handler.GetApiKeys(ctx)
 	return err
 }
 
-// GetSubscriptionsId converts echo context to params.
-func (w *ServerInterfaceWrapper) GetSubscriptionsId(ctx echo.Context) error {
+// PostApiKeys converts echo context to params.
+func (w *ServerInterfaceWrapper) PostApiKeys(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostApiKeys(ctx)
+	return err
+}
+
+// DeleteApiKeysId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteApiKeysId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id openapi_types.UUID
@@ -169,13 +977,17 @@ func (w *ServerInterfaceWrapper) GetSubscriptionsId(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetSubscriptionsId(ctx, id)
+	err = w.Handler.DeleteApiKeysId(ctx, id)
 	return err
 }
 
-// PatchSubscriptionsId converts echo context to params.
-func (w *ServerInterfaceWrapper) PatchSubscriptionsId(ctx echo.Context) error {
+// GetApiKeysId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetApiKeysId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
 	var id openapi_types.UUID
@@ -185,443 +997,3992 @@ func (w *ServerInterfaceWrapper) PatchSubscriptionsId(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PatchSubscriptionsId(ctx, id)
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetApiKeysId(ctx, id)
 	return err
 }
 
-// This is a simple interface which specifies echo.Route addition functions which
-// are present on both echo.Echo and echo.Group, since we want to allow using
-// either of them for path registration
-type EchoRouter interface {
-	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
-}
+// GetServiceCategories converts echo context to params.
+func (w *ServerInterfaceWrapper) GetServiceCategories(ctx echo.Context) error {
+	var err error
 
-// RegisterHandlers adds each server route to the EchoRouter.
-func RegisterHandlers(router EchoRouter, si ServerInterface) {
-	RegisterHandlersWithBaseURL(router, si, "")
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetServiceCategories(ctx)
+	return err
 }
 
-// Registers handlers, and prepends BaseURL to the paths, so that the paths
-// can be served under a prefix.
-func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+// DeleteServiceCategoriesServiceName converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteServiceCategoriesServiceName(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "service_name" -------------
+	var serviceName string
 
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
+	err = runtime.BindStyledParameterWithOptions("simple", "service_name", ctx.Param("service_name"), &serviceName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
 	}
 
-	router.GET(baseURL+"/subscriptions", wrapper.GetSubscriptions)
-	router.POST(baseURL+"/subscriptions", wrapper.PostSubscriptions)
-	router.GET(baseURL+"/subscriptions/total-cost", wrapper.GetSubscriptionsTotalCost)
-	router.DELETE(baseURL+"/subscriptions/:id", wrapper.DeleteSubscriptionsId)
-	router.GET(baseURL+"/subscriptions/:id", wrapper.GetSubscriptionsId)
-	router.PATCH(baseURL+"/subscriptions/:id", wrapper.PatchSubscriptionsId)
+	ctx.Set(BearerAuthScopes, []string{})
 
-}
+	ctx.Set(ApiKeyAuthScopes, []string{})
 
-type GetSubscriptionsRequestObject struct {
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteServiceCategoriesServiceName(ctx, serviceName)
+	return err
 }
 
-type GetSubscriptionsResponseObject interface {
-	VisitGetSubscriptionsResponse(w http.ResponseWriter) error
-}
+// GetServiceCategoriesServiceName converts echo context to params.
+func (w *ServerInterfaceWrapper) GetServiceCategoriesServiceName(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "service_name" -------------
+	var serviceName string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "service_name", ctx.Param("service_name"), &serviceName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
+	}
 
-type GetSubscriptions200JSONResponse []Subscription
+	ctx.Set(BearerAuthScopes, []string{})
 
-func (response GetSubscriptions200JSONResponse) VisitGetSubscriptionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	ctx.Set(ApiKeyAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetServiceCategoriesServiceName(ctx, serviceName)
+	return err
 }
 
-type GetSubscriptions500JSONResponse ErrorResponse
+// PutServiceCategoriesServiceName converts echo context to params.
+func (w *ServerInterfaceWrapper) PutServiceCategoriesServiceName(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "service_name" -------------
+	var serviceName string
 
-func (response GetSubscriptions500JSONResponse) VisitGetSubscriptionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	err = runtime.BindStyledParameterWithOptions("simple", "service_name", ctx.Param("service_name"), &serviceName, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx.Set(BearerAuthScopes, []string{})
 
-type PostSubscriptionsRequestObject struct {
-	Body *PostSubscriptionsJSONRequestBody
-}
+	ctx.Set(ApiKeyAuthScopes, []string{})
 
-type PostSubscriptionsResponseObject interface {
-	VisitPostSubscriptionsResponse(w http.ResponseWriter) error
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutServiceCategoriesServiceName(ctx, serviceName)
+	return err
 }
 
-type PostSubscriptions201JSONResponse Subscription
+// GetServices converts echo context to params.
+func (w *ServerInterfaceWrapper) GetServices(ctx echo.Context) error {
+	var err error
 
-func (response PostSubscriptions201JSONResponse) VisitPostSubscriptionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(201)
+	ctx.Set(BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetServices(ctx)
+	return err
 }
 
-type PostSubscriptions400JSONResponse ErrorResponse
+// PostServices converts echo context to params.
+func (w *ServerInterfaceWrapper) PostServices(ctx echo.Context) error {
+	var err error
 
-func (response PostSubscriptions400JSONResponse) VisitPostSubscriptionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	ctx.Set(BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostServices(ctx)
+	return err
 }
 
-type PostSubscriptions500JSONResponse ErrorResponse
+// DeleteServicesId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteServicesId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-func (response PostSubscriptions500JSONResponse) VisitPostSubscriptionsResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
 
-	return json.NewEncoder(w).Encode(response)
-}
+	ctx.Set(BearerAuthScopes, []string{})
 
-type GetSubscriptionsTotalCostRequestObject struct {
-	Params GetSubscriptionsTotalCostParams
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteServicesId(ctx, id)
+	return err
 }
 
-type GetSubscriptionsTotalCostResponseObject interface {
-	VisitGetSubscriptionsTotalCostResponse(w http.ResponseWriter) error
+// GetServicesId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetServicesId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetServicesId(ctx, id)
+	return err
 }
 
-type GetSubscriptionsTotalCost200JSONResponse TotalCostResponse
+// PutServicesId converts echo context to params.
+func (w *ServerInterfaceWrapper) PutServicesId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
 
-func (response GetSubscriptionsTotalCost200JSONResponse) VisitGetSubscriptionsTotalCostResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
 
-	return json.NewEncoder(w).Encode(response)
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutServicesId(ctx, id)
+	return err
 }
 
-type GetSubscriptionsTotalCost400JSONResponse ErrorResponse
+// GetSubscriptions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSubscriptions(ctx echo.Context) error {
+	var err error
 
-func (response GetSubscriptionsTotalCost400JSONResponse) VisitGetSubscriptionsTotalCostResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	ctx.Set(BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSubscriptionsParams
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", ctx.QueryParams(), &params.ServiceName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", ctx.QueryParams(), &params.Category)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter category: %s", err))
+	}
+
+	// ------------- Optional query parameter "active_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active_at", ctx.QueryParams(), &params.ActiveAt)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter active_at: %s", err))
+	}
+
+	// ------------- Optional query parameter "min_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_price", ctx.QueryParams(), &params.MinPrice)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min_price: %s", err))
+	}
+
+	// ------------- Optional query parameter "max_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_price", ctx.QueryParams(), &params.MaxPrice)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max_price: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSubscriptions(ctx, params)
+	return err
 }
 
-type GetSubscriptionsTotalCost500JSONResponse ErrorResponse
+// PostSubscriptions converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSubscriptions(ctx echo.Context) error {
+	var err error
 
-func (response GetSubscriptionsTotalCost500JSONResponse) VisitGetSubscriptionsTotalCostResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	ctx.Set(BearerAuthScopes, []string{})
 
-	return json.NewEncoder(w).Encode(response)
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSubscriptions(ctx)
+	return err
 }
 
-type DeleteSubscriptionsIdRequestObject struct {
-	Id openapi_types.UUID `json:"id"`
+// GetSubscriptionsDeleted converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSubscriptionsDeleted(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSubscriptionsDeletedParams
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", ctx.QueryParams(), &params.ServiceName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSubscriptionsDeleted(ctx, params)
+	return err
 }
 
-type DeleteSubscriptionsIdResponseObject interface {
-	VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error
+// GetSubscriptionsExport converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSubscriptionsExport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSubscriptionsExportParams
+	// ------------- Optional query parameter "format" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "format", ctx.QueryParams(), &params.Format)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter format: %s", err))
+	}
+
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", ctx.QueryParams(), &params.ServiceName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", ctx.QueryParams(), &params.Category)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter category: %s", err))
+	}
+
+	// ------------- Optional query parameter "active_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active_at", ctx.QueryParams(), &params.ActiveAt)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter active_at: %s", err))
+	}
+
+	// ------------- Optional query parameter "min_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_price", ctx.QueryParams(), &params.MinPrice)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min_price: %s", err))
+	}
+
+	// ------------- Optional query parameter "max_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_price", ctx.QueryParams(), &params.MaxPrice)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max_price: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Accept" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Accept")]; found {
+		var Accept string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Accept, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Accept", valueList[0], &Accept, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Accept: %s", err))
+		}
+
+		params.Accept = &Accept
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSubscriptionsExport(ctx, params)
+	return err
+}
+
+// PostSubscriptionsImport converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSubscriptionsImport(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostSubscriptionsImportParams
+	// ------------- Optional query parameter "mode" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "mode", ctx.QueryParams(), &params.Mode)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter mode: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSubscriptionsImport(ctx, params)
+	return err
+}
+
+// GetSubscriptionsTotalCost converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSubscriptionsTotalCost(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetSubscriptionsTotalCostParams
+	// ------------- Optional query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", ctx.QueryParams(), &params.ServiceName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", ctx.QueryParams(), &params.Category)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter category: %s", err))
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", ctx.QueryParams(), &params.StartDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter start_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", ctx.QueryParams(), &params.EndDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSubscriptionsTotalCost(ctx, params)
+	return err
+}
+
+// DeleteSubscriptionsId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteSubscriptionsId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteSubscriptionsIdParams
+	// ------------- Optional query parameter "hard" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "hard", ctx.QueryParams(), &params.Hard)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter hard: %s", err))
+	}
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteSubscriptionsId(ctx, id, params)
+	return err
+}
+
+// GetSubscriptionsId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSubscriptionsId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSubscriptionsId(ctx, id)
+	return err
+}
+
+// PatchSubscriptionsId converts echo context to params.
+func (w *ServerInterfaceWrapper) PatchSubscriptionsId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchSubscriptionsIdParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PatchSubscriptionsId(ctx, id, params)
+	return err
+}
+
+// PutSubscriptionsId converts echo context to params.
+func (w *ServerInterfaceWrapper) PutSubscriptionsId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PutSubscriptionsIdParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutSubscriptionsId(ctx, id, params)
+	return err
+}
+
+// GetSubscriptionsIdHistory converts echo context to params.
+func (w *ServerInterfaceWrapper) GetSubscriptionsIdHistory(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetSubscriptionsIdHistory(ctx, id)
+	return err
+}
+
+// PostSubscriptionsIdRestore converts echo context to params.
+func (w *ServerInterfaceWrapper) PostSubscriptionsIdRestore(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostSubscriptionsIdRestore(ctx, id)
+	return err
+}
+
+// GetUsers converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsers(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersParams
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsers(ctx, params)
+	return err
+}
+
+// PostUsers converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsers(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsers(ctx)
+	return err
+}
+
+// DeleteUsersId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteUsersId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteUsersId(ctx, id)
+	return err
+}
+
+// GetUsersId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersId(ctx, id)
+	return err
+}
+
+// PutUsersId converts echo context to params.
+func (w *ServerInterfaceWrapper) PutUsersId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutUsersId(ctx, id)
+	return err
+}
+
+// GetUsersIdSubscriptions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersIdSubscriptions(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersIdSubscriptionsParams
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", ctx.QueryParams(), &params.ServiceName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", ctx.QueryParams(), &params.Category)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter category: %s", err))
+	}
+
+	// ------------- Optional query parameter "active_at" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active_at", ctx.QueryParams(), &params.ActiveAt)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter active_at: %s", err))
+	}
+
+	// ------------- Optional query parameter "min_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "min_price", ctx.QueryParams(), &params.MinPrice)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter min_price: %s", err))
+	}
+
+	// ------------- Optional query parameter "max_price" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "max_price", ctx.QueryParams(), &params.MaxPrice)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter max_price: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", ctx.QueryParams(), &params.Sort)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter sort: %s", err))
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", ctx.QueryParams(), &params.Cursor)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter cursor: %s", err))
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersIdSubscriptions(ctx, id, params)
+	return err
+}
+
+// GetUsersIdTotalCost converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersIdTotalCost(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersIdTotalCostParams
+	// ------------- Optional query parameter "service_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "service_name", ctx.QueryParams(), &params.ServiceName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter service_name: %s", err))
+	}
+
+	// ------------- Optional query parameter "category" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "category", ctx.QueryParams(), &params.Category)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter category: %s", err))
+	}
+
+	// ------------- Optional query parameter "start_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "start_date", ctx.QueryParams(), &params.StartDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter start_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "end_date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "end_date", ctx.QueryParams(), &params.EndDate)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter end_date: %s", err))
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "currency", ctx.QueryParams(), &params.Currency)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter currency: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersIdTotalCost(ctx, id, params)
+	return err
+}
+
+// GetUsersIdUpcomingCharges converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersIdUpcomingCharges(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersIdUpcomingChargesParams
+	// ------------- Optional query parameter "days" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "days", ctx.QueryParams(), &params.Days)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter days: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersIdUpcomingCharges(ctx, id, params)
+	return err
+}
+
+// GetWebhooks converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooks(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooks(ctx)
+	return err
+}
+
+// PostWebhooks converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooks(ctx echo.Context) error {
+	var err error
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooks(ctx)
+	return err
+}
+
+// DeleteWebhooksId converts echo context to params.
+func (w *ServerInterfaceWrapper) DeleteWebhooksId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.DeleteWebhooksId(ctx, id)
+	return err
+}
+
+// GetWebhooksId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooksId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooksId(ctx, id)
+	return err
+}
+
+// PutWebhooksId converts echo context to params.
+func (w *ServerInterfaceWrapper) PutWebhooksId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PutWebhooksId(ctx, id)
+	return err
+}
+
+// GetWebhooksIdDeadLetters converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooksIdDeadLetters(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id openapi_types.UUID
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	ctx.Set(BearerAuthScopes, []string{})
+
+	ctx.Set(ApiKeyAuthScopes, []string{})
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooksIdDeadLetters(ctx, id)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.GET(baseURL+"/analytics/spending", wrapper.GetAnalyticsSpending)
+	router.GET(baseURL+"/api-keys", wrapper.GetApiKeys)
+	router.POST(baseURL+"/api-keys", wrapper.PostApiKeys)
+	router.DELETE(baseURL+"/api-keys/:id", wrapper.DeleteApiKeysId)
+	router.GET(baseURL+"/api-keys/:id", wrapper.GetApiKeysId)
+	router.GET(baseURL+"/service-categories", wrapper.GetServiceCategories)
+	router.DELETE(baseURL+"/service-categories/:service_name", wrapper.DeleteServiceCategoriesServiceName)
+	router.GET(baseURL+"/service-categories/:service_name", wrapper.GetServiceCategoriesServiceName)
+	router.PUT(baseURL+"/service-categories/:service_name", wrapper.PutServiceCategoriesServiceName)
+	router.GET(baseURL+"/services", wrapper.GetServices)
+	router.POST(baseURL+"/services", wrapper.PostServices)
+	router.DELETE(baseURL+"/services/:id", wrapper.DeleteServicesId)
+	router.GET(baseURL+"/services/:id", wrapper.GetServicesId)
+	router.PUT(baseURL+"/services/:id", wrapper.PutServicesId)
+	router.GET(baseURL+"/subscriptions", wrapper.GetSubscriptions)
+	router.POST(baseURL+"/subscriptions", wrapper.PostSubscriptions)
+	router.GET(baseURL+"/subscriptions/deleted", wrapper.GetSubscriptionsDeleted)
+	router.GET(baseURL+"/subscriptions/export", wrapper.GetSubscriptionsExport)
+	router.POST(baseURL+"/subscriptions/import", wrapper.PostSubscriptionsImport)
+	router.GET(baseURL+"/subscriptions/total-cost", wrapper.GetSubscriptionsTotalCost)
+	router.DELETE(baseURL+"/subscriptions/:id", wrapper.DeleteSubscriptionsId)
+	router.GET(baseURL+"/subscriptions/:id", wrapper.GetSubscriptionsId)
+	router.PATCH(baseURL+"/subscriptions/:id", wrapper.PatchSubscriptionsId)
+	router.PUT(baseURL+"/subscriptions/:id", wrapper.PutSubscriptionsId)
+	router.GET(baseURL+"/subscriptions/:id/history", wrapper.GetSubscriptionsIdHistory)
+	router.POST(baseURL+"/subscriptions/:id/restore", wrapper.PostSubscriptionsIdRestore)
+	router.GET(baseURL+"/users", wrapper.GetUsers)
+	router.POST(baseURL+"/users", wrapper.PostUsers)
+	router.DELETE(baseURL+"/users/:id", wrapper.DeleteUsersId)
+	router.GET(baseURL+"/users/:id", wrapper.GetUsersId)
+	router.PUT(baseURL+"/users/:id", wrapper.PutUsersId)
+	router.GET(baseURL+"/users/:id/subscriptions", wrapper.GetUsersIdSubscriptions)
+	router.GET(baseURL+"/users/:id/total-cost", wrapper.GetUsersIdTotalCost)
+	router.GET(baseURL+"/users/:id/upcoming-charges", wrapper.GetUsersIdUpcomingCharges)
+	router.GET(baseURL+"/webhooks", wrapper.GetWebhooks)
+	router.POST(baseURL+"/webhooks", wrapper.PostWebhooks)
+	router.DELETE(baseURL+"/webhooks/:id", wrapper.DeleteWebhooksId)
+	router.GET(baseURL+"/webhooks/:id", wrapper.GetWebhooksId)
+	router.PUT(baseURL+"/webhooks/:id", wrapper.PutWebhooksId)
+	router.GET(baseURL+"/webhooks/:id/dead-letters", wrapper.GetWebhooksIdDeadLetters)
+
+}
+
+type GetAnalyticsSpendingRequestObject struct {
+	Params GetAnalyticsSpendingParams
+}
+
+type GetAnalyticsSpendingResponseObject interface {
+	VisitGetAnalyticsSpendingResponse(w http.ResponseWriter) error
+}
+
+type GetAnalyticsSpending200JSONResponse SpendingReport
+
+func (response GetAnalyticsSpending200JSONResponse) VisitGetAnalyticsSpendingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAnalyticsSpending400JSONResponse ErrorResponse
+
+func (response GetAnalyticsSpending400JSONResponse) VisitGetAnalyticsSpendingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetAnalyticsSpending500JSONResponse ErrorResponse
+
+func (response GetAnalyticsSpending500JSONResponse) VisitGetAnalyticsSpendingResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeysRequestObject struct {
+}
+
+type GetApiKeysResponseObject interface {
+	VisitGetApiKeysResponse(w http.ResponseWriter) error
+}
+
+type GetApiKeys200JSONResponse []APIKey
+
+func (response GetApiKeys200JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeys403JSONResponse ErrorResponse
+
+func (response GetApiKeys403JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeys500JSONResponse ErrorResponse
+
+func (response GetApiKeys500JSONResponse) VisitGetApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeysRequestObject struct {
+	Body *PostApiKeysJSONRequestBody
+}
+
+type PostApiKeysResponseObject interface {
+	VisitPostApiKeysResponse(w http.ResponseWriter) error
+}
+
+type PostApiKeys201JSONResponse IssuedAPIKey
+
+func (response PostApiKeys201JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys400JSONResponse ErrorResponse
+
+func (response PostApiKeys400JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys403JSONResponse ErrorResponse
+
+func (response PostApiKeys403JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostApiKeys500JSONResponse ErrorResponse
+
+func (response PostApiKeys500JSONResponse) VisitPostApiKeysResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteApiKeysIdResponseObject interface {
+	VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteApiKeysId204Response struct {
+}
+
+func (response DeleteApiKeysId204Response) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteApiKeysId403JSONResponse ErrorResponse
+
+func (response DeleteApiKeysId403JSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId404JSONResponse ErrorResponse
+
+func (response DeleteApiKeysId404JSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteApiKeysId500JSONResponse ErrorResponse
+
+func (response DeleteApiKeysId500JSONResponse) VisitDeleteApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeysIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetApiKeysIdResponseObject interface {
+	VisitGetApiKeysIdResponse(w http.ResponseWriter) error
+}
+
+type GetApiKeysId200JSONResponse APIKey
+
+func (response GetApiKeysId200JSONResponse) VisitGetApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeysId403JSONResponse ErrorResponse
+
+func (response GetApiKeysId403JSONResponse) VisitGetApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeysId404JSONResponse ErrorResponse
+
+func (response GetApiKeysId404JSONResponse) VisitGetApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetApiKeysId500JSONResponse ErrorResponse
+
+func (response GetApiKeysId500JSONResponse) VisitGetApiKeysIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServiceCategoriesRequestObject struct {
+}
+
+type GetServiceCategoriesResponseObject interface {
+	VisitGetServiceCategoriesResponse(w http.ResponseWriter) error
+}
+
+type GetServiceCategories200JSONResponse []ServiceCategory
+
+func (response GetServiceCategories200JSONResponse) VisitGetServiceCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServiceCategories500JSONResponse ErrorResponse
+
+func (response GetServiceCategories500JSONResponse) VisitGetServiceCategoriesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteServiceCategoriesServiceNameRequestObject struct {
+	ServiceName string `json:"service_name"`
+}
+
+type DeleteServiceCategoriesServiceNameResponseObject interface {
+	VisitDeleteServiceCategoriesServiceNameResponse(w http.ResponseWriter) error
+}
+
+type DeleteServiceCategoriesServiceName204Response struct {
+}
+
+func (response DeleteServiceCategoriesServiceName204Response) VisitDeleteServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteServiceCategoriesServiceName404JSONResponse ErrorResponse
+
+func (response DeleteServiceCategoriesServiceName404JSONResponse) VisitDeleteServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteServiceCategoriesServiceName500JSONResponse ErrorResponse
+
+func (response DeleteServiceCategoriesServiceName500JSONResponse) VisitDeleteServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServiceCategoriesServiceNameRequestObject struct {
+	ServiceName string `json:"service_name"`
+}
+
+type GetServiceCategoriesServiceNameResponseObject interface {
+	VisitGetServiceCategoriesServiceNameResponse(w http.ResponseWriter) error
+}
+
+type GetServiceCategoriesServiceName200JSONResponse ServiceCategory
+
+func (response GetServiceCategoriesServiceName200JSONResponse) VisitGetServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServiceCategoriesServiceName404JSONResponse ErrorResponse
+
+func (response GetServiceCategoriesServiceName404JSONResponse) VisitGetServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServiceCategoriesServiceName500JSONResponse ErrorResponse
+
+func (response GetServiceCategoriesServiceName500JSONResponse) VisitGetServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutServiceCategoriesServiceNameRequestObject struct {
+	ServiceName string `json:"service_name"`
+	Body        *PutServiceCategoriesServiceNameJSONRequestBody
+}
+
+type PutServiceCategoriesServiceNameResponseObject interface {
+	VisitPutServiceCategoriesServiceNameResponse(w http.ResponseWriter) error
+}
+
+type PutServiceCategoriesServiceName200JSONResponse ServiceCategory
+
+func (response PutServiceCategoriesServiceName200JSONResponse) VisitPutServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutServiceCategoriesServiceName400JSONResponse ErrorResponse
+
+func (response PutServiceCategoriesServiceName400JSONResponse) VisitPutServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutServiceCategoriesServiceName404JSONResponse ErrorResponse
+
+func (response PutServiceCategoriesServiceName404JSONResponse) VisitPutServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutServiceCategoriesServiceName500JSONResponse ErrorResponse
+
+func (response PutServiceCategoriesServiceName500JSONResponse) VisitPutServiceCategoriesServiceNameResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServicesRequestObject struct {
+}
+
+type GetServicesResponseObject interface {
+	VisitGetServicesResponse(w http.ResponseWriter) error
+}
+
+type GetServices200JSONResponse []CatalogService
+
+func (response GetServices200JSONResponse) VisitGetServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServices500JSONResponse ErrorResponse
+
+func (response GetServices500JSONResponse) VisitGetServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostServicesRequestObject struct {
+	Body *PostServicesJSONRequestBody
+}
+
+type PostServicesResponseObject interface {
+	VisitPostServicesResponse(w http.ResponseWriter) error
+}
+
+type PostServices201JSONResponse CatalogService
+
+func (response PostServices201JSONResponse) VisitPostServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostServices400JSONResponse ErrorResponse
+
+func (response PostServices400JSONResponse) VisitPostServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostServices409JSONResponse ErrorResponse
+
+func (response PostServices409JSONResponse) VisitPostServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostServices500JSONResponse ErrorResponse
+
+func (response PostServices500JSONResponse) VisitPostServicesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteServicesIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteServicesIdResponseObject interface {
+	VisitDeleteServicesIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteServicesId204Response struct {
+}
+
+func (response DeleteServicesId204Response) VisitDeleteServicesIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteServicesId404JSONResponse ErrorResponse
+
+func (response DeleteServicesId404JSONResponse) VisitDeleteServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteServicesId500JSONResponse ErrorResponse
+
+func (response DeleteServicesId500JSONResponse) VisitDeleteServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServicesIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetServicesIdResponseObject interface {
+	VisitGetServicesIdResponse(w http.ResponseWriter) error
+}
+
+type GetServicesId200JSONResponse CatalogService
+
+func (response GetServicesId200JSONResponse) VisitGetServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServicesId404JSONResponse ErrorResponse
+
+func (response GetServicesId404JSONResponse) VisitGetServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetServicesId500JSONResponse ErrorResponse
+
+func (response GetServicesId500JSONResponse) VisitGetServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutServicesIdRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *PutServicesIdJSONRequestBody
+}
+
+type PutServicesIdResponseObject interface {
+	VisitPutServicesIdResponse(w http.ResponseWriter) error
+}
+
+type PutServicesId200JSONResponse CatalogService
+
+func (response PutServicesId200JSONResponse) VisitPutServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutServicesId400JSONResponse ErrorResponse
+
+func (response PutServicesId400JSONResponse) VisitPutServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutServicesId404JSONResponse ErrorResponse
+
+func (response PutServicesId404JSONResponse) VisitPutServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutServicesId409JSONResponse ErrorResponse
+
+func (response PutServicesId409JSONResponse) VisitPutServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutServicesId500JSONResponse ErrorResponse
+
+func (response PutServicesId500JSONResponse) VisitPutServicesIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsRequestObject struct {
+	Params GetSubscriptionsParams
+}
+
+type GetSubscriptionsResponseObject interface {
+	VisitGetSubscriptionsResponse(w http.ResponseWriter) error
+}
+
+type GetSubscriptions200JSONResponse SubscriptionsPage
+
+func (response GetSubscriptions200JSONResponse) VisitGetSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptions400JSONResponse ErrorResponse
+
+func (response GetSubscriptions400JSONResponse) VisitGetSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptions500JSONResponse ErrorResponse
+
+func (response GetSubscriptions500JSONResponse) VisitGetSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSubscriptionsRequestObject struct {
+	Body *PostSubscriptionsJSONRequestBody
+}
+
+type PostSubscriptionsResponseObject interface {
+	VisitPostSubscriptionsResponse(w http.ResponseWriter) error
+}
+
+type PostSubscriptions201ResponseHeaders struct {
+	ETag string
+}
+
+type PostSubscriptions201JSONResponse struct {
+	Body    Subscription
+	Headers PostSubscriptions201ResponseHeaders
+}
+
+func (response PostSubscriptions201JSONResponse) VisitPostSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostSubscriptions400JSONResponse ErrorResponse
+
+func (response PostSubscriptions400JSONResponse) VisitPostSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSubscriptions500JSONResponse ErrorResponse
+
+func (response PostSubscriptions500JSONResponse) VisitPostSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsDeletedRequestObject struct {
+	Params GetSubscriptionsDeletedParams
+}
+
+type GetSubscriptionsDeletedResponseObject interface {
+	VisitGetSubscriptionsDeletedResponse(w http.ResponseWriter) error
+}
+
+type GetSubscriptionsDeleted200JSONResponse SubscriptionsPage
+
+func (response GetSubscriptionsDeleted200JSONResponse) VisitGetSubscriptionsDeletedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsDeleted400JSONResponse ErrorResponse
+
+func (response GetSubscriptionsDeleted400JSONResponse) VisitGetSubscriptionsDeletedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsDeleted500JSONResponse ErrorResponse
+
+func (response GetSubscriptionsDeleted500JSONResponse) VisitGetSubscriptionsDeletedResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsExportRequestObject struct {
+	Params GetSubscriptionsExportParams
+}
+
+type GetSubscriptionsExportResponseObject interface {
+	VisitGetSubscriptionsExportResponse(w http.ResponseWriter) error
+}
+
+type GetSubscriptionsExport200ResponseHeaders struct {
+	ContentDisposition string
+}
+
+type GetSubscriptionsExport200ApplicationvndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse struct {
+	Body          io.Reader
+	Headers       GetSubscriptionsExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetSubscriptionsExport200ApplicationvndOpenxmlformatsOfficedocumentSpreadsheetmlSheetResponse) VisitGetSubscriptionsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetSubscriptionsExport200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	Headers       GetSubscriptionsExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetSubscriptionsExport200ApplicationxNdjsonResponse) VisitGetSubscriptionsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetSubscriptionsExport200TextcsvResponse struct {
+	Body          io.Reader
+	Headers       GetSubscriptionsExport200ResponseHeaders
+	ContentLength int64
+}
+
+func (response GetSubscriptionsExport200TextcsvResponse) VisitGetSubscriptionsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		w.Header().Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	w.Header().Set("Content-Disposition", fmt.Sprint(response.Headers.ContentDisposition))
+	w.WriteHeader(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(w, response.Body)
+	return err
+}
+
+type GetSubscriptionsExport400JSONResponse ErrorResponse
+
+func (response GetSubscriptionsExport400JSONResponse) VisitGetSubscriptionsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsExport500JSONResponse ErrorResponse
+
+func (response GetSubscriptionsExport500JSONResponse) VisitGetSubscriptionsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSubscriptionsImportRequestObject struct {
+	Params      PostSubscriptionsImportParams
+	ContentType string
+	Body        io.Reader
+}
+
+type PostSubscriptionsImportResponseObject interface {
+	VisitPostSubscriptionsImportResponse(w http.ResponseWriter) error
+}
+
+type PostSubscriptionsImport200JSONResponse ImportReport
+
+func (response PostSubscriptionsImport200JSONResponse) VisitPostSubscriptionsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSubscriptionsImport400JSONResponse ErrorResponse
+
+func (response PostSubscriptionsImport400JSONResponse) VisitPostSubscriptionsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSubscriptionsImport415JSONResponse ErrorResponse
+
+func (response PostSubscriptionsImport415JSONResponse) VisitPostSubscriptionsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(415)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSubscriptionsImport500JSONResponse ErrorResponse
+
+func (response PostSubscriptionsImport500JSONResponse) VisitPostSubscriptionsImportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsTotalCostRequestObject struct {
+	Params GetSubscriptionsTotalCostParams
+}
+
+type GetSubscriptionsTotalCostResponseObject interface {
+	VisitGetSubscriptionsTotalCostResponse(w http.ResponseWriter) error
+}
+
+type GetSubscriptionsTotalCost200JSONResponse TotalCostResponse
+
+func (response GetSubscriptionsTotalCost200JSONResponse) VisitGetSubscriptionsTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsTotalCost400JSONResponse ErrorResponse
+
+func (response GetSubscriptionsTotalCost400JSONResponse) VisitGetSubscriptionsTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsTotalCost500JSONResponse ErrorResponse
+
+func (response GetSubscriptionsTotalCost500JSONResponse) VisitGetSubscriptionsTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSubscriptionsIdRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params DeleteSubscriptionsIdParams
+}
+
+type DeleteSubscriptionsIdResponseObject interface {
+	VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteSubscriptionsId204Response struct {
+}
+
+func (response DeleteSubscriptionsId204Response) VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteSubscriptionsId400JSONResponse ErrorResponse
+
+func (response DeleteSubscriptionsId400JSONResponse) VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSubscriptionsId404JSONResponse ErrorResponse
+
+func (response DeleteSubscriptionsId404JSONResponse) VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSubscriptionsId412JSONResponse ErrorResponse
+
+func (response DeleteSubscriptionsId412JSONResponse) VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteSubscriptionsId500JSONResponse ErrorResponse
+
+func (response DeleteSubscriptionsId500JSONResponse) VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetSubscriptionsIdResponseObject interface {
+	VisitGetSubscriptionsIdResponse(w http.ResponseWriter) error
+}
+
+type GetSubscriptionsId200ResponseHeaders struct {
+	ETag string
+}
+
+type GetSubscriptionsId200JSONResponse struct {
+	Body    Subscription
+	Headers GetSubscriptionsId200ResponseHeaders
+}
+
+func (response GetSubscriptionsId200JSONResponse) VisitGetSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetSubscriptionsId404JSONResponse ErrorResponse
+
+func (response GetSubscriptionsId404JSONResponse) VisitGetSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsId500JSONResponse ErrorResponse
+
+func (response GetSubscriptionsId500JSONResponse) VisitGetSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchSubscriptionsIdRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params PatchSubscriptionsIdParams
+	Body   *PatchSubscriptionsIdApplicationMergePatchPlusJSONRequestBody
+}
+
+type PatchSubscriptionsIdResponseObject interface {
+	VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error
+}
+
+type PatchSubscriptionsId200ResponseHeaders struct {
+	ETag string
+}
+
+type PatchSubscriptionsId200JSONResponse struct {
+	Body    Subscription
+	Headers PatchSubscriptionsId200ResponseHeaders
+}
+
+func (response PatchSubscriptionsId200JSONResponse) VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchSubscriptionsId400JSONResponse ErrorResponse
+
+func (response PatchSubscriptionsId400JSONResponse) VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchSubscriptionsId404JSONResponse ErrorResponse
+
+func (response PatchSubscriptionsId404JSONResponse) VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchSubscriptionsId412JSONResponse ErrorResponse
+
+func (response PatchSubscriptionsId412JSONResponse) VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchSubscriptionsId500JSONResponse ErrorResponse
+
+func (response PatchSubscriptionsId500JSONResponse) VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutSubscriptionsIdRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params PutSubscriptionsIdParams
+	Body   *PutSubscriptionsIdJSONRequestBody
+}
+
+type PutSubscriptionsIdResponseObject interface {
+	VisitPutSubscriptionsIdResponse(w http.ResponseWriter) error
+}
+
+type PutSubscriptionsId200ResponseHeaders struct {
+	ETag string
+}
+
+type PutSubscriptionsId200JSONResponse struct {
+	Body    Subscription
+	Headers PutSubscriptionsId200ResponseHeaders
+}
+
+func (response PutSubscriptionsId200JSONResponse) VisitPutSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PutSubscriptionsId400JSONResponse ErrorResponse
+
+func (response PutSubscriptionsId400JSONResponse) VisitPutSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutSubscriptionsId404JSONResponse ErrorResponse
+
+func (response PutSubscriptionsId404JSONResponse) VisitPutSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutSubscriptionsId412JSONResponse ErrorResponse
+
+func (response PutSubscriptionsId412JSONResponse) VisitPutSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(412)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutSubscriptionsId500JSONResponse ErrorResponse
+
+func (response PutSubscriptionsId500JSONResponse) VisitPutSubscriptionsIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsIdHistoryRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetSubscriptionsIdHistoryResponseObject interface {
+	VisitGetSubscriptionsIdHistoryResponse(w http.ResponseWriter) error
+}
+
+type GetSubscriptionsIdHistory200JSONResponse []SubscriptionChange
+
+func (response GetSubscriptionsIdHistory200JSONResponse) VisitGetSubscriptionsIdHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsIdHistory404JSONResponse ErrorResponse
+
+func (response GetSubscriptionsIdHistory404JSONResponse) VisitGetSubscriptionsIdHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetSubscriptionsIdHistory500JSONResponse ErrorResponse
+
+func (response GetSubscriptionsIdHistory500JSONResponse) VisitGetSubscriptionsIdHistoryResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSubscriptionsIdRestoreRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type PostSubscriptionsIdRestoreResponseObject interface {
+	VisitPostSubscriptionsIdRestoreResponse(w http.ResponseWriter) error
+}
+
+type PostSubscriptionsIdRestore200ResponseHeaders struct {
+	ETag string
+}
+
+type PostSubscriptionsIdRestore200JSONResponse struct {
+	Body    Subscription
+	Headers PostSubscriptionsIdRestore200ResponseHeaders
+}
+
+func (response PostSubscriptionsIdRestore200JSONResponse) VisitPostSubscriptionsIdRestoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PostSubscriptionsIdRestore404JSONResponse ErrorResponse
+
+func (response PostSubscriptionsIdRestore404JSONResponse) VisitPostSubscriptionsIdRestoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostSubscriptionsIdRestore500JSONResponse ErrorResponse
+
+func (response PostSubscriptionsIdRestore500JSONResponse) VisitPostSubscriptionsIdRestoreResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersRequestObject struct {
+	Params GetUsersParams
+}
+
+type GetUsersResponseObject interface {
+	VisitGetUsersResponse(w http.ResponseWriter) error
+}
+
+type GetUsers200JSONResponse UsersPage
+
+func (response GetUsers200JSONResponse) VisitGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsers400JSONResponse ErrorResponse
+
+func (response GetUsers400JSONResponse) VisitGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsers500JSONResponse ErrorResponse
+
+func (response GetUsers500JSONResponse) VisitGetUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsersRequestObject struct {
+	Body *PostUsersJSONRequestBody
+}
+
+type PostUsersResponseObject interface {
+	VisitPostUsersResponse(w http.ResponseWriter) error
+}
+
+type PostUsers201JSONResponse UserAccount
+
+func (response PostUsers201JSONResponse) VisitPostUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsers400JSONResponse ErrorResponse
+
+func (response PostUsers400JSONResponse) VisitPostUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsers409JSONResponse ErrorResponse
+
+func (response PostUsers409JSONResponse) VisitPostUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostUsers500JSONResponse ErrorResponse
+
+func (response PostUsers500JSONResponse) VisitPostUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteUsersIdResponseObject interface {
+	VisitDeleteUsersIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteUsersId204Response struct {
+}
+
+func (response DeleteUsersId204Response) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteUsersId404JSONResponse ErrorResponse
+
+func (response DeleteUsersId404JSONResponse) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersId409JSONResponse ErrorResponse
+
+func (response DeleteUsersId409JSONResponse) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteUsersId500JSONResponse ErrorResponse
+
+func (response DeleteUsersId500JSONResponse) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetUsersIdResponseObject interface {
+	VisitGetUsersIdResponse(w http.ResponseWriter) error
+}
+
+type GetUsersId200JSONResponse UserAccount
+
+func (response GetUsersId200JSONResponse) VisitGetUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersId404JSONResponse ErrorResponse
+
+func (response GetUsersId404JSONResponse) VisitGetUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersId500JSONResponse ErrorResponse
+
+func (response GetUsersId500JSONResponse) VisitGetUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersIdRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *PutUsersIdJSONRequestBody
+}
+
+type PutUsersIdResponseObject interface {
+	VisitPutUsersIdResponse(w http.ResponseWriter) error
+}
+
+type PutUsersId200JSONResponse UserAccount
+
+func (response PutUsersId200JSONResponse) VisitPutUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersId400JSONResponse ErrorResponse
+
+func (response PutUsersId400JSONResponse) VisitPutUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersId404JSONResponse ErrorResponse
+
+func (response PutUsersId404JSONResponse) VisitPutUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersId409JSONResponse ErrorResponse
+
+func (response PutUsersId409JSONResponse) VisitPutUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutUsersId500JSONResponse ErrorResponse
+
+func (response PutUsersId500JSONResponse) VisitPutUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdSubscriptionsRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params GetUsersIdSubscriptionsParams
+}
+
+type GetUsersIdSubscriptionsResponseObject interface {
+	VisitGetUsersIdSubscriptionsResponse(w http.ResponseWriter) error
+}
+
+type GetUsersIdSubscriptions200JSONResponse SubscriptionsPage
+
+func (response GetUsersIdSubscriptions200JSONResponse) VisitGetUsersIdSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdSubscriptions400JSONResponse ErrorResponse
+
+func (response GetUsersIdSubscriptions400JSONResponse) VisitGetUsersIdSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdSubscriptions404JSONResponse ErrorResponse
+
+func (response GetUsersIdSubscriptions404JSONResponse) VisitGetUsersIdSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdSubscriptions500JSONResponse ErrorResponse
+
+func (response GetUsersIdSubscriptions500JSONResponse) VisitGetUsersIdSubscriptionsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdTotalCostRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params GetUsersIdTotalCostParams
+}
+
+type GetUsersIdTotalCostResponseObject interface {
+	VisitGetUsersIdTotalCostResponse(w http.ResponseWriter) error
+}
+
+type GetUsersIdTotalCost200JSONResponse TotalCostResponse
+
+func (response GetUsersIdTotalCost200JSONResponse) VisitGetUsersIdTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdTotalCost400JSONResponse ErrorResponse
+
+func (response GetUsersIdTotalCost400JSONResponse) VisitGetUsersIdTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdTotalCost404JSONResponse ErrorResponse
+
+func (response GetUsersIdTotalCost404JSONResponse) VisitGetUsersIdTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdTotalCost500JSONResponse ErrorResponse
+
+func (response GetUsersIdTotalCost500JSONResponse) VisitGetUsersIdTotalCostResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdUpcomingChargesRequestObject struct {
+	Id     openapi_types.UUID `json:"id"`
+	Params GetUsersIdUpcomingChargesParams
+}
+
+type GetUsersIdUpcomingChargesResponseObject interface {
+	VisitGetUsersIdUpcomingChargesResponse(w http.ResponseWriter) error
+}
+
+type GetUsersIdUpcomingCharges200JSONResponse []UpcomingCharge
+
+func (response GetUsersIdUpcomingCharges200JSONResponse) VisitGetUsersIdUpcomingChargesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdUpcomingCharges400JSONResponse ErrorResponse
+
+func (response GetUsersIdUpcomingCharges400JSONResponse) VisitGetUsersIdUpcomingChargesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdUpcomingCharges404JSONResponse ErrorResponse
+
+func (response GetUsersIdUpcomingCharges404JSONResponse) VisitGetUsersIdUpcomingChargesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetUsersIdUpcomingCharges500JSONResponse ErrorResponse
+
+func (response GetUsersIdUpcomingCharges500JSONResponse) VisitGetUsersIdUpcomingChargesResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksRequestObject struct {
+}
+
+type GetWebhooksResponseObject interface {
+	VisitGetWebhooksResponse(w http.ResponseWriter) error
+}
+
+type GetWebhooks200JSONResponse []Webhook
+
+func (response GetWebhooks200JSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooks500JSONResponse ErrorResponse
+
+func (response GetWebhooks500JSONResponse) VisitGetWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhooksRequestObject struct {
+	Body *PostWebhooksJSONRequestBody
+}
+
+type PostWebhooksResponseObject interface {
+	VisitPostWebhooksResponse(w http.ResponseWriter) error
+}
+
+type PostWebhooks201JSONResponse Webhook
+
+func (response PostWebhooks201JSONResponse) VisitPostWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(201)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhooks400JSONResponse ErrorResponse
+
+func (response PostWebhooks400JSONResponse) VisitPostWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostWebhooks500JSONResponse ErrorResponse
+
+func (response PostWebhooks500JSONResponse) VisitPostWebhooksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhooksIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type DeleteWebhooksIdResponseObject interface {
+	VisitDeleteWebhooksIdResponse(w http.ResponseWriter) error
+}
+
+type DeleteWebhooksId204Response struct {
+}
+
+func (response DeleteWebhooksId204Response) VisitDeleteWebhooksIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type DeleteWebhooksId404JSONResponse ErrorResponse
+
+func (response DeleteWebhooksId404JSONResponse) VisitDeleteWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type DeleteWebhooksId500JSONResponse ErrorResponse
+
+func (response DeleteWebhooksId500JSONResponse) VisitDeleteWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksIdRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetWebhooksIdResponseObject interface {
+	VisitGetWebhooksIdResponse(w http.ResponseWriter) error
+}
+
+type GetWebhooksId200JSONResponse Webhook
+
+func (response GetWebhooksId200JSONResponse) VisitGetWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksId404JSONResponse ErrorResponse
+
+func (response GetWebhooksId404JSONResponse) VisitGetWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksId500JSONResponse ErrorResponse
+
+func (response GetWebhooksId500JSONResponse) VisitGetWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutWebhooksIdRequestObject struct {
+	Id   openapi_types.UUID `json:"id"`
+	Body *PutWebhooksIdJSONRequestBody
+}
+
+type PutWebhooksIdResponseObject interface {
+	VisitPutWebhooksIdResponse(w http.ResponseWriter) error
+}
+
+type PutWebhooksId200JSONResponse Webhook
+
+func (response PutWebhooksId200JSONResponse) VisitPutWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutWebhooksId400JSONResponse ErrorResponse
+
+func (response PutWebhooksId400JSONResponse) VisitPutWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(400)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutWebhooksId404JSONResponse ErrorResponse
+
+func (response PutWebhooksId404JSONResponse) VisitPutWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PutWebhooksId500JSONResponse ErrorResponse
+
+func (response PutWebhooksId500JSONResponse) VisitPutWebhooksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksIdDeadLettersRequestObject struct {
+	Id openapi_types.UUID `json:"id"`
+}
+
+type GetWebhooksIdDeadLettersResponseObject interface {
+	VisitGetWebhooksIdDeadLettersResponse(w http.ResponseWriter) error
+}
+
+type GetWebhooksIdDeadLetters200JSONResponse []DeadLetter
+
+func (response GetWebhooksIdDeadLetters200JSONResponse) VisitGetWebhooksIdDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksIdDeadLetters404JSONResponse ErrorResponse
+
+func (response GetWebhooksIdDeadLetters404JSONResponse) VisitGetWebhooksIdDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(404)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type GetWebhooksIdDeadLetters500JSONResponse ErrorResponse
+
+func (response GetWebhooksIdDeadLetters500JSONResponse) VisitGetWebhooksIdDeadLettersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(500)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Spending time series grouped by service, user, category and month
+	// (GET /analytics/spending)
+	GetAnalyticsSpending(ctx context.Context, request GetAnalyticsSpendingRequestObject) (GetAnalyticsSpendingResponseObject, error)
+	// List api keys, revoked ones included
+	// (GET /api-keys)
+	GetApiKeys(ctx context.Context, request GetApiKeysRequestObject) (GetApiKeysResponseObject, error)
+	// Issue an api key for a machine client
+	// (POST /api-keys)
+	PostApiKeys(ctx context.Context, request PostApiKeysRequestObject) (PostApiKeysResponseObject, error)
+	// Revoke api key
+	// (DELETE /api-keys/{id})
+	DeleteApiKeysId(ctx context.Context, request DeleteApiKeysIdRequestObject) (DeleteApiKeysIdResponseObject, error)
+	// Get api key by ID
+	// (GET /api-keys/{id})
+	GetApiKeysId(ctx context.Context, request GetApiKeysIdRequestObject) (GetApiKeysIdResponseObject, error)
+	// List service categories ordered by category
+	// (GET /service-categories)
+	GetServiceCategories(ctx context.Context, request GetServiceCategoriesRequestObject) (GetServiceCategoriesResponseObject, error)
+	// Remove the category of a service
+	// (DELETE /service-categories/{service_name})
+	DeleteServiceCategoriesServiceName(ctx context.Context, request DeleteServiceCategoriesServiceNameRequestObject) (DeleteServiceCategoriesServiceNameResponseObject, error)
+	// Get the category of a service
+	// (GET /service-categories/{service_name})
+	GetServiceCategoriesServiceName(ctx context.Context, request GetServiceCategoriesServiceNameRequestObject) (GetServiceCategoriesServiceNameResponseObject, error)
+	// Assign a category to a service
+	// (PUT /service-categories/{service_name})
+	PutServiceCategoriesServiceName(ctx context.Context, request PutServiceCategoriesServiceNameRequestObject) (PutServiceCategoriesServiceNameResponseObject, error)
+	// List the service catalog ordered by name
+	// (GET /services)
+	GetServices(ctx context.Context, request GetServicesRequestObject) (GetServicesResponseObject, error)
+	// Add a service to the catalog
+	// (POST /services)
+	PostServices(ctx context.Context, request PostServicesRequestObject) (PostServicesResponseObject, error)
+	// Remove service from the catalog
+	// (DELETE /services/{id})
+	DeleteServicesId(ctx context.Context, request DeleteServicesIdRequestObject) (DeleteServicesIdResponseObject, error)
+	// Get catalog service by ID
+	// (GET /services/{id})
+	GetServicesId(ctx context.Context, request GetServicesIdRequestObject) (GetServicesIdResponseObject, error)
+	// Replace catalog service
+	// (PUT /services/{id})
+	PutServicesId(ctx context.Context, request PutServicesIdRequestObject) (PutServicesIdResponseObject, error)
+	// List subscriptions page by page
+	// (GET /subscriptions)
+	GetSubscriptions(ctx context.Context, request GetSubscriptionsRequestObject) (GetSubscriptionsResponseObject, error)
+	// Add a new subscription
+	// (POST /subscriptions)
+	PostSubscriptions(ctx context.Context, request PostSubscriptionsRequestObject) (PostSubscriptionsResponseObject, error)
+	// List soft-deleted subscriptions page by page
+	// (GET /subscriptions/deleted)
+	GetSubscriptionsDeleted(ctx context.Context, request GetSubscriptionsDeletedRequestObject) (GetSubscriptionsDeletedResponseObject, error)
+	// Export subscriptions as a file
+	// (GET /subscriptions/export)
+	GetSubscriptionsExport(ctx context.Context, request GetSubscriptionsExportRequestObject) (GetSubscriptionsExportResponseObject, error)
+	// Import subscriptions in bulk
+	// (POST /subscriptions/import)
+	PostSubscriptionsImport(ctx context.Context, request PostSubscriptionsImportRequestObject) (PostSubscriptionsImportResponseObject, error)
+	// Calculate total cost of subscriptions
+	// (GET /subscriptions/total-cost)
+	GetSubscriptionsTotalCost(ctx context.Context, request GetSubscriptionsTotalCostRequestObject) (GetSubscriptionsTotalCostResponseObject, error)
+	// Delete subscription
+	// (DELETE /subscriptions/{id})
+	DeleteSubscriptionsId(ctx context.Context, request DeleteSubscriptionsIdRequestObject) (DeleteSubscriptionsIdResponseObject, error)
+	// Get subscription by ID
+	// (GET /subscriptions/{id})
+	GetSubscriptionsId(ctx context.Context, request GetSubscriptionsIdRequestObject) (GetSubscriptionsIdResponseObject, error)
+	// Partially update subscription
+	// (PATCH /subscriptions/{id})
+	PatchSubscriptionsId(ctx context.Context, request PatchSubscriptionsIdRequestObject) (PatchSubscriptionsIdResponseObject, error)
+	// Replace subscription
+	// (PUT /subscriptions/{id})
+	PutSubscriptionsId(ctx context.Context, request PutSubscriptionsIdRequestObject) (PutSubscriptionsIdResponseObject, error)
+	// Get the change history of a subscription, oldest first
+	// (GET /subscriptions/{id}/history)
+	GetSubscriptionsIdHistory(ctx context.Context, request GetSubscriptionsIdHistoryRequestObject) (GetSubscriptionsIdHistoryResponseObject, error)
+	// Restore a soft-deleted subscription
+	// (POST /subscriptions/{id}/restore)
+	PostSubscriptionsIdRestore(ctx context.Context, request PostSubscriptionsIdRestoreRequestObject) (PostSubscriptionsIdRestoreResponseObject, error)
+	// List users page by page ordered by id
+	// (GET /users)
+	GetUsers(ctx context.Context, request GetUsersRequestObject) (GetUsersResponseObject, error)
+	// Create user
+	// (POST /users)
+	PostUsers(ctx context.Context, request PostUsersRequestObject) (PostUsersResponseObject, error)
+	// Remove user
+	// (DELETE /users/{id})
+	DeleteUsersId(ctx context.Context, request DeleteUsersIdRequestObject) (DeleteUsersIdResponseObject, error)
+	// Get user by ID
+	// (GET /users/{id})
+	GetUsersId(ctx context.Context, request GetUsersIdRequestObject) (GetUsersIdResponseObject, error)
+	// Replace user
+	// (PUT /users/{id})
+	PutUsersId(ctx context.Context, request PutUsersIdRequestObject) (PutUsersIdResponseObject, error)
+	// List subscriptions of the user page by page
+	// (GET /users/{id}/subscriptions)
+	GetUsersIdSubscriptions(ctx context.Context, request GetUsersIdSubscriptionsRequestObject) (GetUsersIdSubscriptionsResponseObject, error)
+	// Calculate total cost of the user's subscriptions
+	// (GET /users/{id}/total-cost)
+	GetUsersIdTotalCost(ctx context.Context, request GetUsersIdTotalCostRequestObject) (GetUsersIdTotalCostResponseObject, error)
+	// List charges of the user's subscriptions due soon
+	// (GET /users/{id}/upcoming-charges)
+	GetUsersIdUpcomingCharges(ctx context.Context, request GetUsersIdUpcomingChargesRequestObject) (GetUsersIdUpcomingChargesResponseObject, error)
+	// List webhooks
+	// (GET /webhooks)
+	GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error)
+	// Subscribe a webhook to subscription events
+	// (POST /webhooks)
+	PostWebhooks(ctx context.Context, request PostWebhooksRequestObject) (PostWebhooksResponseObject, error)
+	// Remove webhook together with its dead letters
+	// (DELETE /webhooks/{id})
+	DeleteWebhooksId(ctx context.Context, request DeleteWebhooksIdRequestObject) (DeleteWebhooksIdResponseObject, error)
+	// Get webhook by ID
+	// (GET /webhooks/{id})
+	GetWebhooksId(ctx context.Context, request GetWebhooksIdRequestObject) (GetWebhooksIdResponseObject, error)
+	// Replace webhook
+	// (PUT /webhooks/{id})
+	PutWebhooksId(ctx context.Context, request PutWebhooksIdRequestObject) (PutWebhooksIdResponseObject, error)
+	// List events that could not be delivered to the webhook, the latest first
+	// (GET /webhooks/{id}/dead-letters)
+	GetWebhooksIdDeadLetters(ctx context.Context, request GetWebhooksIdDeadLettersRequestObject) (GetWebhooksIdDeadLettersResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetAnalyticsSpending operation middleware
+func (sh *strictHandler) GetAnalyticsSpending(ctx echo.Context, params GetAnalyticsSpendingParams) error {
+	var request GetAnalyticsSpendingRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAnalyticsSpending(ctx.Request().Context(), request.(GetAnalyticsSpendingRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAnalyticsSpending")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetAnalyticsSpendingResponseObject); ok {
+		return validResponse.VisitGetAnalyticsSpendingResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetApiKeys operation middleware
+func (sh *strictHandler) GetApiKeys(ctx echo.Context) error {
+	var request GetApiKeysRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiKeys(ctx.Request().Context(), request.(GetApiKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetApiKeysResponseObject); ok {
+		return validResponse.VisitGetApiKeysResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostApiKeys operation middleware
+func (sh *strictHandler) PostApiKeys(ctx echo.Context) error {
+	var request PostApiKeysRequestObject
+
+	var body PostApiKeysJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostApiKeys(ctx.Request().Context(), request.(PostApiKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostApiKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostApiKeysResponseObject); ok {
+		return validResponse.VisitPostApiKeysResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteApiKeysId operation middleware
+func (sh *strictHandler) DeleteApiKeysId(ctx echo.Context, id openapi_types.UUID) error {
+	var request DeleteApiKeysIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteApiKeysId(ctx.Request().Context(), request.(DeleteApiKeysIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteApiKeysId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteApiKeysIdResponseObject); ok {
+		return validResponse.VisitDeleteApiKeysIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetApiKeysId operation middleware
+func (sh *strictHandler) GetApiKeysId(ctx echo.Context, id openapi_types.UUID) error {
+	var request GetApiKeysIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetApiKeysId(ctx.Request().Context(), request.(GetApiKeysIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetApiKeysId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetApiKeysIdResponseObject); ok {
+		return validResponse.VisitGetApiKeysIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetServiceCategories operation middleware
+func (sh *strictHandler) GetServiceCategories(ctx echo.Context) error {
+	var request GetServiceCategoriesRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetServiceCategories(ctx.Request().Context(), request.(GetServiceCategoriesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetServiceCategories")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetServiceCategoriesResponseObject); ok {
+		return validResponse.VisitGetServiceCategoriesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteServiceCategoriesServiceName operation middleware
+func (sh *strictHandler) DeleteServiceCategoriesServiceName(ctx echo.Context, serviceName string) error {
+	var request DeleteServiceCategoriesServiceNameRequestObject
+
+	request.ServiceName = serviceName
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteServiceCategoriesServiceName(ctx.Request().Context(), request.(DeleteServiceCategoriesServiceNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteServiceCategoriesServiceName")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteServiceCategoriesServiceNameResponseObject); ok {
+		return validResponse.VisitDeleteServiceCategoriesServiceNameResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetServiceCategoriesServiceName operation middleware
+func (sh *strictHandler) GetServiceCategoriesServiceName(ctx echo.Context, serviceName string) error {
+	var request GetServiceCategoriesServiceNameRequestObject
+
+	request.ServiceName = serviceName
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetServiceCategoriesServiceName(ctx.Request().Context(), request.(GetServiceCategoriesServiceNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetServiceCategoriesServiceName")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetServiceCategoriesServiceNameResponseObject); ok {
+		return validResponse.VisitGetServiceCategoriesServiceNameResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutServiceCategoriesServiceName operation middleware
+func (sh *strictHandler) PutServiceCategoriesServiceName(ctx echo.Context, serviceName string) error {
+	var request PutServiceCategoriesServiceNameRequestObject
+
+	request.ServiceName = serviceName
+
+	var body PutServiceCategoriesServiceNameJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutServiceCategoriesServiceName(ctx.Request().Context(), request.(PutServiceCategoriesServiceNameRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutServiceCategoriesServiceName")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutServiceCategoriesServiceNameResponseObject); ok {
+		return validResponse.VisitPutServiceCategoriesServiceNameResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetServices operation middleware
+func (sh *strictHandler) GetServices(ctx echo.Context) error {
+	var request GetServicesRequestObject
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetServices(ctx.Request().Context(), request.(GetServicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetServices")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetServicesResponseObject); ok {
+		return validResponse.VisitGetServicesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostServices operation middleware
+func (sh *strictHandler) PostServices(ctx echo.Context) error {
+	var request PostServicesRequestObject
+
+	var body PostServicesJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostServices(ctx.Request().Context(), request.(PostServicesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostServices")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostServicesResponseObject); ok {
+		return validResponse.VisitPostServicesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteServicesId operation middleware
+func (sh *strictHandler) DeleteServicesId(ctx echo.Context, id openapi_types.UUID) error {
+	var request DeleteServicesIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteServicesId(ctx.Request().Context(), request.(DeleteServicesIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteServicesId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteServicesIdResponseObject); ok {
+		return validResponse.VisitDeleteServicesIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetServicesId operation middleware
+func (sh *strictHandler) GetServicesId(ctx echo.Context, id openapi_types.UUID) error {
+	var request GetServicesIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetServicesId(ctx.Request().Context(), request.(GetServicesIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetServicesId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetServicesIdResponseObject); ok {
+		return validResponse.VisitGetServicesIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutServicesId operation middleware
+func (sh *strictHandler) PutServicesId(ctx echo.Context, id openapi_types.UUID) error {
+	var request PutServicesIdRequestObject
+
+	request.Id = id
+
+	var body PutServicesIdJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutServicesId(ctx.Request().Context(), request.(PutServicesIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutServicesId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutServicesIdResponseObject); ok {
+		return validResponse.VisitPutServicesIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetSubscriptions operation middleware
+func (sh *strictHandler) GetSubscriptions(ctx echo.Context, params GetSubscriptionsParams) error {
+	var request GetSubscriptionsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubscriptions(ctx.Request().Context(), request.(GetSubscriptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubscriptions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetSubscriptionsResponseObject); ok {
+		return validResponse.VisitGetSubscriptionsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostSubscriptions operation middleware
+func (sh *strictHandler) PostSubscriptions(ctx echo.Context) error {
+	var request PostSubscriptionsRequestObject
+
+	var body PostSubscriptionsJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostSubscriptions(ctx.Request().Context(), request.(PostSubscriptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostSubscriptions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostSubscriptionsResponseObject); ok {
+		return validResponse.VisitPostSubscriptionsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetSubscriptionsDeleted operation middleware
+func (sh *strictHandler) GetSubscriptionsDeleted(ctx echo.Context, params GetSubscriptionsDeletedParams) error {
+	var request GetSubscriptionsDeletedRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubscriptionsDeleted(ctx.Request().Context(), request.(GetSubscriptionsDeletedRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubscriptionsDeleted")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetSubscriptionsDeletedResponseObject); ok {
+		return validResponse.VisitGetSubscriptionsDeletedResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetSubscriptionsExport operation middleware
+func (sh *strictHandler) GetSubscriptionsExport(ctx echo.Context, params GetSubscriptionsExportParams) error {
+	var request GetSubscriptionsExportRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubscriptionsExport(ctx.Request().Context(), request.(GetSubscriptionsExportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubscriptionsExport")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetSubscriptionsExportResponseObject); ok {
+		return validResponse.VisitGetSubscriptionsExportResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostSubscriptionsImport operation middleware
+func (sh *strictHandler) PostSubscriptionsImport(ctx echo.Context, params PostSubscriptionsImportParams) error {
+	var request PostSubscriptionsImportRequestObject
+
+	request.Params = params
+	request.ContentType = ctx.Request().Header.Get("Content-Type")
+
+	request.Body = ctx.Request().Body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostSubscriptionsImport(ctx.Request().Context(), request.(PostSubscriptionsImportRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostSubscriptionsImport")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostSubscriptionsImportResponseObject); ok {
+		return validResponse.VisitPostSubscriptionsImportResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetSubscriptionsTotalCost operation middleware
+func (sh *strictHandler) GetSubscriptionsTotalCost(ctx echo.Context, params GetSubscriptionsTotalCostParams) error {
+	var request GetSubscriptionsTotalCostRequestObject
+
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubscriptionsTotalCost(ctx.Request().Context(), request.(GetSubscriptionsTotalCostRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubscriptionsTotalCost")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetSubscriptionsTotalCostResponseObject); ok {
+		return validResponse.VisitGetSubscriptionsTotalCostResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteSubscriptionsId operation middleware
+func (sh *strictHandler) DeleteSubscriptionsId(ctx echo.Context, id openapi_types.UUID, params DeleteSubscriptionsIdParams) error {
+	var request DeleteSubscriptionsIdRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteSubscriptionsId(ctx.Request().Context(), request.(DeleteSubscriptionsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteSubscriptionsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteSubscriptionsIdResponseObject); ok {
+		return validResponse.VisitDeleteSubscriptionsIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetSubscriptionsId operation middleware
+func (sh *strictHandler) GetSubscriptionsId(ctx echo.Context, id openapi_types.UUID) error {
+	var request GetSubscriptionsIdRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubscriptionsId(ctx.Request().Context(), request.(GetSubscriptionsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubscriptionsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetSubscriptionsIdResponseObject); ok {
+		return validResponse.VisitGetSubscriptionsIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PatchSubscriptionsId operation middleware
+func (sh *strictHandler) PatchSubscriptionsId(ctx echo.Context, id openapi_types.UUID, params PatchSubscriptionsIdParams) error {
+	var request PatchSubscriptionsIdRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	var body PatchSubscriptionsIdApplicationMergePatchPlusJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PatchSubscriptionsId(ctx.Request().Context(), request.(PatchSubscriptionsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PatchSubscriptionsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PatchSubscriptionsIdResponseObject); ok {
+		return validResponse.VisitPatchSubscriptionsIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PutSubscriptionsId operation middleware
+func (sh *strictHandler) PutSubscriptionsId(ctx echo.Context, id openapi_types.UUID, params PutSubscriptionsIdParams) error {
+	var request PutSubscriptionsIdRequestObject
+
+	request.Id = id
+	request.Params = params
+
+	var body PutSubscriptionsIdJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutSubscriptionsId(ctx.Request().Context(), request.(PutSubscriptionsIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutSubscriptionsId")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutSubscriptionsIdResponseObject); ok {
+		return validResponse.VisitPutSubscriptionsIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetSubscriptionsIdHistory operation middleware
+func (sh *strictHandler) GetSubscriptionsIdHistory(ctx echo.Context, id openapi_types.UUID) error {
+	var request GetSubscriptionsIdHistoryRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetSubscriptionsIdHistory(ctx.Request().Context(), request.(GetSubscriptionsIdHistoryRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetSubscriptionsIdHistory")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetSubscriptionsIdHistoryResponseObject); ok {
+		return validResponse.VisitGetSubscriptionsIdHistoryResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostSubscriptionsIdRestore operation middleware
+func (sh *strictHandler) PostSubscriptionsIdRestore(ctx echo.Context, id openapi_types.UUID) error {
+	var request PostSubscriptionsIdRestoreRequestObject
+
+	request.Id = id
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostSubscriptionsIdRestore(ctx.Request().Context(), request.(PostSubscriptionsIdRestoreRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostSubscriptionsIdRestore")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostSubscriptionsIdRestoreResponseObject); ok {
+		return validResponse.VisitPostSubscriptionsIdRestoreResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type DeleteSubscriptionsId204Response struct {
-}
+// GetUsers operation middleware
+func (sh *strictHandler) GetUsers(ctx echo.Context, params GetUsersParams) error {
+	var request GetUsersRequestObject
 
-func (response DeleteSubscriptionsId204Response) VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.WriteHeader(204)
-	return nil
-}
+	request.Params = params
 
-type DeleteSubscriptionsId404JSONResponse ErrorResponse
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsers(ctx.Request().Context(), request.(GetUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsers")
+	}
 
-func (response DeleteSubscriptionsId404JSONResponse) VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetUsersResponseObject); ok {
+		return validResponse.VisitGetUsersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type DeleteSubscriptionsId500JSONResponse ErrorResponse
+// PostUsers operation middleware
+func (sh *strictHandler) PostUsers(ctx echo.Context) error {
+	var request PostUsersRequestObject
 
-func (response DeleteSubscriptionsId500JSONResponse) VisitDeleteSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	var body PostUsersJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostUsers(ctx.Request().Context(), request.(PostUsersRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostUsers")
+	}
 
-type GetSubscriptionsIdRequestObject struct {
-	Id openapi_types.UUID `json:"id"`
-}
+	response, err := handler(ctx, request)
 
-type GetSubscriptionsIdResponseObject interface {
-	VisitGetSubscriptionsIdResponse(w http.ResponseWriter) error
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostUsersResponseObject); ok {
+		return validResponse.VisitPostUsersResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type GetSubscriptionsId200JSONResponse Subscription
-
-func (response GetSubscriptionsId200JSONResponse) VisitGetSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+// DeleteUsersId operation middleware
+func (sh *strictHandler) DeleteUsersId(ctx echo.Context, id openapi_types.UUID) error {
+	var request DeleteUsersIdRequestObject
 
-	return json.NewEncoder(w).Encode(response)
-}
+	request.Id = id
 
-type GetSubscriptionsId404JSONResponse ErrorResponse
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteUsersId(ctx.Request().Context(), request.(DeleteUsersIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteUsersId")
+	}
 
-func (response GetSubscriptionsId404JSONResponse) VisitGetSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(DeleteUsersIdResponseObject); ok {
+		return validResponse.VisitDeleteUsersIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type GetSubscriptionsId500JSONResponse ErrorResponse
+// GetUsersId operation middleware
+func (sh *strictHandler) GetUsersId(ctx echo.Context, id openapi_types.UUID) error {
+	var request GetUsersIdRequestObject
 
-func (response GetSubscriptionsId500JSONResponse) VisitGetSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersId(ctx.Request().Context(), request.(GetUsersIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersId")
+	}
 
-type PatchSubscriptionsIdRequestObject struct {
-	Id   openapi_types.UUID `json:"id"`
-	Body *PatchSubscriptionsIdJSONRequestBody
-}
+	response, err := handler(ctx, request)
 
-type PatchSubscriptionsIdResponseObject interface {
-	VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetUsersIdResponseObject); ok {
+		return validResponse.VisitGetUsersIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type PatchSubscriptionsId200JSONResponse Subscription
+// PutUsersId operation middleware
+func (sh *strictHandler) PutUsersId(ctx echo.Context, id openapi_types.UUID) error {
+	var request PutUsersIdRequestObject
 
-func (response PatchSubscriptionsId200JSONResponse) VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	request.Id = id
 
-	return json.NewEncoder(w).Encode(response)
-}
+	var body PutUsersIdJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
 
-type PatchSubscriptionsId400JSONResponse ErrorResponse
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PutUsersId(ctx.Request().Context(), request.(PutUsersIdRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PutUsersId")
+	}
 
-func (response PatchSubscriptionsId400JSONResponse) VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(400)
+	response, err := handler(ctx, request)
 
-	return json.NewEncoder(w).Encode(response)
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PutUsersIdResponseObject); ok {
+		return validResponse.VisitPutUsersIdResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type PatchSubscriptionsId404JSONResponse ErrorResponse
+// GetUsersIdSubscriptions operation middleware
+func (sh *strictHandler) GetUsersIdSubscriptions(ctx echo.Context, id openapi_types.UUID, params GetUsersIdSubscriptionsParams) error {
+	var request GetUsersIdSubscriptionsRequestObject
 
-func (response PatchSubscriptionsId404JSONResponse) VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(404)
+	request.Id = id
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersIdSubscriptions(ctx.Request().Context(), request.(GetUsersIdSubscriptionsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersIdSubscriptions")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetUsersIdSubscriptionsResponseObject); ok {
+		return validResponse.VisitGetUsersIdSubscriptionsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type PatchSubscriptionsId500JSONResponse ErrorResponse
+// GetUsersIdTotalCost operation middleware
+func (sh *strictHandler) GetUsersIdTotalCost(ctx echo.Context, id openapi_types.UUID, params GetUsersIdTotalCostParams) error {
+	var request GetUsersIdTotalCostRequestObject
 
-func (response PatchSubscriptionsId500JSONResponse) VisitPatchSubscriptionsIdResponse(w http.ResponseWriter) error {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(500)
+	request.Id = id
+	request.Params = params
 
-	return json.NewEncoder(w).Encode(response)
-}
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersIdTotalCost(ctx.Request().Context(), request.(GetUsersIdTotalCostRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersIdTotalCost")
+	}
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
-	// List all subscriptions
-	// (GET /subscriptions)
-	GetSubscriptions(ctx context.Context, request GetSubscriptionsRequestObject) (GetSubscriptionsResponseObject, error)
-	// Add a new subscription
-	// (POST /subscriptions)
-	PostSubscriptions(ctx context.Context, request PostSubscriptionsRequestObject) (PostSubscriptionsResponseObject, error)
-	// Calculate total cost of subscriptions
-	// (GET /subscriptions/total-cost)
-	GetSubscriptionsTotalCost(ctx context.Context, request GetSubscriptionsTotalCostRequestObject) (GetSubscriptionsTotalCostResponseObject, error)
-	// Delete subscription
-	// (DELETE /subscriptions/{id})
-	DeleteSubscriptionsId(ctx context.Context, request DeleteSubscriptionsIdRequestObject) (DeleteSubscriptionsIdResponseObject, error)
-	// Get subscription by ID
-	// (GET /subscriptions/{id})
-	GetSubscriptionsId(ctx context.Context, request GetSubscriptionsIdRequestObject) (GetSubscriptionsIdResponseObject, error)
-	// Update subscription
-	// (PATCH /subscriptions/{id})
-	PatchSubscriptionsId(ctx context.Context, request PatchSubscriptionsIdRequestObject) (PatchSubscriptionsIdResponseObject, error)
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetUsersIdTotalCostResponseObject); ok {
+		return validResponse.VisitGetUsersIdTotalCostResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
-type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+// GetUsersIdUpcomingCharges operation middleware
+func (sh *strictHandler) GetUsersIdUpcomingCharges(ctx echo.Context, id openapi_types.UUID, params GetUsersIdUpcomingChargesParams) error {
+	var request GetUsersIdUpcomingChargesRequestObject
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	request.Id = id
+	request.Params = params
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.GetUsersIdUpcomingCharges(ctx.Request().Context(), request.(GetUsersIdUpcomingChargesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetUsersIdUpcomingCharges")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(GetUsersIdUpcomingChargesResponseObject); ok {
+		return validResponse.VisitGetUsersIdUpcomingChargesResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-// GetSubscriptions operation middleware
-func (sh *strictHandler) GetSubscriptions(ctx echo.Context) error {
-	var request GetSubscriptionsRequestObject
+// GetWebhooks operation middleware
+func (sh *strictHandler) GetWebhooks(ctx echo.Context) error {
+	var request GetWebhooksRequestObject
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetSubscriptions(ctx.Request().Context(), request.(GetSubscriptionsRequestObject))
+		return sh.ssi.GetWebhooks(ctx.Request().Context(), request.(GetWebhooksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetSubscriptions")
+		handler = middleware(handler, "GetWebhooks")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(GetSubscriptionsResponseObject); ok {
-		return validResponse.VisitGetSubscriptionsResponse(ctx.Response())
+	} else if validResponse, ok := response.(GetWebhooksResponseObject); ok {
+		return validResponse.VisitGetWebhooksResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
 	return nil
 }
 
-// PostSubscriptions operation middleware
-func (sh *strictHandler) PostSubscriptions(ctx echo.Context) error {
-	var request PostSubscriptionsRequestObject
+// PostWebhooks operation middleware
+func (sh *strictHandler) PostWebhooks(ctx echo.Context) error {
+	var request PostWebhooksRequestObject
 
-	var body PostSubscriptionsJSONRequestBody
+	var body PostWebhooksJSONRequestBody
 	if err := ctx.Bind(&body); err != nil {
 		return err
 	}
 	request.Body = &body
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PostSubscriptions(ctx.Request().Context(), request.(PostSubscriptionsRequestObject))
+		return sh.ssi.PostWebhooks(ctx.Request().Context(), request.(PostWebhooksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PostSubscriptions")
+		handler = middleware(handler, "PostWebhooks")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(PostSubscriptionsResponseObject); ok {
-		return validResponse.VisitPostSubscriptionsResponse(ctx.Response())
+	} else if validResponse, ok := response.(PostWebhooksResponseObject); ok {
+		return validResponse.VisitPostWebhooksResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
 	return nil
 }
 
-// GetSubscriptionsTotalCost operation middleware
-func (sh *strictHandler) GetSubscriptionsTotalCost(ctx echo.Context, params GetSubscriptionsTotalCostParams) error {
-	var request GetSubscriptionsTotalCostRequestObject
+// DeleteWebhooksId operation middleware
+func (sh *strictHandler) DeleteWebhooksId(ctx echo.Context, id openapi_types.UUID) error {
+	var request DeleteWebhooksIdRequestObject
 
-	request.Params = params
+	request.Id = id
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetSubscriptionsTotalCost(ctx.Request().Context(), request.(GetSubscriptionsTotalCostRequestObject))
+		return sh.ssi.DeleteWebhooksId(ctx.Request().Context(), request.(DeleteWebhooksIdRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetSubscriptionsTotalCost")
+		handler = middleware(handler, "DeleteWebhooksId")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(GetSubscriptionsTotalCostResponseObject); ok {
-		return validResponse.VisitGetSubscriptionsTotalCostResponse(ctx.Response())
+	} else if validResponse, ok := response.(DeleteWebhooksIdResponseObject); ok {
+		return validResponse.VisitDeleteWebhooksIdResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
 	return nil
 }
 
-// DeleteSubscriptionsId operation middleware
-func (sh *strictHandler) DeleteSubscriptionsId(ctx echo.Context, id openapi_types.UUID) error {
-	var request DeleteSubscriptionsIdRequestObject
+// GetWebhooksId operation middleware
+func (sh *strictHandler) GetWebhooksId(ctx echo.Context, id openapi_types.UUID) error {
+	var request GetWebhooksIdRequestObject
 
 	request.Id = id
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteSubscriptionsId(ctx.Request().Context(), request.(DeleteSubscriptionsIdRequestObject))
+		return sh.ssi.GetWebhooksId(ctx.Request().Context(), request.(GetWebhooksIdRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteSubscriptionsId")
+		handler = middleware(handler, "GetWebhooksId")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(DeleteSubscriptionsIdResponseObject); ok {
-		return validResponse.VisitDeleteSubscriptionsIdResponse(ctx.Response())
+	} else if validResponse, ok := response.(GetWebhooksIdResponseObject); ok {
+		return validResponse.VisitGetWebhooksIdResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
 	return nil
 }
 
-// GetSubscriptionsId operation middleware
-func (sh *strictHandler) GetSubscriptionsId(ctx echo.Context, id openapi_types.UUID) error {
-	var request GetSubscriptionsIdRequestObject
+// PutWebhooksId operation middleware
+func (sh *strictHandler) PutWebhooksId(ctx echo.Context, id openapi_types.UUID) error {
+	var request PutWebhooksIdRequestObject
 
 	request.Id = id
 
+	var body PutWebhooksIdJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetSubscriptionsId(ctx.Request().Context(), request.(GetSubscriptionsIdRequestObject))
+		return sh.ssi.PutWebhooksId(ctx.Request().Context(), request.(PutWebhooksIdRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetSubscriptionsId")
+		handler = middleware(handler, "PutWebhooksId")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(GetSubscriptionsIdResponseObject); ok {
-		return validResponse.VisitGetSubscriptionsIdResponse(ctx.Response())
+	} else if validResponse, ok := response.(PutWebhooksIdResponseObject); ok {
+		return validResponse.VisitPutWebhooksIdResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
 	return nil
 }
 
-// PatchSubscriptionsId operation middleware
-func (sh *strictHandler) PatchSubscriptionsId(ctx echo.Context, id openapi_types.UUID) error {
-	var request PatchSubscriptionsIdRequestObject
+// GetWebhooksIdDeadLetters operation middleware
+func (sh *strictHandler) GetWebhooksIdDeadLetters(ctx echo.Context, id openapi_types.UUID) error {
+	var request GetWebhooksIdDeadLettersRequestObject
 
 	request.Id = id
 
-	var body PatchSubscriptionsIdJSONRequestBody
-	if err := ctx.Bind(&body); err != nil {
-		return err
-	}
-	request.Body = &body
-
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.PatchSubscriptionsId(ctx.Request().Context(), request.(PatchSubscriptionsIdRequestObject))
+		return sh.ssi.GetWebhooksIdDeadLetters(ctx.Request().Context(), request.(GetWebhooksIdDeadLettersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "PatchSubscriptionsId")
+		handler = middleware(handler, "GetWebhooksIdDeadLetters")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return err
-	} else if validResponse, ok := response.(PatchSubscriptionsIdResponseObject); ok {
-		return validResponse.VisitPatchSubscriptionsIdResponse(ctx.Response())
+	} else if validResponse, ok := response.(GetWebhooksIdDeadLettersResponseObject); ok {
+		return validResponse.VisitGetWebhooksIdDeadLettersResponse(ctx.Response())
 	} else if response != nil {
 		return fmt.Errorf("unexpected response type: %T", response)
 	}
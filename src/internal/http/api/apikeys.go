@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"effective-mobile/internal/http/middleware"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/service"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h HandlersDependencies) GetApiKeys(ctx context.Context, request GetApiKeysRequestObject) (GetApiKeysResponseObject, error) {
+	const op = "internal.http.api.apikeys.GetApiKeys"
+	log := withReqIDLog(ctx, h.Log)
+
+	keys, err := h.APIKeyService.GetAPIKeys(ctx)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	response := make(GetApiKeys200JSONResponse, len(keys))
+	for i, k := range keys {
+		response[i] = toAPIKeyViewModel(k)
+	}
+
+	log.Info("api keys fetched", slog.String("op", op), slog.Int("count", len(response)))
+	return response, nil
+}
+
+func (h HandlersDependencies) PostApiKeys(ctx context.Context, request PostApiKeysRequestObject) (PostApiKeysResponseObject, error) {
+	const op = "internal.http.api.apikeys.PostApiKeys"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	scopes := make([]models.APIKeyScope, len(request.Body.Scopes))
+	for i, s := range request.Body.Scopes {
+		scopes[i] = models.APIKeyScope(s)
+	}
+
+	issued, err := h.APIKeyService.IssueAPIKey(ctx, service.IssueAPIKeyArgs{
+		Name:      request.Body.Name,
+		UserID:    request.Body.UserId,
+		Scopes:    scopes,
+		ExpiresAt: request.Body.ExpiresAt,
+	})
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	k := toAPIKeyViewModel(issued.APIKey)
+	log.Info("api key issued", slog.String("op", op), slog.Any("api_key_id", k.Id))
+	return PostApiKeys201JSONResponse{
+		Id:         k.Id,
+		TenantId:   k.TenantId,
+		Name:       k.Name,
+		UserId:     k.UserId,
+		Hint:       k.Hint,
+		Scopes:     k.Scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		CreatedAt:  k.CreatedAt,
+		RevokedAt:  k.RevokedAt,
+		Key:        issued.Key,
+	}, nil
+}
+
+func (h HandlersDependencies) GetApiKeysId(ctx context.Context, request GetApiKeysIdRequestObject) (GetApiKeysIdResponseObject, error) {
+	const op = "internal.http.api.apikeys.GetApiKeysId"
+	log := withReqIDLog(ctx, h.Log)
+
+	k, err := h.APIKeyService.FindAPIKeyByID(ctx, request.Id)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("api key fetched", slog.String("op", op), slog.Any("api_key_id", k.ID))
+	return GetApiKeysId200JSONResponse(toAPIKeyViewModel(k)), nil
+}
+
+func (h HandlersDependencies) DeleteApiKeysId(ctx context.Context, request DeleteApiKeysIdRequestObject) (DeleteApiKeysIdResponseObject, error) {
+	const op = "internal.http.api.apikeys.DeleteApiKeysId"
+	log := withReqIDLog(ctx, h.Log)
+
+	if err := h.APIKeyService.RevokeAPIKey(ctx, request.Id); err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("api key revoked", slog.String("op", op), slog.Any("api_key_id", request.Id))
+	return DeleteApiKeysId204Response{}, nil
+}
+
+func toAPIKeyViewModel(k *models.APIKey) APIKey {
+	scopes := make([]APIKeyScope, len(k.Scopes))
+	for i, s := range k.Scopes {
+		scopes[i] = APIKeyScope(s)
+	}
+
+	return APIKey{
+		Id:         k.ID,
+		TenantId:   k.Tenant,
+		Name:       k.Name,
+		UserId:     k.Owner,
+		Hint:       k.Hint,
+		Scopes:     scopes,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		CreatedAt:  k.CreatedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+// apiKeyAuthenticator lets the auth middleware accept api keys, a key acts as its owner within its tenant
+// with the admin role if it has the admin scope and read-only unless it may write.
+type apiKeyAuthenticator struct {
+	keys service.APIKeyService
+}
+
+func (a apiKeyAuthenticator) AuthenticateAPIKey(ctx context.Context, key string) (middleware.Identity, error) {
+	k, err := a.keys.AuthenticateAPIKey(ctx, key)
+	if err != nil {
+		var svcErr *service.ServiceError
+		if errors.As(err, &svcErr) && svcErr.Code == service.ErrUnauthenticated {
+			return middleware.Identity{}, fmt.Errorf("%w: %s", middleware.ErrInvalidCredentials, svcErr.Message)
+		}
+		return middleware.Identity{}, err
+	}
+
+	role := service.RoleUser
+	if k.Allows(models.ScopeAdmin) {
+		role = service.RoleAdmin
+	}
+
+	return middleware.Identity{
+		Subject:  k.Owner.String(),
+		Role:     string(role),
+		ReadOnly: !k.Allows(models.ScopeWrite),
+		APIKeyID: k.ID.String(),
+		Tenant:   k.Tenant,
+	}, nil
+}
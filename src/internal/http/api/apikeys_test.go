@@ -0,0 +1,142 @@
+package api
+
+import (
+	"context"
+	"effective-mobile/internal/config"
+	"effective-mobile/internal/http/middleware"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/service"
+	"effective-mobile/pkg/jwt"
+	"effective-mobile/pkg/ratelimit"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeAPIKeyService knows the keys by their key string.
+type fakeAPIKeyService struct {
+	service.APIKeyService
+	keys map[string]*models.APIKey
+}
+
+func (f fakeAPIKeyService) AuthenticateAPIKey(_ context.Context, key string) (*models.APIKey, error) {
+	k, ok := f.keys[key]
+	if !ok {
+		return nil, service.NewUnauthenticatedError("invalid api key")
+	}
+	return k, nil
+}
+
+// callerRecorder records the principal and the tenant the user service is called with.
+type callerRecorder struct {
+	service.UserService
+	principal service.Principal
+	tenant    models.TenantID
+}
+
+func (f *callerRecorder) FindUserByID(ctx context.Context, id models.PersonID) (*models.User, error) {
+	f.principal, _ = service.PrincipalFromContext(ctx)
+	f.tenant = service.TenantFromContext(ctx)
+	return &models.User{ID: id, DisplayName: "Ann"}, nil
+}
+
+func (f *callerRecorder) RemoveUser(ctx context.Context, id models.PersonID) error {
+	f.principal, _ = service.PrincipalFromContext(ctx)
+	return nil
+}
+
+func TestAPIKeyAuthentication(t *testing.T) {
+	owner := uuid.New()
+	keys := fakeAPIKeyService{keys: map[string]*models.APIKey{
+		"emk_reader": {ID: uuid.New(), Tenant: "acme", Owner: owner, Scopes: []models.APIKeyScope{models.ScopeRead}},
+		"emk_admin":  {ID: uuid.New(), Tenant: "acme", Owner: owner, Scopes: []models.APIKeyScope{models.ScopeAdmin}},
+	}}
+	verifier, err := jwt.NewHS256Verifier([]byte("s3cret"), jwt.Options{})
+	if err != nil {
+		t.Fatalf("NewHS256Verifier() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		key        string
+		wantStatus int
+		wantRole   service.Role
+	}{
+		{name: "reader", method: http.MethodGet, key: "emk_reader", wantStatus: http.StatusOK, wantRole: service.RoleUser},
+		{name: "admin scope", method: http.MethodGet, key: "emk_admin", wantStatus: http.StatusOK, wantRole: service.RoleAdmin},
+		{name: "read-only key writes", method: http.MethodDelete, key: "emk_reader", wantStatus: http.StatusForbidden},
+		{name: "unknown key", method: http.MethodGet, key: "emk_unknown", wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, authMethod := range []string{"none", "gateway", "hs256"} {
+		for _, tt := range tests {
+			t.Run(authMethod+"/"+tt.name, func(t *testing.T) {
+				users := &callerRecorder{}
+				log := slog.New(slog.NewTextHandler(io.Discard, nil))
+				cfg := &config.CRUDConfig{}
+				cfg.HTTPServerConfig.Auth.Method = authMethod
+				cfg.HTTPServerConfig.Auth.UserHeader = "X-User-ID"
+				cfg.HTTPServerConfig.Tenancy.Header = "X-Tenant-ID"
+				cfg.HTTPServerConfig.Tenancy.Default = models.DefaultTenant
+				var v middleware.TokenVerifier
+				if authMethod == "hs256" {
+					v = verifier
+				}
+				srv := NewHTTPServer(log, HandlersDependencies{Log: log, UserService: users, APIKeyService: keys}, v, nil, nil, cfg)
+
+				req := httptest.NewRequest(tt.method, "/users/"+owner.String(), nil)
+				req.Header.Set(middleware.HeaderAPIKey, tt.key)
+				rec := httptest.NewRecorder()
+				srv.e.ServeHTTP(rec, req)
+
+				if rec.Code != tt.wantStatus {
+					t.Fatalf("status = %d, want %d, body: %s", rec.Code, tt.wantStatus, rec.Body.String())
+				}
+				if tt.wantStatus != http.StatusOK {
+					if users.principal != (service.Principal{}) {
+						t.Errorf("service was called as %+v, want the request rejected", users.principal)
+					}
+					return
+				}
+				if want := (service.Principal{UserID: owner, Role: tt.wantRole}); users.principal != want {
+					t.Errorf("principal = %+v, want %+v", users.principal, want)
+				}
+				if users.tenant != "acme" {
+					t.Errorf("tenant = %q, want the tenant of the key", users.tenant)
+				}
+			})
+		}
+	}
+}
+
+func TestRateLimitCountsRejectedCredentials(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	cfg := &config.CRUDConfig{}
+	cfg.HTTPServerConfig.Auth.Method = "hs256"
+	cfg.HTTPServerConfig.RateLimit.Default = config.RouteRateLimit{Requests: 1, Per: time.Hour}
+	verifier, err := jwt.NewHS256Verifier([]byte("s3cret"), jwt.Options{})
+	if err != nil {
+		t.Fatalf("NewHS256Verifier() error = %v", err)
+	}
+	keys := fakeAPIKeyService{keys: map[string]*models.APIKey{}}
+	srv := NewHTTPServer(log, HandlersDependencies{Log: log, UserService: &callerRecorder{}, APIKeyService: keys}, verifier, ratelimit.NewMemoryStore(), nil, cfg)
+
+	// guessing keys must not bypass the quota of the address by failing authentication or by changing the key
+	for i, want := range []int{http.StatusUnauthorized, http.StatusTooManyRequests, http.StatusTooManyRequests} {
+		req := httptest.NewRequest(http.MethodGet, "/users/"+uuid.NewString(), nil)
+		req.Header.Set(middleware.HeaderAPIKey, fmt.Sprintf("emk_guess%d", i))
+		rec := httptest.NewRecorder()
+		srv.e.ServeHTTP(rec, req)
+
+		if rec.Code != want {
+			t.Errorf("request %d: status = %d, want %d", i+1, rec.Code, want)
+		}
+	}
+}
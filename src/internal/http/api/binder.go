@@ -0,0 +1,39 @@
+package api
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEApplicationMergePatchJSON is the media type of JSON Merge Patch documents (RFC 7396).
+const MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+
+// binder decodes JSON Merge Patch bodies on top of echo's default binder. The generated strict handlers bind
+// every request body through echo, and the default binder rejects unknown media types with 415.
+type binder struct {
+	echo.DefaultBinder
+}
+
+func (b binder) Bind(i interface{}, c echo.Context) error {
+	req := c.Request()
+	if !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), MIMEApplicationMergePatchJSON) {
+		return b.DefaultBinder.Bind(i, c)
+	}
+
+	if err := b.BindPathParams(c, i); err != nil {
+		return err
+	}
+	if req.ContentLength == 0 {
+		return nil
+	}
+	if err := c.Echo().JSONSerializer.Deserialize(c, i); err != nil {
+		if he, ok := err.(*echo.HTTPError); ok {
+			return he
+		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/service"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h HandlersDependencies) GetServices(ctx context.Context, request GetServicesRequestObject) (GetServicesResponseObject, error) {
+	const op = "internal.http.api.catalog.GetServices"
+	log := withReqIDLog(ctx, h.Log)
+
+	services, err := h.ServiceCatalog.GetServices(ctx)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	response := make(GetServices200JSONResponse, len(services))
+	for i, svc := range services {
+		response[i] = toCatalogViewModel(svc)
+	}
+
+	log.Info("services fetched", slog.String("op", op), slog.Int("count", len(response)))
+	return response, nil
+}
+
+func (h HandlersDependencies) PostServices(ctx context.Context, request PostServicesRequestObject) (PostServicesResponseObject, error) {
+	const op = "internal.http.api.catalog.PostServices"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	body := request.Body
+	args := service.CreateServiceArgs{Name: body.Name}
+	if body.Aliases != nil {
+		args.Aliases = *body.Aliases
+	}
+	if body.DefaultPrice != nil {
+		args.DefaultPrice = &models.Money{Amount: body.DefaultPrice.Amount, Currency: models.Currency(body.DefaultPrice.Currency)}
+	}
+	if body.Category != nil {
+		args.Category = *body.Category
+	}
+
+	svc, err := h.ServiceCatalog.CreateService(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("service created", slog.String("op", op), slog.Any("service_id", svc.ID))
+	return PostServices201JSONResponse(toCatalogViewModel(svc)), nil
+}
+
+func (h HandlersDependencies) GetServicesId(ctx context.Context, request GetServicesIdRequestObject) (GetServicesIdResponseObject, error) {
+	const op = "internal.http.api.catalog.GetServicesId"
+	log := withReqIDLog(ctx, h.Log)
+
+	svc, err := h.ServiceCatalog.FindServiceByID(ctx, request.Id)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("service fetched", slog.String("op", op), slog.Any("service_id", svc.ID))
+	return GetServicesId200JSONResponse(toCatalogViewModel(svc)), nil
+}
+
+func (h HandlersDependencies) PutServicesId(ctx context.Context, request PutServicesIdRequestObject) (PutServicesIdResponseObject, error) {
+	const op = "internal.http.api.catalog.PutServicesId"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	body := request.Body
+	args := service.UpdateServiceArgs{ServiceID: request.Id, Name: body.Name}
+	if body.Aliases != nil {
+		args.Aliases = *body.Aliases
+	}
+	if body.DefaultPrice != nil {
+		args.DefaultPrice = &models.Money{Amount: body.DefaultPrice.Amount, Currency: models.Currency(body.DefaultPrice.Currency)}
+	}
+	if body.Category != nil {
+		args.Category = *body.Category
+	}
+
+	svc, err := h.ServiceCatalog.UpdateService(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("service replaced", slog.String("op", op), slog.Any("service_id", svc.ID))
+	return PutServicesId200JSONResponse(toCatalogViewModel(svc)), nil
+}
+
+func (h HandlersDependencies) DeleteServicesId(ctx context.Context, request DeleteServicesIdRequestObject) (DeleteServicesIdResponseObject, error) {
+	const op = "internal.http.api.catalog.DeleteServicesId"
+	log := withReqIDLog(ctx, h.Log)
+
+	if err := h.ServiceCatalog.RemoveService(ctx, request.Id); err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("service removed", slog.String("op", op), slog.Any("service_id", request.Id))
+	return DeleteServicesId204Response{}, nil
+}
+
+func toCatalogViewModel(svc *models.Service) CatalogService {
+	vm := CatalogService{
+		Id:      svc.ID,
+		Name:    svc.Name,
+		Aliases: svc.Aliases,
+	}
+	if vm.Aliases == nil {
+		vm.Aliases = []string{}
+	}
+	if svc.DefaultPrice != nil {
+		vm.DefaultPrice = &Money{Amount: svc.DefaultPrice.Amount, Currency: string(svc.DefaultPrice.Currency)}
+	}
+	if svc.Category != "" {
+		vm.Category = &svc.Category
+	}
+
+	return vm
+}
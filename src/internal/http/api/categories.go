@@ -0,0 +1,78 @@
+package api
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h HandlersDependencies) GetServiceCategories(ctx context.Context, request GetServiceCategoriesRequestObject) (GetServiceCategoriesResponseObject, error) {
+	const op = "internal.http.api.categories.GetServiceCategories"
+	log := withReqIDLog(ctx, h.Log)
+
+	mappings, err := h.ServiceCategories.GetServiceCategories(ctx)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	response := make(GetServiceCategories200JSONResponse, len(mappings))
+	for i, m := range mappings {
+		response[i] = toCategoryViewModel(m)
+	}
+
+	log.Info("service categories fetched", slog.String("op", op), slog.Int("count", len(response)))
+	return response, nil
+}
+
+func (h HandlersDependencies) GetServiceCategoriesServiceName(ctx context.Context, request GetServiceCategoriesServiceNameRequestObject) (GetServiceCategoriesServiceNameResponseObject, error) {
+	const op = "internal.http.api.categories.GetServiceCategoriesServiceName"
+	log := withReqIDLog(ctx, h.Log)
+
+	mapping, err := h.ServiceCategories.FindServiceCategory(ctx, request.ServiceName)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("service category fetched", slog.String("op", op), slog.String("service_name", mapping.ServiceName))
+	return GetServiceCategoriesServiceName200JSONResponse(toCategoryViewModel(mapping)), nil
+}
+
+func (h HandlersDependencies) PutServiceCategoriesServiceName(ctx context.Context, request PutServiceCategoriesServiceNameRequestObject) (PutServiceCategoriesServiceNameResponseObject, error) {
+	const op = "internal.http.api.categories.PutServiceCategoriesServiceName"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	mapping, err := h.ServiceCategories.SetServiceCategory(ctx, request.ServiceName, request.Body.Category)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("service category set", slog.String("op", op), slog.String("service_name", mapping.ServiceName))
+	return PutServiceCategoriesServiceName200JSONResponse(toCategoryViewModel(mapping)), nil
+}
+
+func (h HandlersDependencies) DeleteServiceCategoriesServiceName(ctx context.Context, request DeleteServiceCategoriesServiceNameRequestObject) (DeleteServiceCategoriesServiceNameResponseObject, error) {
+	const op = "internal.http.api.categories.DeleteServiceCategoriesServiceName"
+	log := withReqIDLog(ctx, h.Log)
+
+	if err := h.ServiceCategories.RemoveServiceCategory(ctx, request.ServiceName); err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("service category removed", slog.String("op", op), slog.String("service_name", request.ServiceName))
+	return DeleteServiceCategoriesServiceName204Response{}, nil
+}
+
+func toCategoryViewModel(m *models.ServiceCategory) ServiceCategory {
+	return ServiceCategory{
+		ServiceName: m.ServiceName,
+		Category:    m.Category,
+	}
+}
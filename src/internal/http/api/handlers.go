@@ -5,8 +5,16 @@ import (
 	"effective-mobile/internal/http/middleware"
 	"effective-mobile/internal/models"
 	"effective-mobile/internal/service"
+	"effective-mobile/internal/storage"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/labstack/echo/v4"
@@ -16,6 +24,13 @@ import (
 type HandlersDependencies struct {
 	Log                 *slog.Logger
 	SubscriptionService service.SubscriptionService
+	AnalyticsService    service.AnalyticsService
+	ServiceCatalog      service.ServiceCatalog
+	ServiceCategories   service.ServiceCategories
+	UserService         service.UserService
+	RenewalService      service.RenewalService
+	WebhookService      service.WebhookService
+	APIKeyService       service.APIKeyService
 }
 
 func withReqIDLog(ctx context.Context, log *slog.Logger) *slog.Logger {
@@ -40,6 +55,18 @@ func handleServiceError(err error, log *slog.Logger, op string) error {
 		case service.ErrNotFound:
 			log.Warn("resource not found", slog.String("op", op), slog.String("error", svcErr.Message))
 			return echo.NewHTTPError(http.StatusNotFound, ErrorResponse{Error: svcErr.Message})
+		case service.ErrConflict:
+			log.Warn("conflict", slog.String("op", op), slog.String("error", svcErr.Message))
+			return echo.NewHTTPError(http.StatusConflict, ErrorResponse{Error: svcErr.Message})
+		case service.ErrUnauthenticated:
+			log.Warn("unauthenticated", slog.String("op", op), slog.String("error", svcErr.Message))
+			return echo.NewHTTPError(http.StatusUnauthorized, ErrorResponse{Error: svcErr.Message})
+		case service.ErrForbidden:
+			log.Warn("forbidden", slog.String("op", op), slog.String("error", svcErr.Message))
+			return echo.NewHTTPError(http.StatusForbidden, ErrorResponse{Error: svcErr.Message})
+		case service.ErrPreconditionFailed:
+			log.Warn("precondition failed", slog.String("op", op), slog.String("error", svcErr.Message))
+			return echo.NewHTTPError(http.StatusPreconditionFailed, ErrorResponse{Error: svcErr.Message})
 		default:
 			log.Error("internal error", slog.String("op", op), slog.String("error", svcErr.Message))
 			return echo.NewHTTPError(http.StatusInternalServerError, ErrorResponse{Error: "internal server error"})
@@ -54,22 +81,51 @@ func (h HandlersDependencies) GetSubscriptions(ctx context.Context, request GetS
 	const op = "internal.http.api.handlers.GetSubscriptions"
 	log := withReqIDLog(ctx, h.Log)
 
-	allSubs := h.SubscriptionService.GetSubscriptions(ctx)
-	responseModels := make(GetSubscriptions200JSONResponse, len(allSubs))
-	for i, sub := range allSubs {
-		vm := toViewModel(sub)
-		responseModels[i] = Subscription{
-			Id:          vm.Id,
-			Price:       vm.Price,
-			ServiceName: vm.ServiceName,
-			StartDate:   vm.StartDate,
-			EndDate:     vm.EndDate,
-			UserId:      vm.UserId,
-		}
+	params := request.Params
+	args := service.ListSubscriptionsArgs{
+		MinPrice: params.MinPrice,
+		MaxPrice: params.MaxPrice,
+	}
+	if params.Currency != nil {
+		args.Currency = *params.Currency
+	}
+	if params.UserId != nil {
+		args.UserID = *params.UserId
+	}
+	if params.ServiceName != nil {
+		args.Service = *params.ServiceName
+	}
+	if params.Category != nil {
+		args.Category = *params.Category
+	}
+	if params.ActiveAt != nil {
+		args.ActiveAt = &params.ActiveAt.Time
+	}
+	if params.Sort != nil {
+		args.Order = service.SubscriptionsOrder(*params.Sort)
+	}
+	if params.Cursor != nil {
+		args.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		args.Limit = *params.Limit
 	}
 
-	log.Info("subscriptions fetched", slog.String("op", op), slog.Int("count", len(responseModels)))
-	return responseModels, nil
+	page, err := h.SubscriptionService.GetSubscriptions(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	items := make([]Subscription, len(page.Subscriptions))
+	for i, sub := range page.Subscriptions {
+		items[i] = toViewModel(sub)
+	}
+
+	log.Info("subscriptions fetched", slog.String("op", op), slog.Int("count", len(items)))
+	return GetSubscriptions200JSONResponse{
+		Items:      items,
+		NextCursor: page.NextCursor,
+	}, nil
 }
 
 func (h HandlersDependencies) PostSubscriptions(ctx context.Context, request PostSubscriptionsRequestObject) (PostSubscriptionsResponseObject, error) {
@@ -81,17 +137,34 @@ func (h HandlersDependencies) PostSubscriptions(ctx context.Context, request Pos
 		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
 	}
 
+	price, inUnits, err := subscriptionPrice(request.Body.Price, request.Body.PriceMinor)
+	if err != nil {
+		log.Warn("invalid request: ambiguous price", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+	if price == nil {
+		log.Warn("invalid request: price is missing", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "price or price_minor is required"})
+	}
+
 	var endTime *time.Time
 	if endDate := request.Body.EndDate; endDate != nil {
 		endTime = &endDate.Time
 	}
 
 	args := service.CreateNewSubscriptionArgs{
-		UserID:    request.Body.UserId,
-		Service:   request.Body.ServiceName,
-		StartTime: request.Body.StartDate.Time,
-		EndTime:   endTime,
-		PriceRUB:  request.Body.Price,
+		UserID:       request.Body.UserId,
+		Service:      request.Body.ServiceName,
+		StartTime:    request.Body.StartDate.Time,
+		EndTime:      endTime,
+		Price:        *price,
+		PriceInUnits: inUnits,
+	}
+	if request.Body.Currency != nil {
+		args.Currency = models.Currency(*request.Body.Currency)
+	}
+	if request.Body.BillingPeriod != nil {
+		args.BillingPeriod = models.BillingPeriod(*request.Body.BillingPeriod)
 	}
 
 	sub, err := h.SubscriptionService.CreateNewSubscription(ctx, args)
@@ -102,12 +175,103 @@ func (h HandlersDependencies) PostSubscriptions(ctx context.Context, request Pos
 	vm := toViewModel(sub)
 	log.Info("subscription created", slog.String("op", op), slog.Any("subscription_id", sub.ID))
 	return PostSubscriptions201JSONResponse{
-		EndDate:     vm.EndDate,
-		Id:          vm.Id,
-		Price:       vm.Price,
-		ServiceName: vm.ServiceName,
-		StartDate:   vm.StartDate,
-		UserId:      vm.UserId,
+		Body:    vm,
+		Headers: PostSubscriptions201ResponseHeaders{ETag: formatETag(sub.Version)},
+	}, nil
+}
+
+func (h HandlersDependencies) GetSubscriptionsExport(ctx context.Context, request GetSubscriptionsExportRequestObject) (GetSubscriptionsExportResponseObject, error) {
+	const op = "internal.http.api.handlers.GetSubscriptionsExport"
+	log := withReqIDLog(ctx, h.Log)
+
+	params := request.Params
+	args := service.ExportSubscriptionsArgs{
+		MinPrice: params.MinPrice,
+		MaxPrice: params.MaxPrice,
+	}
+	if params.Currency != nil {
+		args.Currency = *params.Currency
+	}
+	if params.Format != nil {
+		args.Format = service.ExportFormat(*params.Format)
+	} else if params.Accept != nil {
+		args.Format = negotiateExportFormat(*params.Accept)
+	}
+	if params.UserId != nil {
+		args.UserID = *params.UserId
+	}
+	if params.ServiceName != nil {
+		args.Service = *params.ServiceName
+	}
+	if params.Category != nil {
+		args.Category = *params.Category
+	}
+	if params.ActiveAt != nil {
+		args.ActiveAt = &params.ActiveAt.Time
+	}
+	if params.Sort != nil {
+		args.Order = service.SubscriptionsOrder(*params.Sort)
+	}
+
+	export, err := h.SubscriptionService.ExportSubscriptions(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("subscriptions export started", slog.String("op", op), slog.String("format", string(export.Format)))
+	return exportResponse{
+		contentType: export.Format.ContentType(),
+		fileName:    export.FileName,
+		write:       export.Write,
+	}, nil
+}
+
+func (h HandlersDependencies) PostSubscriptionsImport(ctx context.Context, request PostSubscriptionsImportRequestObject) (PostSubscriptionsImportResponseObject, error) {
+	const op = "internal.http.api.handlers.PostSubscriptionsImport"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(request.ContentType)
+	args := service.ImportSubscriptionsArgs{Data: request.Body}
+	switch mediaType {
+	case "text/csv":
+		args.Format = service.ImportCSV
+	case "application/x-ndjson", "application/jsonl":
+		args.Format = service.ImportNDJSON
+	default:
+		log.Warn("unsupported import content type", slog.String("op", op), slog.String("content_type", request.ContentType))
+		return PostSubscriptionsImport415JSONResponse{Error: "content type must be text/csv or application/x-ndjson"}, nil
+	}
+	if request.Params.Mode != nil {
+		args.Mode = service.ImportMode(*request.Params.Mode)
+	}
+
+	report, err := h.SubscriptionService.ImportSubscriptions(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	lines := make([]ImportLine, len(report.Lines))
+	for i, l := range report.Lines {
+		lines[i] = ImportLine{
+			Line:           l.Line,
+			Status:         ImportLineStatus(l.Status),
+			SubscriptionId: l.SubscriptionID,
+		}
+		if l.Error != "" {
+			lines[i].Error = &l.Error
+		}
+	}
+
+	log.Info("subscriptions imported", slog.String("op", op), slog.Int("accepted", report.Accepted), slog.Int("rejected", report.Rejected))
+	return PostSubscriptionsImport200JSONResponse{
+		Accepted: report.Accepted,
+		Rejected: report.Rejected,
+		Lines:    lines,
 	}, nil
 }
 
@@ -123,12 +287,8 @@ func (h HandlersDependencies) GetSubscriptionsId(ctx context.Context, request Ge
 	vm := toViewModel(sub)
 	log.Info("subscription fetched", slog.String("op", op), slog.Any("subscription_id", sub.ID))
 	return GetSubscriptionsId200JSONResponse{
-		Id:          vm.Id,
-		EndDate:     vm.EndDate,
-		Price:       vm.Price,
-		ServiceName: vm.ServiceName,
-		StartDate:   vm.StartDate,
-		UserId:      vm.UserId,
+		Body:    vm,
+		Headers: GetSubscriptionsId200ResponseHeaders{ETag: formatETag(sub.Version)},
 	}, nil
 }
 
@@ -145,32 +305,179 @@ func (h HandlersDependencies) GetSubscriptionsTotalCost(ctx context.Context, req
 		countEndTime = &params.EndDate.Time
 	}
 
-	totalPrice, err := h.SubscriptionService.CalculateTotalSubscriptionsPrice(ctx, params.UserId, params.ServiceName, countStartTime, countEndTime)
+	args := service.CalculateTotalPriceArgs{
+		StartTime: countStartTime,
+		EndTime:   countEndTime,
+	}
+	if params.UserId != nil {
+		args.UserID = *params.UserId
+	}
+	if params.ServiceName != nil {
+		args.Service = *params.ServiceName
+	}
+	if params.Category != nil {
+		args.Category = *params.Category
+	}
+	if params.Currency != nil {
+		currency := models.Currency(*params.Currency)
+		args.Currency = &currency
+	}
+
+	response, err := h.totalCost(ctx, op, args)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("total cost calculated", slog.String("op", op), slog.Any("user_id", args.UserID), slog.Int("currencies", len(*response.Totals)))
+	return GetSubscriptionsTotalCost200JSONResponse(response), nil
+}
+
+func (h HandlersDependencies) GetAnalyticsSpending(ctx context.Context, request GetAnalyticsSpendingRequestObject) (GetAnalyticsSpendingResponseObject, error) {
+	const op = "internal.http.api.handlers.GetAnalyticsSpending"
+	log := withReqIDLog(ctx, h.Log)
+
+	params := request.Params
+	var args service.SpendingArgs
+	if params.UserId != nil {
+		args.UserID = *params.UserId
+	}
+	if params.ServiceName != nil {
+		args.Service = *params.ServiceName
+	}
+	if params.Category != nil {
+		args.Category = *params.Category
+	}
+	if params.StartDate != nil {
+		args.StartTime = &params.StartDate.Time
+	}
+	if params.EndDate != nil {
+		args.EndTime = &params.EndDate.Time
+	}
+	if params.GroupBy != nil {
+		for _, d := range *params.GroupBy {
+			args.GroupBy = append(args.GroupBy, storage.SpendingDimension(d))
+		}
+	}
+
+	report, err := h.AnalyticsService.GetSpending(ctx, args)
 	if err != nil {
 		return nil, handleServiceError(err, h.Log, op)
 	}
 
-	log.Info("total cost calculated", slog.String("op", op), slog.Any("user_id", params.UserId), slog.Int64("total_cost", totalPrice.TotalPriceRUB))
-	return GetSubscriptionsTotalCost200JSONResponse{
-		TotalCost: &totalPrice.TotalPriceRUB,
-	}, nil
+	groupBy := make([]SpendingDimension, len(report.GroupBy))
+	for i, d := range report.GroupBy {
+		groupBy[i] = SpendingDimension(d)
+	}
+
+	items := make([]SpendingGroup, len(report.Groups))
+	for i, g := range report.Groups {
+		items[i] = SpendingGroup{
+			Amount:      g.Amount.Amount,
+			Currency:    string(g.Amount.Currency),
+			Charges:     g.Charges,
+			ServiceName: g.ServiceName,
+			UserId:      g.UserID,
+			Category:    g.Category,
+		}
+		if g.Month != nil {
+			items[i].Month = &openapi_types.Date{Time: g.Month.UTC()}
+		}
+	}
+
+	response := GetAnalyticsSpending200JSONResponse{
+		EndDate: openapi_types.Date{Time: report.To},
+		GroupBy: groupBy,
+		Items:   items,
+	}
+	if !report.From.IsZero() {
+		response.StartDate = &openapi_types.Date{Time: report.From}
+	}
+
+	log.Info("spending calculated", slog.String("op", op), slog.Int("groups", len(items)))
+	return response, nil
 }
 
 func (h HandlersDependencies) DeleteSubscriptionsId(ctx context.Context, request DeleteSubscriptionsIdRequestObject) (DeleteSubscriptionsIdResponseObject, error) {
 	const op = "internal.http.api.handlers.DeleteSubscriptionsId"
 	log := withReqIDLog(ctx, h.Log)
 
-	err := h.SubscriptionService.RemoveExistingSubscription(ctx, request.Id)
+	hard := request.Params.Hard != nil && *request.Params.Hard
+	ifMatch, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		log.Warn("invalid If-Match header", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+
+	if hard {
+		err = h.SubscriptionService.PurgeSubscription(ctx, request.Id, ifMatch)
+	} else {
+		err = h.SubscriptionService.RemoveExistingSubscription(ctx, request.Id, ifMatch)
+	}
 	if err != nil {
 		return nil, handleServiceError(err, h.Log, op)
 	}
 
-	log.Info("subscription deleted", slog.String("op", op), slog.Any("subscription_id", request.Id))
+	log.Info("subscription deleted", slog.String("op", op), slog.Any("subscription_id", request.Id), slog.Bool("hard", hard))
 	return DeleteSubscriptionsId204Response{}, nil
 }
 
-func (h HandlersDependencies) PatchSubscriptionsId(ctx context.Context, request PatchSubscriptionsIdRequestObject) (PatchSubscriptionsIdResponseObject, error) {
-	const op = "internal.http.api.handlers.PatchSubscriptionsId"
+func (h HandlersDependencies) GetSubscriptionsDeleted(ctx context.Context, request GetSubscriptionsDeletedRequestObject) (GetSubscriptionsDeletedResponseObject, error) {
+	const op = "internal.http.api.handlers.GetSubscriptionsDeleted"
+	log := withReqIDLog(ctx, h.Log)
+
+	params := request.Params
+	var args service.ListSubscriptionsArgs
+	if params.UserId != nil {
+		args.UserID = *params.UserId
+	}
+	if params.ServiceName != nil {
+		args.Service = *params.ServiceName
+	}
+	if params.Sort != nil {
+		args.Order = service.SubscriptionsOrder(*params.Sort)
+	}
+	if params.Cursor != nil {
+		args.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		args.Limit = *params.Limit
+	}
+
+	page, err := h.SubscriptionService.GetDeletedSubscriptions(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	items := make([]Subscription, len(page.Subscriptions))
+	for i, sub := range page.Subscriptions {
+		items[i] = toViewModel(sub)
+	}
+
+	log.Info("deleted subscriptions fetched", slog.String("op", op), slog.Int("count", len(items)))
+	return GetSubscriptionsDeleted200JSONResponse{
+		Items:      items,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (h HandlersDependencies) PostSubscriptionsIdRestore(ctx context.Context, request PostSubscriptionsIdRestoreRequestObject) (PostSubscriptionsIdRestoreResponseObject, error) {
+	const op = "internal.http.api.handlers.PostSubscriptionsIdRestore"
+	log := withReqIDLog(ctx, h.Log)
+
+	sub, err := h.SubscriptionService.RestoreSubscription(ctx, request.Id)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	vm := toViewModel(sub)
+	log.Info("subscription restored", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+	return PostSubscriptionsIdRestore200JSONResponse{
+		Body:    vm,
+		Headers: PostSubscriptionsIdRestore200ResponseHeaders{ETag: formatETag(sub.Version)},
+	}, nil
+}
+
+func (h HandlersDependencies) PutSubscriptionsId(ctx context.Context, request PutSubscriptionsIdRequestObject) (PutSubscriptionsIdResponseObject, error) {
+	const op = "internal.http.api.handlers.PutSubscriptionsId"
 	log := withReqIDLog(ctx, h.Log)
 
 	if request.Body == nil {
@@ -178,6 +485,22 @@ func (h HandlersDependencies) PatchSubscriptionsId(ctx context.Context, request
 		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
 	}
 
+	ifMatch, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		log.Warn("invalid If-Match header", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+
+	price, inUnits, err := subscriptionPrice(request.Body.Price, request.Body.PriceMinor)
+	if err != nil {
+		log.Warn("invalid request: ambiguous price", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+	if price == nil {
+		log.Warn("invalid request: price is missing", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "price or price_minor is required"})
+	}
+
 	var endTime *time.Time
 	if request.Body.EndDate != nil {
 		endTime = &request.Body.EndDate.Time
@@ -188,7 +511,15 @@ func (h HandlersDependencies) PatchSubscriptionsId(ctx context.Context, request
 		Service:        request.Body.ServiceName,
 		StartTime:      request.Body.StartDate.Time,
 		EndTime:        endTime,
-		PriceRUB:       request.Body.Price,
+		Price:          *price,
+		PriceInUnits:   inUnits,
+		IfMatch:        ifMatch,
+	}
+	if request.Body.Currency != nil {
+		args.Currency = models.Currency(*request.Body.Currency)
+	}
+	if request.Body.BillingPeriod != nil {
+		args.BillingPeriod = models.BillingPeriod(*request.Body.BillingPeriod)
 	}
 
 	sub, err := h.SubscriptionService.UpdateExistingSubscription(ctx, args)
@@ -197,17 +528,177 @@ func (h HandlersDependencies) PatchSubscriptionsId(ctx context.Context, request
 	}
 
 	vm := toViewModel(sub)
-	log.Info("subscription updated", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+	log.Info("subscription replaced", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+	return PutSubscriptionsId200JSONResponse{
+		Body:    vm,
+		Headers: PutSubscriptionsId200ResponseHeaders{ETag: formatETag(sub.Version)},
+	}, nil
+}
+
+func (h HandlersDependencies) PatchSubscriptionsId(ctx context.Context, request PatchSubscriptionsIdRequestObject) (PatchSubscriptionsIdResponseObject, error) {
+	const op = "internal.http.api.handlers.PatchSubscriptionsId"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	ifMatch, err := parseIfMatch(request.Params.IfMatch)
+	if err != nil {
+		log.Warn("invalid If-Match header", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+
+	body := request.Body
+	price, inUnits, err := subscriptionPrice(body.Price, body.PriceMinor)
+	if err != nil {
+		log.Warn("invalid request: ambiguous price", slog.String("op", op), slog.String("error", err.Error()))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	}
+	args := service.PatchSubscriptionArgs{
+		SubscriptionID: request.Id,
+		UserID:         body.UserId,
+		Service:        body.ServiceName,
+		Price:          price,
+		PriceInUnits:   inUnits,
+		IfMatch:        ifMatch,
+	}
+	if body.StartDate != nil {
+		args.StartTime = &body.StartDate.Time
+	}
+	if body.EndDate.IsNull() {
+		args.ClearEndTime = true
+	} else if endDate, err := body.EndDate.Get(); err == nil {
+		args.EndTime = &endDate.Time
+	}
+	if body.Currency != nil {
+		currency := models.Currency(*body.Currency)
+		args.Currency = &currency
+	}
+	if body.BillingPeriod != nil {
+		period := models.BillingPeriod(*body.BillingPeriod)
+		args.BillingPeriod = &period
+	}
+
+	sub, err := h.SubscriptionService.PatchSubscription(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	vm := toViewModel(sub)
+	log.Info("subscription patched", slog.String("op", op), slog.Any("subscription_id", sub.ID))
 	return PatchSubscriptionsId200JSONResponse{
-		EndDate:     vm.EndDate,
-		Id:          vm.Id,
-		Price:       vm.Price,
-		ServiceName: vm.ServiceName,
-		StartDate:   vm.StartDate,
-		UserId:      vm.UserId,
+		Body:    vm,
+		Headers: PatchSubscriptionsId200ResponseHeaders{ETag: formatETag(sub.Version)},
 	}, nil
 }
 
+func (h HandlersDependencies) GetSubscriptionsIdHistory(ctx context.Context, request GetSubscriptionsIdHistoryRequestObject) (GetSubscriptionsIdHistoryResponseObject, error) {
+	const op = "internal.http.api.handlers.GetSubscriptionsIdHistory"
+	log := withReqIDLog(ctx, h.Log)
+
+	changes, err := h.SubscriptionService.GetSubscriptionHistory(ctx, request.Id)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	responseModels := make(GetSubscriptionsIdHistory200JSONResponse, len(changes))
+	for i, c := range changes {
+		oldValue, err := toObject(c.OldValue)
+		if err != nil {
+			return nil, handleServiceError(err, h.Log, op)
+		}
+		newValue, err := toObject(c.NewValue)
+		if err != nil {
+			return nil, handleServiceError(err, h.Log, op)
+		}
+
+		responseModels[i] = SubscriptionChange{
+			Id:             c.ID,
+			SubscriptionId: c.SubscriptionID,
+			Action:         SubscriptionChangeAction(c.Action),
+			ChangedAt:      c.ChangedAt.UTC(),
+			ChangedBy:      c.ChangedBy,
+			RequestId:      c.RequestID,
+			OldValue:       oldValue,
+			NewValue:       newValue,
+		}
+	}
+
+	log.Info("subscription history fetched", slog.String("op", op), slog.Any("subscription_id", request.Id), slog.Int("count", len(responseModels)))
+	return responseModels, nil
+}
+
+func toObject(raw json.RawMessage) (*map[string]interface{}, error) {
+	if raw == nil {
+		return nil, nil
+	}
+
+	var obj map[string]interface{}
+	if err := json.Unmarshal(raw, &obj); err != nil {
+		return nil, err
+	}
+
+	return &obj, nil
+}
+
+// totalCost calculates the total and reports a single total_cost only when all charges are in one currency.
+func (h HandlersDependencies) totalCost(ctx context.Context, op string, args service.CalculateTotalPriceArgs) (TotalCostResponse, error) {
+	totalPrice, err := h.SubscriptionService.CalculateTotalSubscriptionsPrice(ctx, args)
+	if err != nil {
+		return TotalCostResponse{}, handleServiceError(err, h.Log, op)
+	}
+
+	totals := make([]Money, len(totalPrice.Totals))
+	for i, t := range totalPrice.Totals {
+		totals[i] = Money{Amount: t.Amount, Currency: string(t.Currency)}
+	}
+
+	costs := make([]SubscriptionCost, len(totalPrice.Subscriptions))
+	for i, c := range totalPrice.Subscriptions {
+		costs[i] = SubscriptionCost{
+			SubscriptionId: c.SubscriptionID,
+			ServiceName:    c.ServiceName,
+			Price:          c.Price.Units(),
+			PriceMinor:     c.Price.Amount,
+			Currency:       string(c.Price.Currency),
+			BillingPeriod:  BillingPeriod(c.BillingPeriod),
+			BilledMonths:   c.BilledPeriods,
+			BilledPeriods:  c.BilledPeriods,
+			Cost:           c.Cost.Units(),
+			CostMinor:      c.Cost.Amount,
+		}
+	}
+
+	response := TotalCostResponse{
+		Totals:        &totals,
+		Subscriptions: &costs,
+	}
+	if len(totals) <= 1 {
+		var totalCost int64
+		if len(totalPrice.Totals) == 1 {
+			totalCost = totalPrice.Totals[0].Units()
+		}
+		response.TotalCost = &totalCost
+	}
+
+	return response, nil
+}
+
+// subscriptionPrice picks the price given in a subscription body, either in whole units or in minor units.
+// It returns nil when neither is given.
+func subscriptionPrice(units, minor *int64) (price *int64, inUnits bool, err error) {
+	switch {
+	case units != nil && minor != nil:
+		return nil, false, fmt.Errorf("price and price_minor can not be combined")
+	case units != nil:
+		return units, true, nil
+	default:
+		return minor, false, nil
+	}
+}
+
 func toViewModel(sub *models.Subscription) Subscription {
 	var endDate *openapi_types.Date
 	if sub.IsCompleted() {
@@ -217,11 +708,78 @@ func toViewModel(sub *models.Subscription) Subscription {
 	}
 
 	return Subscription{
-		EndDate:     endDate,
-		Id:          sub.ID,
-		Price:       sub.PriceRUB,
-		ServiceName: sub.ServiceName,
-		StartDate:   openapi_types.Date{Time: sub.StartedAt.UTC()},
-		UserId:      sub.Owner,
+		EndDate:       endDate,
+		Id:            sub.ID,
+		TenantId:      sub.Tenant,
+		Price:         sub.Price.Units(),
+		PriceMinor:    sub.Price.Amount,
+		Currency:      string(sub.Price.Currency),
+		BillingPeriod: BillingPeriod(sub.Period),
+		ServiceName:   sub.ServiceName,
+		StartDate:     openapi_types.Date{Time: sub.StartedAt.UTC()},
+		UserId:        sub.Owner,
+		DeletedAt:     sub.DeletedAt,
+		Version:       sub.Version,
+	}
+}
+
+// exportResponse streams the file straight into the response instead of buffering it like the generated responses do.
+type exportResponse struct {
+	contentType string
+	fileName    string
+	write       func(w io.Writer) error
+}
+
+func (r exportResponse) VisitGetSubscriptionsExportResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", r.contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": r.fileName}))
+	w.WriteHeader(http.StatusOK)
+
+	return r.write(w)
+}
+
+// negotiateExportFormat picks the first export format listed in an Accept header, csv when none is.
+func negotiateExportFormat(accept string) service.ExportFormat {
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		for _, f := range []service.ExportFormat{service.ExportCSV, service.ExportNDJSON, service.ExportXLSX} {
+			if ct, _, _ := mime.ParseMediaType(f.ContentType()); ct == mediaType {
+				return f
+			}
+		}
+	}
+
+	return service.ExportCSV
+}
+
+// formatETag renders a subscription version as a strong entity tag.
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseIfMatch extracts the expected version from an If-Match header, nil means any version will do.
+func parseIfMatch(header *IfMatch) (*int64, error) {
+	if header == nil {
+		return nil, nil
 	}
+
+	value := strings.TrimSpace(*header)
+	if value == "" || value == "*" {
+		return nil, nil
+	}
+
+	value = strings.TrimPrefix(value, "W/")
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return nil, errors.New("If-Match must be a quoted entity tag")
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return nil, errors.New("If-Match does not match any subscription version")
+	}
+
+	return &version, nil
 }
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"context"
+	"effective-mobile/internal/config"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/service"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeSubscriptionService records the arguments of the calls the tests make, other methods are not implemented.
+type fakeSubscriptionService struct {
+	service.SubscriptionService
+	patched *service.PatchSubscriptionArgs
+	created *service.CreateNewSubscriptionArgs
+}
+
+func (f *fakeSubscriptionService) CreateNewSubscription(_ context.Context, c service.CreateNewSubscriptionArgs) (*models.Subscription, error) {
+	f.created = &c
+	price := models.Money{Amount: c.Price, Currency: c.Currency}
+	if c.PriceInUnits {
+		price = models.MoneyFromUnits(c.Price, c.Currency)
+	}
+	return &models.Subscription{
+		ID:          uuid.New(),
+		ServiceName: c.Service,
+		Price:       price,
+		StartedAt:   c.StartTime,
+		Owner:       c.UserID,
+		Period:      models.BillingMonthly,
+		Version:     1,
+	}, nil
+}
+
+func (f *fakeSubscriptionService) PatchSubscription(_ context.Context, p service.PatchSubscriptionArgs) (*models.Subscription, error) {
+	f.patched = &p
+	return &models.Subscription{
+		ID:          p.SubscriptionID,
+		ServiceName: "Netflix",
+		Price:       models.Money{Amount: 500, Currency: "RUB"},
+		StartedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Owner:       uuid.New(),
+		Period:      models.BillingMonthly,
+		Version:     2,
+	}, nil
+}
+
+func newTestServer(deps HandlersDependencies) *server {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	deps.Log = log
+
+	cfg := &config.CRUDConfig{}
+	cfg.HTTPServerConfig.Auth.Method = "none"
+	cfg.HTTPServerConfig.Tenancy.Default = models.DefaultTenant
+
+	return NewHTTPServer(log, deps, nil, nil, nil, cfg)
+}
+
+func TestPatchSubscriptionAcceptsMergePatch(t *testing.T) {
+	tests := []struct {
+		name         string
+		contentType  string
+		body         string
+		wantStatus   int
+		wantPrice    *int64
+		wantClearEnd bool
+	}{
+		{
+			name:        "merge patch changes only given fields",
+			contentType: MIMEApplicationMergePatchJSON,
+			body:        `{"price": 500}`,
+			wantStatus:  http.StatusOK,
+			wantPrice:   ptr(int64(500)),
+		},
+		{
+			name:         "null clears end date",
+			contentType:  MIMEApplicationMergePatchJSON,
+			body:         `{"end_date": null}`,
+			wantStatus:   http.StatusOK,
+			wantClearEnd: true,
+		},
+		{
+			name:        "media type parameters are accepted",
+			contentType: MIMEApplicationMergePatchJSON + "; charset=utf-8",
+			body:        `{"price": 500}`,
+			wantStatus:  http.StatusOK,
+			wantPrice:   ptr(int64(500)),
+		},
+		{
+			name:        "malformed document is rejected",
+			contentType: MIMEApplicationMergePatchJSON,
+			body:        `{"price": `,
+			wantStatus:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subs := &fakeSubscriptionService{}
+			srv := newTestServer(HandlersDependencies{SubscriptionService: subs})
+
+			id := uuid.New()
+			req := httptest.NewRequest(http.MethodPatch, "/subscriptions/"+id.String(), strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			rec := httptest.NewRecorder()
+			srv.e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			p := subs.patched
+			if p == nil {
+				t.Fatal("PatchSubscription was not called")
+			}
+			if p.SubscriptionID != id {
+				t.Errorf("subscription id = %s, want %s", p.SubscriptionID, id)
+			}
+			if (p.Price == nil) != (tt.wantPrice == nil) || (p.Price != nil && *p.Price != *tt.wantPrice) {
+				t.Errorf("price = %v, want %v", p.Price, tt.wantPrice)
+			}
+			if p.ClearEndTime != tt.wantClearEnd {
+				t.Errorf("clear end time = %v, want %v", p.ClearEndTime, tt.wantClearEnd)
+			}
+			if p.UserID != nil || p.Service != nil || p.StartTime != nil {
+				t.Errorf("omitted fields were set: %+v", p)
+			}
+			if got := rec.Header().Get("ETag"); got != `"2"` {
+				t.Errorf("ETag = %q, want %q", got, `"2"`)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestPostSubscriptionPrice(t *testing.T) {
+	tests := []struct {
+		name           string
+		price          string
+		wantStatus     int
+		wantPrice      int64
+		wantPriceMinor int64
+	}{
+		{name: "price keeps whole units", price: `"price": 400`, wantStatus: http.StatusCreated, wantPrice: 400, wantPriceMinor: 40000},
+		{name: "price_minor keeps the fraction", price: `"price_minor": 39990`, wantStatus: http.StatusCreated, wantPrice: 399, wantPriceMinor: 39990},
+		{name: "both prices are rejected", price: `"price": 400, "price_minor": 40000`, wantStatus: http.StatusBadRequest},
+		{name: "missing price is rejected", price: `"currency": "RUB"`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subs := &fakeSubscriptionService{}
+			srv := newTestServer(HandlersDependencies{SubscriptionService: subs})
+
+			body := `{"service_name": "Netflix", "user_id": "` + uuid.NewString() + `", "start_date": "2025-01-01", ` + tt.price + `}`
+			req := httptest.NewRequest(http.MethodPost, "/subscriptions", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			srv.e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+
+			var got Subscription
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("can not decode response: %v", err)
+			}
+			if got.Price != tt.wantPrice || got.PriceMinor != tt.wantPriceMinor {
+				t.Errorf("price = %d, price_minor = %d, want %d and %d", got.Price, got.PriceMinor, tt.wantPrice, tt.wantPriceMinor)
+			}
+		})
+	}
+}
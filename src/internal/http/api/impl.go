@@ -4,8 +4,16 @@ import (
 	"context"
 	"effective-mobile/internal/config"
 	"effective-mobile/internal/http/middleware"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/service"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/ratelimit"
+	"fmt"
 	"log/slog"
+	"net/http"
+	"strings"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	echomiddleware "github.com/labstack/echo/v4/middleware"
 )
@@ -16,14 +24,48 @@ type server struct {
 	log    *slog.Logger
 }
 
-func NewHTTPServer(log *slog.Logger, deps HandlersDependencies, config *config.CRUDConfig) *server {
+// NewHTTPServer serves the API. Api keys are checked with the api key service whatever the auth method,
+// bearer tokens with verifier. A nil verifier leaves callers without an api key unauthenticated unless
+// the configuration trusts a gateway to authenticate them.
+// Clients are rate limited by address and, once authenticated, by api key or user with buckets kept in limits,
+// a nil store disables rate limiting. Client addresses are told by extractIP, a nil extractor takes the address
+// of the connection.
+func NewHTTPServer(log *slog.Logger, deps HandlersDependencies, verifier middleware.TokenVerifier, limits ratelimit.Store, extractIP echo.IPExtractor, config *config.CRUDConfig) *server {
 	e := echo.New()
+	e.Binder = binder{}
+	if extractIP == nil {
+		extractIP = echo.ExtractIPDirect()
+	}
+	e.IPExtractor = extractIP
 	e.Use(echomiddleware.RequestID())
 	e.Use(middleware.NewEnrichRequestContextMiddleware())
 	e.Use(middleware.NewRequestLoggerMiddleware(log))
+
+	def, rules := rateLimitRules(config.HTTPServerConfig.RateLimit)
+	if limits != nil {
+		e.Use(middleware.NewAddressRateLimitMiddleware(limits, def, rules, log))
+	}
+
+	auth := config.HTTPServerConfig.Auth
+
+	publicPaths := []string{"/", "/swagger.yml"}
+	e.Use(middleware.NewAPIKeyAuthMiddleware(apiKeyAuthenticator{keys: deps.APIKeyService}, publicPaths, log))
+	open := false
+	switch {
+	case auth.Method == "gateway":
+		e.Use(middleware.NewGatewayAuthMiddleware(auth.UserHeader, auth.RoleHeader, publicPaths, log))
+	case verifier != nil:
+		e.Use(middleware.NewAuthMiddleware(verifier, auth.RoleClaim, publicPaths, log))
+	default:
+		open = true
+		log.Warn("authentication is disabled, the API is open to anyone")
+	}
+	if limits != nil {
+		e.Use(middleware.NewRateLimitMiddleware(limits, def, rules, log))
+	}
 	RegisterHandlers(e, NewStrictHandler(
 		deps,
-		[]StrictMiddlewareFunc{},
+		[]StrictMiddlewareFunc{withChangeMeta, withTenant(config.HTTPServerConfig.Tenancy, auth.Method == "gateway"), withPrincipal(open)},
 	))
 
 	e.File("/", config.Swagger.UIPath)
@@ -47,3 +89,112 @@ func (s *server) Stop(ctx context.Context) error {
 	s.log.Info("stopping server")
 	return s.e.Shutdown(ctx)
 }
+
+func rateLimitRules(cfg config.RateLimitConfig) (ratelimit.Limit, []middleware.RateLimitRule) {
+	rules := make([]middleware.RateLimitRule, len(cfg.Routes))
+	for i, r := range cfg.Routes {
+		rules[i] = middleware.RateLimitRule{
+			Method: strings.ToUpper(r.Method),
+			Path:   r.Path,
+			Limit:  ratelimit.Limit{Requests: r.Requests, Per: r.Per, Burst: r.Burst},
+		}
+	}
+
+	return ratelimit.Limit{Requests: cfg.Default.Requests, Per: cfg.Default.Per, Burst: cfg.Default.Burst}, rules
+}
+
+// withChangeMeta attaches the request origin to the context, so storage can record it in the change history.
+func withChangeMeta(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+	return func(ctx echo.Context, request interface{}) (interface{}, error) {
+		req := ctx.Request()
+		meta := storage.ChangeMeta{
+			RequestID: ctx.Response().Header().Get(echo.HeaderXRequestID),
+		}
+		if id, ok := middleware.IdentityFromContext(req.Context()); ok {
+			meta.Actor = id.Subject
+		}
+		ctx.SetRequest(req.WithContext(storage.WithChangeMeta(req.Context(), meta)))
+
+		return f(ctx, request)
+	}
+}
+
+// withPrincipal turns the authenticated identity into the principal services authorize calls with.
+// The subject must be a user id, a missing role means a regular user. Callers of an open API act as
+// admins, otherwise requests without an identity are rejected.
+func withPrincipal(open bool) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		return func(ctx echo.Context, request interface{}) (interface{}, error) {
+			req := ctx.Request()
+			id, ok := middleware.IdentityFromContext(req.Context())
+			if !ok && open {
+				ctx.SetRequest(req.WithContext(service.WithPrincipal(req.Context(), service.Principal{Role: service.RoleAdmin})))
+				return f(ctx, request)
+			}
+			if !ok {
+				return nil, echo.NewHTTPError(http.StatusUnauthorized, ErrorResponse{Error: "caller is not authenticated"})
+			}
+
+			userID, err := uuid.Parse(id.Subject)
+			if err != nil {
+				return nil, echo.NewHTTPError(http.StatusUnauthorized, ErrorResponse{Error: "caller identity is not a user id"})
+			}
+			role := service.RoleUser
+			if id.Role != "" {
+				role = service.Role(id.Role)
+			}
+			if !role.IsValid() {
+				return nil, echo.NewHTTPError(http.StatusForbidden, ErrorResponse{Error: fmt.Sprintf("unknown role %q", id.Role)})
+			}
+
+			p := service.Principal{UserID: userID, Role: role}
+			ctx.SetRequest(req.WithContext(service.WithPrincipal(req.Context(), p)))
+
+			return f(ctx, request)
+		}
+	}
+}
+
+// withTenant scopes the request to the tenant the caller is bound to, it relies on withPrincipal having run
+// before it. Api keys carry their tenant, tokens the tenant claim and with trustHeader the tenant header set by
+// the gateway is the caller's. Credentials without a tenant are rejected, only operators may request another
+// tenant. Callers of an open API act for the requested tenant or the default one.
+func withTenant(cfg config.TenancyConfig, trustHeader bool) StrictMiddlewareFunc {
+	return func(f StrictHandlerFunc, operationID string) StrictHandlerFunc {
+		return func(ctx echo.Context, request interface{}) (interface{}, error) {
+			req := ctx.Request()
+			requested := strings.TrimSpace(req.Header.Get(cfg.Header))
+
+			var tenant models.TenantID
+			id, authenticated := middleware.IdentityFromContext(req.Context())
+			switch {
+			case !authenticated:
+				tenant = requested
+				if tenant == "" {
+					tenant = cfg.Default
+				}
+			case id.APIKeyID != "":
+				tenant = id.Tenant
+			case id.Claims != nil:
+				tenant, _ = id.Claims.String(cfg.TenantClaim)
+			case trustHeader:
+				tenant = requested
+			}
+			if p, _ := service.PrincipalFromContext(req.Context()); p.Role == service.RoleOperator && requested != "" {
+				tenant = requested
+			}
+			if tenant == "" {
+				return nil, echo.NewHTTPError(http.StatusUnauthorized, ErrorResponse{Error: "caller is not bound to a tenant"})
+			}
+			if requested != "" && requested != tenant {
+				return nil, echo.NewHTTPError(http.StatusForbidden, ErrorResponse{Error: fmt.Sprintf("access to tenant %q is denied", requested)})
+			}
+			if err := models.ValidateTenantID(tenant); err != nil {
+				return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+			}
+			ctx.SetRequest(req.WithContext(service.WithTenant(req.Context(), tenant)))
+
+			return f(ctx, request)
+		}
+	}
+}
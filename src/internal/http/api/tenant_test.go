@@ -0,0 +1,153 @@
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"effective-mobile/internal/config"
+	"effective-mobile/internal/http/middleware"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/service"
+	"effective-mobile/pkg/jwt"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var testSecret = []byte("s3cret")
+
+// testToken signs an HS256 token of the caller with the given role and tenant claims, empty ones are left out.
+func testToken(t *testing.T, subject models.PersonID, role, tenant string) string {
+	t.Helper()
+
+	claims := map[string]any{"sub": subject.String(), "exp": time.Now().Add(time.Hour).Unix()}
+	if role != "" {
+		claims["role"] = role
+	}
+	if tenant != "" {
+		claims["tenant"] = tenant
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatalf("can not encode claims: %v", err)
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, testSecret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestTenantSelection(t *testing.T) {
+	owner := uuid.New()
+	keys := fakeAPIKeyService{keys: map[string]*models.APIKey{
+		"emk_admin": {ID: uuid.New(), Tenant: "acme", Owner: owner, Scopes: []models.APIKeyScope{models.ScopeAdmin}},
+	}}
+	verifier, err := jwt.NewHS256Verifier(testSecret, jwt.Options{})
+	if err != nil {
+		t.Fatalf("NewHS256Verifier() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		authMethod string
+		headers    map[string]string
+		wantStatus int
+		wantTenant models.TenantID
+	}{
+		{name: "open api", authMethod: "none", wantStatus: http.StatusOK, wantTenant: models.DefaultTenant},
+		{name: "open api requests tenant", authMethod: "none", headers: map[string]string{"X-Tenant-ID": "globex"}, wantStatus: http.StatusOK, wantTenant: "globex"},
+		{name: "api key", authMethod: "hs256", headers: map[string]string{middleware.HeaderAPIKey: "emk_admin"}, wantStatus: http.StatusOK, wantTenant: "acme"},
+		{
+			name:       "admin api key requests another tenant",
+			authMethod: "hs256",
+			headers:    map[string]string{middleware.HeaderAPIKey: "emk_admin", "X-Tenant-ID": "globex"},
+			wantStatus: http.StatusForbidden,
+		},
+		{name: "token", authMethod: "hs256", headers: map[string]string{"Authorization": "Bearer " + testToken(t, owner, "", "acme")}, wantStatus: http.StatusOK, wantTenant: "acme"},
+		{
+			name:       "token requests own tenant",
+			authMethod: "hs256",
+			headers:    map[string]string{"Authorization": "Bearer " + testToken(t, owner, "", "acme"), "X-Tenant-ID": "acme"},
+			wantStatus: http.StatusOK,
+			wantTenant: "acme",
+		},
+		{
+			name:       "admin token requests another tenant",
+			authMethod: "hs256",
+			headers:    map[string]string{"Authorization": "Bearer " + testToken(t, owner, "admin", "acme"), "X-Tenant-ID": "globex"},
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:       "token without tenant",
+			authMethod: "hs256",
+			headers:    map[string]string{"Authorization": "Bearer " + testToken(t, owner, "admin", ""), "X-Tenant-ID": "acme"},
+			wantStatus: http.StatusUnauthorized,
+		},
+		{
+			name:       "operator requests another tenant",
+			authMethod: "hs256",
+			headers:    map[string]string{"Authorization": "Bearer " + testToken(t, owner, "operator", "acme"), "X-Tenant-ID": "globex"},
+			wantStatus: http.StatusOK,
+			wantTenant: "globex",
+		},
+		{
+			name:       "operator without tenant claim",
+			authMethod: "hs256",
+			headers:    map[string]string{"Authorization": "Bearer " + testToken(t, owner, "operator", ""), "X-Tenant-ID": "globex"},
+			wantStatus: http.StatusOK,
+			wantTenant: "globex",
+		},
+		{
+			name:       "gateway",
+			authMethod: "gateway",
+			headers:    map[string]string{"X-User-ID": owner.String(), "X-Tenant-ID": "acme"},
+			wantStatus: http.StatusOK,
+			wantTenant: "acme",
+		},
+		{name: "gateway without tenant", authMethod: "gateway", headers: map[string]string{"X-User-ID": owner.String()}, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := &callerRecorder{}
+			log := slog.New(slog.NewTextHandler(io.Discard, nil))
+			cfg := &config.CRUDConfig{}
+			cfg.HTTPServerConfig.Auth.Method = tt.authMethod
+			cfg.HTTPServerConfig.Auth.UserHeader = "X-User-ID"
+			cfg.HTTPServerConfig.Auth.RoleClaim = "role"
+			cfg.HTTPServerConfig.Tenancy = config.TenancyConfig{Header: "X-Tenant-ID", TenantClaim: "tenant", Default: models.DefaultTenant}
+			var v middleware.TokenVerifier
+			if tt.authMethod == "hs256" {
+				v = verifier
+			}
+			srv := NewHTTPServer(log, HandlersDependencies{Log: log, UserService: users, APIKeyService: keys}, v, nil, nil, cfg)
+
+			req := httptest.NewRequest(http.MethodGet, "/users/"+owner.String(), nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			srv.e.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				if users.principal != (service.Principal{}) {
+					t.Errorf("service was called as %+v, want the request rejected", users.principal)
+				}
+				return
+			}
+			if users.tenant != tt.wantTenant {
+				t.Errorf("tenant = %q, want %q", users.tenant, tt.wantTenant)
+			}
+		})
+	}
+}
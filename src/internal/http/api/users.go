@@ -0,0 +1,256 @@
+package api
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/service"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (h HandlersDependencies) GetUsers(ctx context.Context, request GetUsersRequestObject) (GetUsersResponseObject, error) {
+	const op = "internal.http.api.users.GetUsers"
+	log := withReqIDLog(ctx, h.Log)
+
+	var args service.ListUsersArgs
+	if request.Params.Cursor != nil {
+		args.Cursor = *request.Params.Cursor
+	}
+	if request.Params.Limit != nil {
+		args.Limit = *request.Params.Limit
+	}
+
+	page, err := h.UserService.GetUsers(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	items := make([]UserAccount, len(page.Users))
+	for i, u := range page.Users {
+		items[i] = toUserViewModel(u)
+	}
+
+	log.Info("users fetched", slog.String("op", op), slog.Int("count", len(items)))
+	return GetUsers200JSONResponse{
+		Items:      items,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (h HandlersDependencies) PostUsers(ctx context.Context, request PostUsersRequestObject) (PostUsersResponseObject, error) {
+	const op = "internal.http.api.users.PostUsers"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	args := service.CreateUserArgs{
+		DisplayName: request.Body.DisplayName,
+		Email:       string(request.Body.Email),
+	}
+	if request.Body.Timezone != nil {
+		args.Timezone = *request.Body.Timezone
+	}
+
+	u, err := h.UserService.CreateUser(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("user created", slog.String("op", op), slog.Any("user_id", u.ID))
+	return PostUsers201JSONResponse(toUserViewModel(u)), nil
+}
+
+func (h HandlersDependencies) GetUsersId(ctx context.Context, request GetUsersIdRequestObject) (GetUsersIdResponseObject, error) {
+	const op = "internal.http.api.users.GetUsersId"
+	log := withReqIDLog(ctx, h.Log)
+
+	u, err := h.UserService.FindUserByID(ctx, request.Id)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("user fetched", slog.String("op", op), slog.Any("user_id", u.ID))
+	return GetUsersId200JSONResponse(toUserViewModel(u)), nil
+}
+
+func (h HandlersDependencies) PutUsersId(ctx context.Context, request PutUsersIdRequestObject) (PutUsersIdResponseObject, error) {
+	const op = "internal.http.api.users.PutUsersId"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	args := service.UpdateUserArgs{
+		PersonID:    request.Id,
+		DisplayName: request.Body.DisplayName,
+		Email:       string(request.Body.Email),
+	}
+	if request.Body.Timezone != nil {
+		args.Timezone = *request.Body.Timezone
+	}
+
+	u, err := h.UserService.UpdateUser(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("user updated", slog.String("op", op), slog.Any("user_id", u.ID))
+	return PutUsersId200JSONResponse(toUserViewModel(u)), nil
+}
+
+func (h HandlersDependencies) DeleteUsersId(ctx context.Context, request DeleteUsersIdRequestObject) (DeleteUsersIdResponseObject, error) {
+	const op = "internal.http.api.users.DeleteUsersId"
+	log := withReqIDLog(ctx, h.Log)
+
+	if err := h.UserService.RemoveUser(ctx, request.Id); err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("user removed", slog.String("op", op), slog.Any("user_id", request.Id))
+	return DeleteUsersId204Response{}, nil
+}
+
+func (h HandlersDependencies) GetUsersIdSubscriptions(ctx context.Context, request GetUsersIdSubscriptionsRequestObject) (GetUsersIdSubscriptionsResponseObject, error) {
+	const op = "internal.http.api.users.GetUsersIdSubscriptions"
+	log := withReqIDLog(ctx, h.Log)
+
+	// an unknown user is reported as such instead of an empty page
+	if _, err := h.UserService.FindUserByID(ctx, request.Id); err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	params := request.Params
+	args := service.ListSubscriptionsArgs{
+		UserID:   request.Id,
+		MinPrice: params.MinPrice,
+		MaxPrice: params.MaxPrice,
+	}
+	if params.Currency != nil {
+		args.Currency = *params.Currency
+	}
+	if params.ServiceName != nil {
+		args.Service = *params.ServiceName
+	}
+	if params.Category != nil {
+		args.Category = *params.Category
+	}
+	if params.ActiveAt != nil {
+		args.ActiveAt = &params.ActiveAt.Time
+	}
+	if params.Sort != nil {
+		args.Order = service.SubscriptionsOrder(*params.Sort)
+	}
+	if params.Cursor != nil {
+		args.Cursor = *params.Cursor
+	}
+	if params.Limit != nil {
+		args.Limit = *params.Limit
+	}
+
+	page, err := h.SubscriptionService.GetSubscriptions(ctx, args)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	items := make([]Subscription, len(page.Subscriptions))
+	for i, sub := range page.Subscriptions {
+		items[i] = toViewModel(sub)
+	}
+
+	log.Info("user subscriptions fetched", slog.String("op", op), slog.Any("user_id", request.Id), slog.Int("count", len(items)))
+	return GetUsersIdSubscriptions200JSONResponse{
+		Items:      items,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (h HandlersDependencies) GetUsersIdTotalCost(ctx context.Context, request GetUsersIdTotalCostRequestObject) (GetUsersIdTotalCostResponseObject, error) {
+	const op = "internal.http.api.users.GetUsersIdTotalCost"
+	log := withReqIDLog(ctx, h.Log)
+
+	// without this check an unknown user would cost nothing instead of being reported
+	if _, err := h.UserService.FindUserByID(ctx, request.Id); err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	params := request.Params
+	var countStartTime, countEndTime *time.Time
+	if params.StartDate != nil {
+		countStartTime = &params.StartDate.Time
+	}
+	if params.EndDate != nil {
+		countEndTime = &params.EndDate.Time
+	}
+
+	args := service.CalculateTotalPriceArgs{
+		UserID:    request.Id,
+		StartTime: countStartTime,
+		EndTime:   countEndTime,
+	}
+	if params.ServiceName != nil {
+		args.Service = *params.ServiceName
+	}
+	if params.Category != nil {
+		args.Category = *params.Category
+	}
+	if params.Currency != nil {
+		currency := models.Currency(*params.Currency)
+		args.Currency = &currency
+	}
+
+	response, err := h.totalCost(ctx, op, args)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("user total cost calculated", slog.String("op", op), slog.Any("user_id", request.Id), slog.Int("currencies", len(*response.Totals)))
+	return GetUsersIdTotalCost200JSONResponse(response), nil
+}
+
+func (h HandlersDependencies) GetUsersIdUpcomingCharges(ctx context.Context, request GetUsersIdUpcomingChargesRequestObject) (GetUsersIdUpcomingChargesResponseObject, error) {
+	const op = "internal.http.api.users.GetUsersIdUpcomingCharges"
+	log := withReqIDLog(ctx, h.Log)
+
+	var days int
+	if request.Params.Days != nil {
+		days = *request.Params.Days
+	}
+
+	charges, err := h.RenewalService.GetUpcomingCharges(ctx, request.Id, days)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	response := make(GetUsersIdUpcomingCharges200JSONResponse, len(charges))
+	for i, c := range charges {
+		response[i] = UpcomingCharge{
+			SubscriptionId: c.SubscriptionID,
+			ServiceName:    c.ServiceName,
+			Amount:         Money{Amount: c.Amount.Amount, Currency: string(c.Amount.Currency)},
+			BillingPeriod:  BillingPeriod(c.BillingPeriod),
+			ChargeDate:     openapi_types.Date{Time: c.ChargeAt},
+		}
+	}
+
+	log.Info("upcoming charges fetched", slog.String("op", op), slog.Any("user_id", request.Id), slog.Int("count", len(response)))
+	return response, nil
+}
+
+func toUserViewModel(u *models.User) UserAccount {
+	return UserAccount{
+		Id:          u.ID,
+		DisplayName: u.DisplayName,
+		Email:       u.Email,
+		Timezone:    u.Timezone,
+		CreatedAt:   u.CreatedAt,
+	}
+}
@@ -0,0 +1,154 @@
+package api
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/service"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h HandlersDependencies) GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error) {
+	const op = "internal.http.api.webhooks.GetWebhooks"
+	log := withReqIDLog(ctx, h.Log)
+
+	webhooks, err := h.WebhookService.GetWebhooks(ctx)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	response := make(GetWebhooks200JSONResponse, len(webhooks))
+	for i, w := range webhooks {
+		response[i] = toWebhookViewModel(w)
+	}
+
+	log.Info("webhooks fetched", slog.String("op", op), slog.Int("count", len(response)))
+	return response, nil
+}
+
+func (h HandlersDependencies) PostWebhooks(ctx context.Context, request PostWebhooksRequestObject) (PostWebhooksResponseObject, error) {
+	const op = "internal.http.api.webhooks.PostWebhooks"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	w, err := h.WebhookService.CreateWebhook(ctx, service.CreateWebhookArgs{
+		URL:    request.Body.Url,
+		Secret: request.Body.Secret,
+		Events: toEventTypes(request.Body.Events),
+	})
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("webhook created", slog.String("op", op), slog.Any("webhook_id", w.ID))
+	return PostWebhooks201JSONResponse(toWebhookViewModel(w)), nil
+}
+
+func (h HandlersDependencies) GetWebhooksId(ctx context.Context, request GetWebhooksIdRequestObject) (GetWebhooksIdResponseObject, error) {
+	const op = "internal.http.api.webhooks.GetWebhooksId"
+	log := withReqIDLog(ctx, h.Log)
+
+	w, err := h.WebhookService.FindWebhookByID(ctx, request.Id)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("webhook fetched", slog.String("op", op), slog.Any("webhook_id", w.ID))
+	return GetWebhooksId200JSONResponse(toWebhookViewModel(w)), nil
+}
+
+func (h HandlersDependencies) PutWebhooksId(ctx context.Context, request PutWebhooksIdRequestObject) (PutWebhooksIdResponseObject, error) {
+	const op = "internal.http.api.webhooks.PutWebhooksId"
+	log := withReqIDLog(ctx, h.Log)
+
+	if request.Body == nil {
+		log.Warn("invalid request: body is nil", slog.String("op", op))
+		return nil, echo.NewHTTPError(http.StatusBadRequest, ErrorResponse{Error: "request body is required"})
+	}
+
+	w, err := h.WebhookService.UpdateWebhook(ctx, service.UpdateWebhookArgs{
+		WebhookID: request.Id,
+		URL:       request.Body.Url,
+		Secret:    request.Body.Secret,
+		Events:    toEventTypes(request.Body.Events),
+	})
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("webhook updated", slog.String("op", op), slog.Any("webhook_id", w.ID))
+	return PutWebhooksId200JSONResponse(toWebhookViewModel(w)), nil
+}
+
+func (h HandlersDependencies) DeleteWebhooksId(ctx context.Context, request DeleteWebhooksIdRequestObject) (DeleteWebhooksIdResponseObject, error) {
+	const op = "internal.http.api.webhooks.DeleteWebhooksId"
+	log := withReqIDLog(ctx, h.Log)
+
+	if err := h.WebhookService.RemoveWebhook(ctx, request.Id); err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	log.Info("webhook removed", slog.String("op", op), slog.Any("webhook_id", request.Id))
+	return DeleteWebhooksId204Response{}, nil
+}
+
+func (h HandlersDependencies) GetWebhooksIdDeadLetters(ctx context.Context, request GetWebhooksIdDeadLettersRequestObject) (GetWebhooksIdDeadLettersResponseObject, error) {
+	const op = "internal.http.api.webhooks.GetWebhooksIdDeadLetters"
+	log := withReqIDLog(ctx, h.Log)
+
+	letters, err := h.WebhookService.GetDeadLetters(ctx, request.Id)
+	if err != nil {
+		return nil, handleServiceError(err, h.Log, op)
+	}
+
+	response := make(GetWebhooksIdDeadLetters200JSONResponse, len(letters))
+	for i, d := range letters {
+		var payload map[string]interface{}
+		if err := json.Unmarshal(d.Payload, &payload); err != nil {
+			log.Error("failed to decode dead letter payload", slog.String("op", op), slog.Int64("dead_letter_id", d.ID), slog.Any("error", err))
+			return nil, echo.NewHTTPError(http.StatusInternalServerError, ErrorResponse{Error: "internal server error"})
+		}
+		response[i] = DeadLetter{
+			Id:        d.ID,
+			EventId:   d.EventID,
+			EventType: EventType(d.EventType),
+			Payload:   payload,
+			Attempts:  d.Attempts,
+			LastError: d.LastError,
+			FailedAt:  d.FailedAt,
+		}
+	}
+
+	log.Info("dead letters fetched", slog.String("op", op), slog.Any("webhook_id", request.Id), slog.Int("count", len(response)))
+	return response, nil
+}
+
+func toWebhookViewModel(w *models.Webhook) Webhook {
+	events := make([]EventType, len(w.Events))
+	for i, e := range w.Events {
+		events[i] = EventType(e)
+	}
+
+	return Webhook{
+		Id:        w.ID,
+		Url:       w.URL,
+		Events:    events,
+		CreatedAt: w.CreatedAt,
+	}
+}
+
+func toEventTypes(events []EventType) []models.EventType {
+	out := make([]models.EventType, len(events))
+	for i, e := range events {
+		out[i] = models.EventType(e)
+	}
+
+	return out
+}
@@ -0,0 +1,210 @@
+package middleware
+
+import (
+	"context"
+	"effective-mobile/pkg/jwt"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// HeaderAPIKey carries the key of a machine client, it is checked instead of other credentials when present.
+const HeaderAPIKey = "X-API-Key"
+
+// ErrInvalidCredentials marks credentials that are rejected with 401 rather than failing the request with 500.
+var ErrInvalidCredentials = errors.New("invalid credentials")
+
+// Identity is the authenticated caller of a request. Claims are nil for identities not taken from a token,
+// a ReadOnly identity may only perform safe requests.
+type Identity struct {
+	Subject  string
+	Role     string
+	ReadOnly bool
+	Claims   *jwt.Claims
+	// Tenant is set for callers whose credentials are bound to a tenant outside of token claims.
+	Tenant string
+	// APIKeyID is set for callers authenticated with an api key.
+	APIKeyID string
+}
+
+type identityCtxKey struct{}
+
+// WithIdentity returns a copy of ctx carrying the caller identity.
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityCtxKey{}, id)
+}
+
+// IdentityFromContext returns the caller identity stored by the auth middleware.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityCtxKey{}).(Identity)
+	return id, ok
+}
+
+// TokenVerifier checks a bearer token and returns its claims.
+type TokenVerifier interface {
+	Verify(token string) (*jwt.Claims, error)
+}
+
+// APIKeyAuthenticator resolves an api key to the identity of its owner,
+// it fails with ErrInvalidCredentials for keys that must not be accepted.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (Identity, error)
+}
+
+// NewAPIKeyAuthMiddleware authenticates requests carrying an api key in the X-API-Key header and rejects
+// invalid keys with 401. Requests without a key are left to the middleware authenticating other credentials,
+// which skips requests already authenticated here. The caller identity is stored in the echo context under
+// EnrichIdentityKey and in the request context.
+func NewAPIKeyAuthMiddleware(keys APIKeyAuthenticator, publicPaths []string, log *slog.Logger) echo.MiddlewareFunc {
+	log = log.With(
+		slog.String("component", "middleware.auth"),
+	)
+	public := pathSet(publicPaths)
+
+	log.Info("api key auth middleware enabled")
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(HeaderAPIKey)
+			if _, ok := public[req.URL.Path]; ok || key == "" {
+				return next(c)
+			}
+
+			id, err := keys.AuthenticateAPIKey(req.Context(), key)
+			if err != nil {
+				if errors.Is(err, ErrInvalidCredentials) {
+					return unauthorized(c, "invalid api key")
+				}
+				log.Error("failed to authenticate api key",
+					slog.String("request_id", c.Response().Header().Get(echo.HeaderXRequestID)),
+					slog.String("error", err.Error()),
+				)
+				return echo.NewHTTPError(http.StatusInternalServerError, map[string]string{"error": "internal server error"})
+			}
+			if id.ReadOnly && !isSafeMethod(req.Method) {
+				return echo.NewHTTPError(http.StatusForbidden, map[string]string{"error": "api key is read-only"})
+			}
+
+			setIdentity(c, id)
+			return next(c)
+		}
+	}
+}
+
+// NewAuthMiddleware rejects requests without a valid bearer token with 401, except requests to publicPaths
+// and requests authenticated with an api key before. The caller identity is stored in the echo context under
+// EnrichIdentityKey and in the request context, the role of a token holder is read from the roleClaim claim.
+func NewAuthMiddleware(verifier TokenVerifier, roleClaim string, publicPaths []string, log *slog.Logger) echo.MiddlewareFunc {
+	log = log.With(
+		slog.String("component", "middleware.auth"),
+	)
+	public := pathSet(publicPaths)
+
+	log.Info("auth middleware enabled")
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if skipAuthentication(req, public) {
+				return next(c)
+			}
+
+			token, ok := bearerToken(req)
+			if !ok {
+				return unauthorized(c, "bearer token or api key is required")
+			}
+
+			claims, err := verifier.Verify(token)
+			if err != nil {
+				log.Warn("token rejected",
+					slog.String("request_id", c.Response().Header().Get(echo.HeaderXRequestID)),
+					slog.String("error", err.Error()),
+				)
+				return unauthorized(c, "invalid bearer token")
+			}
+			if claims.Subject == "" {
+				return unauthorized(c, "token has no subject")
+			}
+
+			role, _ := claims.String(roleClaim)
+			setIdentity(c, Identity{Subject: claims.Subject, Role: role, Claims: claims})
+
+			return next(c)
+		}
+	}
+}
+
+// NewGatewayAuthMiddleware trusts the caller identity asserted by an authenticating gateway in the
+// userHeader and roleHeader request headers. It must only be used behind a gateway that overwrites
+// these headers on every request, otherwise any client can impersonate any user.
+func NewGatewayAuthMiddleware(userHeader, roleHeader string, publicPaths []string, log *slog.Logger) echo.MiddlewareFunc {
+	log = log.With(
+		slog.String("component", "middleware.auth"),
+	)
+	public := pathSet(publicPaths)
+
+	log.Info("gateway auth middleware enabled", slog.String("user_header", userHeader), slog.String("role_header", roleHeader))
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if skipAuthentication(req, public) {
+				return next(c)
+			}
+
+			subject := strings.TrimSpace(req.Header.Get(userHeader))
+			if subject == "" {
+				return unauthorized(c, "caller identity is required")
+			}
+
+			setIdentity(c, Identity{Subject: subject, Role: strings.TrimSpace(req.Header.Get(roleHeader))})
+			return next(c)
+		}
+	}
+}
+
+// skipAuthentication tells whether the request needs no credentials or was authenticated already.
+func skipAuthentication(req *http.Request, public map[string]struct{}) bool {
+	if _, ok := public[req.URL.Path]; ok {
+		return true
+	}
+	_, ok := IdentityFromContext(req.Context())
+	return ok
+}
+
+func isSafeMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+func setIdentity(c echo.Context, id Identity) {
+	c.Set(EnrichIdentityKey, id)
+	c.SetRequest(c.Request().WithContext(WithIdentity(c.Request().Context(), id)))
+}
+
+func pathSet(paths []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		set[p] = struct{}{}
+	}
+
+	return set
+}
+
+func bearerToken(req *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(req.Header.Get(echo.HeaderAuthorization), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func unauthorized(c echo.Context, message string) error {
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="api"`)
+	return echo.NewHTTPError(http.StatusUnauthorized, map[string]string{"error": message})
+}
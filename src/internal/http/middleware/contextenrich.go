@@ -6,6 +6,9 @@ import (
 
 const EnrichReqIDKey string = "enrich:request_id"
 
+// EnrichIdentityKey holds the authenticated caller Identity, it is set by the auth middleware.
+const EnrichIdentityKey string = "enrich:identity"
+
 func NewEnrichRequestContextMiddleware() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
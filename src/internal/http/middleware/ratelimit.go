@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"effective-mobile/pkg/ratelimit"
+	"fmt"
+	"log/slog"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	HeaderRateLimitLimit     = "X-RateLimit-Limit"
+	HeaderRateLimitRemaining = "X-RateLimit-Remaining"
+	HeaderRateLimitReset     = "X-RateLimit-Reset"
+)
+
+// RateLimitRule limits requests to the route Path, written as the echo route pattern such as "/subscriptions/:id".
+// An empty Method matches every method of the route.
+type RateLimitRule struct {
+	Method string
+	Path   string
+	Limit  ratelimit.Limit
+}
+
+// NewAddressRateLimitMiddleware rejects clients exceeding their quota with 429, counting every request against
+// the IP address it comes from as told by the IP extractor of the echo instance. It is registered before the
+// auth middleware, so requests with made-up credentials are limited before any of them is looked up.
+func NewAddressRateLimitMiddleware(store ratelimit.Store, def ratelimit.Limit, rules []RateLimitRule, log *slog.Logger) echo.MiddlewareFunc {
+	return newRateLimitMiddleware(store, def, rules, "address", log, func(c echo.Context) string {
+		return "ip:" + c.RealIP()
+	})
+}
+
+// NewRateLimitMiddleware rejects callers exceeding their quota with 429, counting requests against the api key
+// or the user they were authenticated as. It is registered after the auth middleware, so only verified
+// credentials get a bucket of their own, requests without an identity are left to the address rate limit.
+func NewRateLimitMiddleware(store ratelimit.Store, def ratelimit.Limit, rules []RateLimitRule, log *slog.Logger) echo.MiddlewareFunc {
+	return newRateLimitMiddleware(store, def, rules, "identity", log, func(c echo.Context) string {
+		id, ok := IdentityFromContext(c.Request().Context())
+		switch {
+		case !ok:
+			return ""
+		case id.APIKeyID != "":
+			return "key:" + id.APIKeyID
+		default:
+			return "user:" + id.Subject
+		}
+	})
+}
+
+// newRateLimitMiddleware counts requests against the bucket of the client named by client, requests it names no
+// client for pass uncounted. Every rule gives a client its own bucket, requests to routes without a rule share
+// a bucket limited by def and a zero def leaves them unlimited. Requests pass when the store fails, an outage
+// of the store must not take the API down with it.
+func newRateLimitMiddleware(store ratelimit.Store, def ratelimit.Limit, rules []RateLimitRule, counted string, log *slog.Logger, client func(c echo.Context) string) echo.MiddlewareFunc {
+	log = log.With(
+		slog.String("component", "middleware.ratelimit"),
+		slog.String("counted_by", counted),
+	)
+
+	log.Info("rate limit middleware enabled", slog.Int("rules", len(rules)))
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			bucket, limit := "default", def
+			for _, r := range rules {
+				if r.Path == c.Path() && (r.Method == "" || r.Method == c.Request().Method) {
+					bucket, limit = r.Method+" "+r.Path, r.Limit
+					break
+				}
+			}
+			if limit.IsZero() {
+				return next(c)
+			}
+			name := client(c)
+			if name == "" {
+				return next(c)
+			}
+
+			d, err := store.Take(c.Request().Context(), name+"|"+bucket, limit)
+			if err != nil {
+				log.Error("failed to check rate limit",
+					slog.String("request_id", c.Response().Header().Get(echo.HeaderXRequestID)),
+					slog.String("error", err.Error()),
+				)
+				return next(c)
+			}
+
+			h := c.Response().Header()
+			h.Set(HeaderRateLimitLimit, strconv.Itoa(d.Limit))
+			h.Set(HeaderRateLimitRemaining, strconv.Itoa(d.Remaining))
+			h.Set(HeaderRateLimitReset, strconv.Itoa(ceilSeconds(d.ResetAfter)))
+			if !d.Allowed {
+				h.Set(echo.HeaderRetryAfter, strconv.Itoa(ceilSeconds(d.RetryAfter)))
+				return echo.NewHTTPError(http.StatusTooManyRequests, map[string]string{"error": "rate limit exceeded"})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// NewIPExtractor tells the client address of a request. Without trusted proxies it is the address of the
+// connection, otherwise the X-Forwarded-For header is followed back through the proxies in the trusted CIDR
+// ranges, so clients can not pick their address by sending the header themselves.
+func NewIPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, p := range trustedProxies {
+		_, ipNet, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy range %q: %w", p, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
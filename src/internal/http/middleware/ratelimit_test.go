@@ -0,0 +1,196 @@
+package middleware
+
+import (
+	"context"
+	"effective-mobile/pkg/ratelimit"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// failingStore stands for a store that is down.
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("store is down")
+}
+
+// fakeAPIKeys knows the keys by their key string.
+type fakeAPIKeys map[string]Identity
+
+func (k fakeAPIKeys) AuthenticateAPIKey(_ context.Context, key string) (Identity, error) {
+	id, ok := k[key]
+	if !ok {
+		return Identity{}, ErrInvalidCredentials
+	}
+	return id, nil
+}
+
+// newRateLimitedServer allows every client address and every api key a single request to /limited and to the
+// other routes each per hour. The only valid api key is "emk_valid".
+func newRateLimitedServer(t *testing.T, store ratelimit.Store, extractIP echo.IPExtractor) *echo.Echo {
+	t.Helper()
+
+	e := echo.New()
+	e.IPExtractor = extractIP
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	once := ratelimit.Limit{Requests: 1, Per: time.Hour}
+	rules := []RateLimitRule{{Method: http.MethodGet, Path: "/limited", Limit: once}}
+	keys := fakeAPIKeys{"emk_valid": {Subject: "owner", APIKeyID: "key"}}
+	e.Use(NewAddressRateLimitMiddleware(store, once, rules, log))
+	e.Use(NewAPIKeyAuthMiddleware(keys, nil, log))
+	e.Use(NewRateLimitMiddleware(store, once, rules, log))
+
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/limited", ok)
+	e.GET("/other", ok)
+
+	return e
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	type request struct {
+		path       string
+		remoteAddr string
+		headers    map[string]string
+		wantStatus int
+	}
+	const client, other = "203.0.113.7:4000", "198.51.100.1:4000"
+
+	tests := []struct {
+		name     string
+		requests []request
+	}{
+		{
+			name: "quota exceeded",
+			requests: []request{
+				{path: "/limited", remoteAddr: client, wantStatus: http.StatusOK},
+				{path: "/limited", remoteAddr: client, wantStatus: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "routes have own buckets",
+			requests: []request{
+				{path: "/limited", remoteAddr: client, wantStatus: http.StatusOK},
+				{path: "/other", remoteAddr: client, wantStatus: http.StatusOK},
+				{path: "/other", remoteAddr: client, wantStatus: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "clients are counted by address",
+			requests: []request{
+				{path: "/limited", remoteAddr: client, wantStatus: http.StatusOK},
+				{path: "/limited", remoteAddr: other, wantStatus: http.StatusOK},
+			},
+		},
+		{
+			name: "forwarded address of an untrusted client is ignored",
+			requests: []request{
+				{path: "/limited", remoteAddr: client, wantStatus: http.StatusOK},
+				{path: "/limited", remoteAddr: client, headers: map[string]string{echo.HeaderXForwardedFor: "192.0.2.1"}, wantStatus: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "made-up keys from one address are limited",
+			requests: []request{
+				{path: "/limited", remoteAddr: client, headers: map[string]string{HeaderAPIKey: "emk_one"}, wantStatus: http.StatusUnauthorized},
+				{path: "/limited", remoteAddr: client, headers: map[string]string{HeaderAPIKey: "emk_two"}, wantStatus: http.StatusTooManyRequests},
+				{path: "/limited", remoteAddr: client, headers: map[string]string{HeaderAPIKey: "emk_valid"}, wantStatus: http.StatusTooManyRequests},
+			},
+		},
+		{
+			name: "verified keys are counted on their own",
+			requests: []request{
+				{path: "/limited", remoteAddr: client, headers: map[string]string{HeaderAPIKey: "emk_valid"}, wantStatus: http.StatusOK},
+				{path: "/limited", remoteAddr: other, headers: map[string]string{HeaderAPIKey: "emk_valid"}, wantStatus: http.StatusTooManyRequests},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newRateLimitedServer(t, ratelimit.NewMemoryStore(), echo.ExtractIPDirect())
+
+			for i, r := range tt.requests {
+				req := httptest.NewRequest(http.MethodGet, r.path, nil)
+				req.RemoteAddr = r.remoteAddr
+				for k, v := range r.headers {
+					req.Header.Set(k, v)
+				}
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, req)
+
+				if rec.Code != r.wantStatus {
+					t.Fatalf("request %d: status = %d, want %d", i+1, rec.Code, r.wantStatus)
+				}
+				if rec.Header().Get(HeaderRateLimitLimit) != "1" {
+					t.Errorf("request %d: %s = %q, want 1", i+1, HeaderRateLimitLimit, rec.Header().Get(HeaderRateLimitLimit))
+				}
+				if r.wantStatus == http.StatusTooManyRequests && rec.Header().Get(echo.HeaderRetryAfter) != "3600" {
+					t.Errorf("request %d: %s = %q, want 3600", i+1, echo.HeaderRetryAfter, rec.Header().Get(echo.HeaderRetryAfter))
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitMiddlewarePassesWhenStoreFails(t *testing.T) {
+	e := newRateLimitedServer(t, failingStore{}, echo.ExtractIPDirect())
+
+	req := httptest.NewRequest(http.MethodGet, "/limited", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestNewIPExtractor(t *testing.T) {
+	tests := []struct {
+		name           string
+		trustedProxies []string
+		remoteAddr     string
+		forwardedFor   string
+		want           string
+	}{
+		{name: "direct", remoteAddr: "203.0.113.7:4000", forwardedFor: "192.0.2.1", want: "203.0.113.7"},
+		{name: "trusted proxy", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "10.1.2.3:4000", forwardedFor: "192.0.2.1", want: "192.0.2.1"},
+		{
+			name:           "client spoofing behind trusted proxy",
+			trustedProxies: []string{"10.0.0.0/8"},
+			remoteAddr:     "10.1.2.3:4000",
+			forwardedFor:   "192.0.2.99, 192.0.2.1",
+			want:           "192.0.2.1",
+		},
+		{name: "untrusted proxy", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "203.0.113.7:4000", forwardedFor: "192.0.2.1", want: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			extract, err := NewIPExtractor(tt.trustedProxies)
+			if err != nil {
+				t.Fatalf("NewIPExtractor() error = %v", err)
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, tt.forwardedFor)
+			if got := extract(req); got != tt.want {
+				t.Errorf("client address = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewIPExtractorRejectsInvalidRanges(t *testing.T) {
+	if _, err := NewIPExtractor([]string{"10.0.0.1"}); err == nil {
+		t.Error("NewIPExtractor() succeeded, want an error")
+	}
+}
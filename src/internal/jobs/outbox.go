@@ -0,0 +1,23 @@
+package jobs
+
+import (
+	"context"
+	"effective-mobile/internal/service"
+	"time"
+)
+
+// RelayOutbox publishes lifecycle events waiting in the outbox.
+func RelayOutbox(relay service.OutboxRelay, batchSize int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := relay.RelayEvents(ctx, batchSize)
+		return err
+	}
+}
+
+// PurgeDispatchedEvents removes events that were dispatched longer than retention ago.
+func PurgeDispatchedEvents(relay service.OutboxRelay, retention time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := relay.PurgeDispatchedEvents(ctx, retention)
+		return err
+	}
+}
@@ -0,0 +1,15 @@
+package jobs
+
+import (
+	"context"
+	"effective-mobile/internal/storage"
+	"time"
+)
+
+// PurgeIdleRateLimits removes rate limit buckets not used for longer than ttl, they have refilled long ago.
+func PurgeIdleRateLimits(s storage.RateLimitStorage, ttl time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := s.PurgeIdleBefore(ctx, time.Now().UTC().Add(-ttl))
+		return err
+	}
+}
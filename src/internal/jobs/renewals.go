@@ -0,0 +1,14 @@
+package jobs
+
+import (
+	"context"
+	"effective-mobile/internal/service"
+)
+
+// SendRenewalReminders reminds users of charges due within daysBefore days.
+func SendRenewalReminders(svc service.RenewalService, daysBefore int) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := svc.SendRenewalReminders(ctx, daysBefore)
+		return err
+	}
+}
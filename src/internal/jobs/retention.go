@@ -0,0 +1,15 @@
+package jobs
+
+import (
+	"context"
+	"effective-mobile/internal/service"
+	"time"
+)
+
+// PurgeDeletedSubscriptions removes subscriptions that stayed soft-deleted longer than retention.
+func PurgeDeletedSubscriptions(svc service.SubscriptionService, retention time.Duration) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		_, err := svc.PurgeExpiredSubscriptions(ctx, retention)
+		return err
+	}
+}
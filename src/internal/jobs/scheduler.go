@@ -0,0 +1,89 @@
+package jobs
+
+import (
+	"context"
+	"effective-mobile/internal/service"
+	"effective-mobile/pkg/logger/sl"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Scheduler runs background jobs periodically until it is stopped.
+type Scheduler struct {
+	log    *slog.Logger
+	jobs   []job
+	wg     sync.WaitGroup
+	cancel context.CancelFunc
+}
+
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+func NewScheduler(log *slog.Logger) *Scheduler {
+	return &Scheduler{
+		log: log.With(slog.String("component", "Scheduler")),
+	}
+}
+
+// Every registers a job that runs once right after Start and then every interval.
+func (s *Scheduler) Every(name string, interval time.Duration, run func(ctx context.Context) error) {
+	s.jobs = append(s.jobs, job{name: name, interval: interval, run: run})
+}
+
+// Start runs the registered jobs as service.SystemPrincipal, it fails without starting any of them when a job has a non-positive interval.
+func (s *Scheduler) Start() error {
+	for _, j := range s.jobs {
+		if j.interval <= 0 {
+			return fmt.Errorf("job %s: interval must be positive, got %s", j.name, j.interval)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(service.WithPrincipal(context.Background(), service.SystemPrincipal))
+	s.cancel = cancel
+
+	for _, j := range s.jobs {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.loop(ctx, j)
+		}()
+	}
+
+	return nil
+}
+
+// Stop cancels running jobs and waits for them to return.
+func (s *Scheduler) Stop() {
+	if s.cancel == nil {
+		return
+	}
+
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, j job) {
+	log := s.log.With(slog.String("job", j.name))
+	log.Info("job scheduled", slog.String("interval", j.interval.String()))
+
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.run(ctx); err != nil && ctx.Err() == nil {
+			log.Error("job failed", sl.Err(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("job stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"context"
+	"effective-mobile/internal/service"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+func TestSchedulerRejectsNonPositiveInterval(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		t.Run(interval.String(), func(t *testing.T) {
+			s := NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+			ran := make(chan struct{}, 1)
+			s.Every("ok", time.Hour, func(ctx context.Context) error { return nil })
+			s.Every("broken", interval, func(ctx context.Context) error {
+				ran <- struct{}{}
+				return nil
+			})
+
+			if err := s.Start(); err == nil {
+				t.Fatal("Start() succeeded, want an error")
+			}
+			s.Stop()
+
+			select {
+			case <-ran:
+				t.Error("job with an invalid interval ran")
+			default:
+			}
+		})
+	}
+}
+
+func TestSchedulerRunsJobRightAfterStart(t *testing.T) {
+	s := NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	ran := make(chan struct{}, 1)
+	s.Every("job", time.Hour, func(ctx context.Context) error {
+		select {
+		case ran <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer s.Stop()
+
+	select {
+	case <-ran:
+	case <-time.After(time.Second):
+		t.Fatal("job did not run after Start")
+	}
+}
+
+func TestSchedulerRunsJobsAsSystem(t *testing.T) {
+	s := NewScheduler(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	principals := make(chan service.Principal, 1)
+	s.Every("job", time.Hour, func(ctx context.Context) error {
+		p, _ := service.PrincipalFromContext(ctx)
+		select {
+		case principals <- p:
+		default:
+		}
+		return nil
+	})
+
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer s.Stop()
+
+	select {
+	case p := <-principals:
+		if p != service.SystemPrincipal {
+			t.Errorf("job principal = %+v, want %+v", p, service.SystemPrincipal)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("job did not run after Start")
+	}
+}
@@ -0,0 +1,143 @@
+package models
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type APIKeyID = uuid.UUID
+
+type APIKeyScope string
+
+const (
+	// ScopeRead allows reading the data of the key owner.
+	ScopeRead APIKeyScope = "read"
+	// ScopeWrite allows changing the data of the key owner, it implies ScopeRead.
+	ScopeWrite APIKeyScope = "write"
+	// ScopeAdmin grants the admin role, it implies every other scope.
+	ScopeAdmin APIKeyScope = "admin"
+)
+
+func (s APIKeyScope) IsValid() bool {
+	switch s {
+	case ScopeRead, ScopeWrite, ScopeAdmin:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIKeyPrefix starts every issued key, so leaked keys are easy to spot.
+const APIKeyPrefix = "emk_"
+
+// apiKeyHintLength is the number of leading key characters stored in clear to tell keys apart.
+const apiKeyHintLength = len(APIKeyPrefix) + 8
+
+// APIKey authenticates a machine client acting for its owner within Tenant. Only a hash of the key
+// is kept, the key itself is shown once when it is issued.
+type APIKey struct {
+	ID         APIKeyID
+	Tenant     TenantID
+	Name       string
+	Owner      PersonID
+	Hint       string
+	Hash       []byte
+	Scopes     []APIKeyScope
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time
+	RevokedAt  *time.Time
+}
+
+// NewAPIKey issues a key for the owner in the tenant and returns it along with the secret key string.
+func NewAPIKey(tenant TenantID, name string, owner PersonID, scopes []APIKeyScope, expiresAt *time.Time) (*APIKey, string, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, "", fmt.Errorf("can not create api key: could not generate api key id")
+	}
+
+	if err := ValidateTenantID(tenant); err != nil {
+		return nil, "", fmt.Errorf("invalid api key: %w", err)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", fmt.Errorf("invalid api key: name is not provided")
+	}
+	if owner == uuid.Nil {
+		return nil, "", fmt.Errorf("invalid api key: owner is not provided")
+	}
+
+	if len(scopes) == 0 {
+		return nil, "", fmt.Errorf("invalid api key: no scopes are provided")
+	}
+	unique := make([]APIKeyScope, 0, len(scopes))
+	for _, s := range scopes {
+		if !s.IsValid() {
+			return nil, "", fmt.Errorf("invalid api key: unknown scope %q", s)
+		}
+		if !slices.Contains(unique, s) {
+			unique = append(unique, s)
+		}
+	}
+
+	now := time.Now().UTC()
+	if expiresAt != nil {
+		if !expiresAt.After(now) {
+			return nil, "", fmt.Errorf("invalid api key: expiry must be in the future")
+		}
+		utc := expiresAt.UTC()
+		expiresAt = &utc
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("can not create api key: could not generate key")
+	}
+	key := APIKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	return &APIKey{
+		ID:        id,
+		Tenant:    tenant,
+		Name:      name,
+		Owner:     owner,
+		Hint:      key[:apiKeyHintLength],
+		Hash:      HashAPIKey(key),
+		Scopes:    unique,
+		ExpiresAt: expiresAt,
+		CreatedAt: now,
+	}, key, nil
+}
+
+// HashAPIKey returns the digest keys are stored and looked up by.
+func HashAPIKey(key string) []byte {
+	sum := sha256.Sum256([]byte(key))
+	return sum[:]
+}
+
+// Allows tells whether the key was granted the scope directly or through a broader one.
+func (k *APIKey) Allows(scope APIKeyScope) bool {
+	for _, s := range k.Scopes {
+		if s == scope || s == ScopeAdmin || (s == ScopeWrite && scope == ScopeRead) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsActive tells whether the key is neither revoked nor expired at the given moment.
+func (k *APIKey) IsActive(at time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || at.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	owner := uuid.New()
+	future := time.Now().Add(time.Hour)
+	past := time.Now().Add(-time.Hour)
+
+	k, key, err := NewAPIKey("acme", " billing cron ", owner, []APIKeyScope{ScopeRead, ScopeWrite, ScopeRead}, &future)
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix) || !strings.HasPrefix(key, k.Hint) || len(k.Hint) >= len(key) {
+		t.Errorf("hint %q does not tell key %q apart without revealing it", k.Hint, key)
+	}
+	if !bytes.Equal(k.Hash, HashAPIKey(key)) {
+		t.Error("stored hash does not match the issued key")
+	}
+	if k.Tenant != "acme" || k.Name != "billing cron" || k.Owner != owner {
+		t.Errorf("key = %+v, want tenant acme, name %q and owner %s", k, "billing cron", owner)
+	}
+	if !slices.Equal(k.Scopes, []APIKeyScope{ScopeRead, ScopeWrite}) {
+		t.Errorf("scopes = %v, want duplicates dropped", k.Scopes)
+	}
+
+	_, other, err := NewAPIKey("acme", "billing cron", owner, []APIKeyScope{ScopeRead}, nil)
+	if err != nil {
+		t.Fatalf("NewAPIKey() error = %v", err)
+	}
+	if other == key || bytes.Equal(HashAPIKey(other), k.Hash) {
+		t.Error("two issued keys are equal")
+	}
+
+	invalid := []struct {
+		name      string
+		tenant    TenantID
+		keyName   string
+		owner     PersonID
+		scopes    []APIKeyScope
+		expiresAt *time.Time
+	}{
+		{name: "no tenant", keyName: "cron", owner: owner, scopes: []APIKeyScope{ScopeRead}},
+		{name: "invalid tenant", tenant: "Acme Corp", keyName: "cron", owner: owner, scopes: []APIKeyScope{ScopeRead}},
+		{name: "blank name", tenant: "acme", keyName: "  ", owner: owner, scopes: []APIKeyScope{ScopeRead}},
+		{name: "no owner", tenant: "acme", keyName: "cron", scopes: []APIKeyScope{ScopeRead}},
+		{name: "no scopes", tenant: "acme", keyName: "cron", owner: owner},
+		{name: "unknown scope", tenant: "acme", keyName: "cron", owner: owner, scopes: []APIKeyScope{"delete"}},
+		{name: "expired", tenant: "acme", keyName: "cron", owner: owner, scopes: []APIKeyScope{ScopeRead}, expiresAt: &past},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := NewAPIKey(tt.tenant, tt.keyName, tt.owner, tt.scopes, tt.expiresAt); err == nil {
+				t.Error("NewAPIKey() succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestAPIKeyAllows(t *testing.T) {
+	tests := []struct {
+		scopes []APIKeyScope
+		scope  APIKeyScope
+		want   bool
+	}{
+		{scopes: []APIKeyScope{ScopeRead}, scope: ScopeRead, want: true},
+		{scopes: []APIKeyScope{ScopeRead}, scope: ScopeWrite, want: false},
+		{scopes: []APIKeyScope{ScopeRead}, scope: ScopeAdmin, want: false},
+		{scopes: []APIKeyScope{ScopeWrite}, scope: ScopeRead, want: true},
+		{scopes: []APIKeyScope{ScopeWrite}, scope: ScopeAdmin, want: false},
+		{scopes: []APIKeyScope{ScopeAdmin}, scope: ScopeRead, want: true},
+		{scopes: []APIKeyScope{ScopeAdmin}, scope: ScopeWrite, want: true},
+		{scopes: nil, scope: ScopeRead, want: false},
+	}
+
+	for _, tt := range tests {
+		k := APIKey{Scopes: tt.scopes}
+		if got := k.Allows(tt.scope); got != tt.want {
+			t.Errorf("key with %v: Allows(%s) = %v, want %v", tt.scopes, tt.scope, got, tt.want)
+		}
+	}
+}
+
+func TestAPIKeyIsActive(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	before, after := now.Add(-time.Minute), now.Add(time.Minute)
+
+	tests := []struct {
+		name string
+		key  APIKey
+		want bool
+	}{
+		{name: "never expires", key: APIKey{}, want: true},
+		{name: "expires later", key: APIKey{ExpiresAt: &after}, want: true},
+		{name: "expires now", key: APIKey{ExpiresAt: &now}, want: false},
+		{name: "expired", key: APIKey{ExpiresAt: &before}, want: false},
+		{name: "revoked", key: APIKey{RevokedAt: &before}, want: false},
+		{name: "revoked before expiry", key: APIKey{ExpiresAt: &after, RevokedAt: &before}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.key.IsActive(now); got != tt.want {
+				t.Errorf("IsActive() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
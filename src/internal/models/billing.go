@@ -0,0 +1,144 @@
+package models
+
+import "time"
+
+type BillingPeriod string
+
+const (
+	BillingMonthly BillingPeriod = "monthly"
+	BillingYearly  BillingPeriod = "yearly"
+	BillingWeekly  BillingPeriod = "weekly"
+	BillingOneOff  BillingPeriod = "one_off"
+)
+
+func (p BillingPeriod) IsValid() bool {
+	switch p {
+	case BillingMonthly, BillingYearly, BillingWeekly, BillingOneOff:
+		return true
+	default:
+		return false
+	}
+}
+
+// ChargesWithin returns the dates on which the subscription is charged within the [from, to] window.
+// Monthly, yearly and one-off charges are counted per calendar month, so a charge falls into the
+// window whenever its billing month overlaps it; weekly charges are matched by exact date.
+// An open-ended subscription is charged up to the window end.
+func (s *Subscription) ChargesWithin(from, to time.Time) []time.Time {
+	if s.Period == BillingWeekly {
+		return s.weeklyChargesWithin(from, to)
+	}
+
+	step := 1
+	switch s.Period {
+	case BillingYearly:
+		step = 12
+	case BillingOneOff:
+		step = 0
+	}
+
+	startMonth := monthIndex(s.StartedAt)
+	firstMonth := monthIndex(from)
+	lastMonth := monthIndex(to)
+	if s.IsCompleted() {
+		lastMonth = min(lastMonth, monthIndex(*s.CompletedAt))
+	}
+
+	var charges []time.Time
+	k := 0
+	if step > 0 && firstMonth > startMonth {
+		k = (firstMonth - startMonth + step - 1) / step
+	}
+	for ; startMonth+k*step <= lastMonth; k++ {
+		if startMonth+k*step >= firstMonth {
+			charges = append(charges, addMonthsClamped(s.StartedAt, k*step))
+		}
+		if step == 0 {
+			break
+		}
+	}
+
+	return charges
+}
+
+// BilledPeriods returns the number of charges of the subscription within the [from, to] window.
+func (s *Subscription) BilledPeriods(from, to time.Time) int {
+	return len(s.ChargesWithin(from, to))
+}
+
+// ChargeDatesBetween returns the exact dates on which the subscription is charged within the [from, to] window.
+// Unlike ChargesWithin it does not round to billing months and nothing is charged after the subscription ends.
+func (s *Subscription) ChargeDatesBetween(from, to time.Time) []time.Time {
+	last := to.UTC()
+	if s.IsCompleted() && s.CompletedAt.Before(last) {
+		last = *s.CompletedAt
+	}
+
+	start := s.StartedAt.UTC()
+	switch s.Period {
+	case BillingWeekly:
+		return s.weeklyChargesWithin(from, last)
+	case BillingOneOff:
+		if start.Before(from) || start.After(last) {
+			return nil
+		}
+		return []time.Time{start}
+	}
+
+	step := 1
+	if s.Period == BillingYearly {
+		step = 12
+	}
+
+	k := 0
+	if months := monthIndex(from) - monthIndex(start); months > 0 {
+		k = (months + step - 1) / step
+	}
+
+	var charges []time.Time
+	for charge := addMonthsClamped(start, k*step); !charge.After(last); charge = addMonthsClamped(start, k*step) {
+		if !charge.Before(from) {
+			charges = append(charges, charge)
+		}
+		k++
+	}
+
+	return charges
+}
+
+func (s *Subscription) weeklyChargesWithin(from, to time.Time) []time.Time {
+	const week = 7 * 24 * time.Hour
+
+	last := to.UTC()
+	if s.IsCompleted() && s.CompletedAt.Before(last) {
+		last = *s.CompletedAt
+	}
+
+	start := s.StartedAt.UTC()
+	k := 0
+	if from.After(start) {
+		k = int((from.Sub(start) + week - 1) / week)
+	}
+
+	var charges []time.Time
+	for charge := start.AddDate(0, 0, 7*k); !charge.After(last); charge = charge.AddDate(0, 0, 7) {
+		charges = append(charges, charge)
+	}
+
+	return charges
+}
+
+// addMonthsClamped shifts t by the given number of months, clamping the day to the length
+// of the target month instead of overflowing into the next one.
+func addMonthsClamped(t time.Time, months int) time.Time {
+	t = t.UTC()
+	first := time.Date(t.Year(), t.Month()+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	return first.AddDate(0, 0, min(t.Day(), lastDay)-1)
+}
+
+func monthIndex(t time.Time) int {
+	t = t.UTC()
+	return t.Year()*12 + int(t.Month()) - 1
+}
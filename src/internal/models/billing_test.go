@@ -0,0 +1,116 @@
+package models
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestChargesWithin(t *testing.T) {
+	day := func(year int, month time.Month, d int) time.Time {
+		return time.Date(year, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		period   BillingPeriod
+		start    time.Time
+		end      *time.Time
+		from, to time.Time
+		want     []time.Time
+	}{
+		{
+			name:   "start on the 31st clamped to shorter months",
+			period: BillingMonthly,
+			start:  day(2024, 1, 31),
+			from:   day(2024, 1, 1),
+			to:     day(2024, 4, 30),
+			want:   []time.Time{day(2024, 1, 31), day(2024, 2, 29), day(2024, 3, 31), day(2024, 4, 30)},
+		},
+		{
+			name:   "clamping does not carry over to later months",
+			period: BillingMonthly,
+			start:  day(2023, 11, 30),
+			from:   day(2024, 2, 1),
+			to:     day(2024, 3, 31),
+			want:   []time.Time{day(2024, 2, 29), day(2024, 3, 30)},
+		},
+		{
+			name:   "partial first and last month",
+			period: BillingMonthly,
+			start:  day(2024, 1, 15),
+			from:   day(2024, 2, 20),
+			to:     day(2024, 4, 10),
+			want:   []time.Time{day(2024, 2, 15), day(2024, 3, 15), day(2024, 4, 15)},
+		},
+		{
+			name:   "ended within the window",
+			period: BillingMonthly,
+			start:  day(2024, 1, 15),
+			end:    ptr(day(2024, 3, 10)),
+			from:   day(2024, 1, 1),
+			to:     day(2024, 12, 31),
+			want:   []time.Time{day(2024, 1, 15), day(2024, 2, 15), day(2024, 3, 15)},
+		},
+		{
+			name:   "open-ended charged up to the window end",
+			period: BillingMonthly,
+			start:  day(2023, 6, 1),
+			from:   day(2024, 11, 1),
+			to:     day(2025, 1, 31),
+			want:   []time.Time{day(2024, 11, 1), day(2024, 12, 1), day(2025, 1, 1)},
+		},
+		{
+			name:   "started after the window",
+			period: BillingMonthly,
+			start:  day(2024, 5, 1),
+			from:   day(2024, 1, 1),
+			to:     day(2024, 4, 30),
+		},
+		{
+			name:   "yearly from a leap day",
+			period: BillingYearly,
+			start:  day(2020, 2, 29),
+			from:   day(2021, 1, 1),
+			to:     day(2024, 12, 31),
+			want:   []time.Time{day(2021, 2, 28), day(2022, 2, 28), day(2023, 2, 28), day(2024, 2, 29)},
+		},
+		{
+			name:   "yearly not due within the window",
+			period: BillingYearly,
+			start:  day(2024, 3, 10),
+			from:   day(2024, 4, 1),
+			to:     day(2025, 2, 28),
+		},
+		{
+			name:   "one-off within the window",
+			period: BillingOneOff,
+			start:  day(2024, 3, 10),
+			from:   day(2024, 3, 1),
+			to:     day(2024, 12, 31),
+			want:   []time.Time{day(2024, 3, 10)},
+		},
+		{
+			name:   "one-off before the window",
+			period: BillingOneOff,
+			start:  day(2024, 1, 10),
+			from:   day(2024, 3, 1),
+			to:     day(2024, 12, 31),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Subscription{Period: tt.period, StartedAt: tt.start, CompletedAt: tt.end}
+
+			got := s.ChargesWithin(tt.from, tt.to)
+			if !slices.EqualFunc(got, tt.want, time.Time.Equal) {
+				t.Errorf("ChargesWithin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type ChangeAction string
+
+const (
+	ChangeCreated  ChangeAction = "created"
+	ChangeUpdated  ChangeAction = "updated"
+	ChangeDeleted  ChangeAction = "deleted"
+	ChangeRestored ChangeAction = "restored"
+)
+
+// SubscriptionChange is an append-only history entry holding the subscription row
+// before and after the change as JSON.
+type SubscriptionChange struct {
+	ID             int64
+	SubscriptionID SubscriptionID
+	Action         ChangeAction
+	ChangedAt      time.Time
+	ChangedBy      *string
+	RequestID      *string
+	OldValue       json.RawMessage
+	NewValue       json.RawMessage
+}
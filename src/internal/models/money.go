@@ -0,0 +1,108 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Currency is an ISO 4217 alphabetic currency code.
+type Currency string
+
+const DefaultCurrency Currency = "RUB"
+
+func ParseCurrency(code string) (Currency, error) {
+	c := Currency(strings.ToUpper(strings.TrimSpace(code)))
+	if !c.IsValid() {
+		return "", fmt.Errorf("invalid currency code %q", code)
+	}
+
+	return c, nil
+}
+
+func (c Currency) IsValid() bool {
+	if len(c) != 3 {
+		return false
+	}
+
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// minorUnitDigits lists the ISO 4217 currencies whose minor unit is not a hundredth of the major one.
+var minorUnitDigits = map[Currency]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// MinorUnits returns how many minor units make up one whole unit of the currency.
+func (c Currency) MinorUnits() int64 {
+	digits, ok := minorUnitDigits[c]
+	if !ok {
+		digits = 2
+	}
+
+	units := int64(1)
+	for range digits {
+		units *= 10
+	}
+
+	return units
+}
+
+// Money is an amount in minor units of its currency (e.g. kopecks for RUB, cents for USD).
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+func NewMoney(amount int64, currency Currency) (Money, error) {
+	if amount < 0 {
+		return Money{}, fmt.Errorf("amount must not be negative")
+	}
+
+	if currency == "" {
+		currency = DefaultCurrency
+	} else if !currency.IsValid() {
+		return Money{}, fmt.Errorf("invalid currency code %q", currency)
+	}
+
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// MoneyFromUnits returns the amount of whole currency units in minor units, an empty currency means DefaultCurrency.
+func MoneyFromUnits(units int64, currency Currency) Money {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	return Money{Amount: units * currency.MinorUnits(), Currency: currency}
+}
+
+// Units returns the amount in whole units of the currency, the fraction is dropped.
+func (m Money) Units() int64 {
+	return m.Amount / m.Currency.MinorUnits()
+}
+
+func (m Money) Times(n int) Money {
+	return Money{Amount: m.Amount * int64(n), Currency: m.Currency}
+}
+
+// Convert returns the amount exchanged at the given rate, rounded to the nearest minor unit.
+func (m Money) Convert(rate float64, to Currency) Money {
+	if m.Currency == to {
+		return m
+	}
+
+	return Money{Amount: int64(math.Round(float64(m.Amount) * rate)), Currency: to}
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+}
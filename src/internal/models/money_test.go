@@ -0,0 +1,36 @@
+package models
+
+import "testing"
+
+func TestMoneyUnits(t *testing.T) {
+	tests := []struct {
+		name      string
+		units     int64
+		currency  Currency
+		wantMinor int64
+	}{
+		{name: "kopecks", units: 199, currency: "RUB", wantMinor: 19900},
+		{name: "default currency", units: 5, currency: "", wantMinor: 500},
+		{name: "no minor unit", units: 1200, currency: "JPY", wantMinor: 1200},
+		{name: "three digit minor unit", units: 3, currency: "KWD", wantMinor: 3000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := MoneyFromUnits(tt.units, tt.currency)
+			if m.Amount != tt.wantMinor {
+				t.Errorf("amount = %d, want %d", m.Amount, tt.wantMinor)
+			}
+			if got := m.Units(); got != tt.units {
+				t.Errorf("units = %d, want %d", got, tt.units)
+			}
+		})
+	}
+}
+
+func TestMoneyUnitsDropsFraction(t *testing.T) {
+	m := Money{Amount: 19999, Currency: "USD"}
+	if got := m.Units(); got != 199 {
+		t.Errorf("units = %d, want 199", got)
+	}
+}
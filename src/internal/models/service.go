@@ -0,0 +1,115 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type ServiceID = uuid.UUID
+
+// Service is a catalog entry every subscription's service name is resolved to.
+type Service struct {
+	ID     ServiceID
+	Tenant TenantID
+	// Name is the canonical name stored on subscriptions.
+	Name ServiceName
+	// Aliases are other spellings resolved to the same entry, e.g. a localized name.
+	Aliases      []ServiceName
+	DefaultPrice *Money
+	// Category is kept in the service categories mapping under the service name.
+	Category string
+}
+
+// ServiceCategory classifies every subscription to the named service, e.g. as streaming or cloud.
+type ServiceCategory struct {
+	Tenant      TenantID
+	ServiceName ServiceName
+	Category    string
+}
+
+func NewServiceCategory(service ServiceName, category string) (*ServiceCategory, error) {
+	service = strings.Join(strings.Fields(service), " ")
+	if service == "" {
+		return nil, fmt.Errorf("invalid category: service name is not provided")
+	}
+
+	category = NormalizeCategory(category)
+	if category == "" {
+		return nil, fmt.Errorf("invalid category: category is not provided")
+	}
+
+	return &ServiceCategory{ServiceName: service, Category: category}, nil
+}
+
+func NewService(name ServiceName, aliases []ServiceName, defaultPrice *Money, category string) (*Service, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("can not create service: could not generate service id")
+	}
+
+	s := &Service{ID: id}
+	if err := s.Change(name, aliases, defaultPrice, category); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Change replaces every attribute of the service. Aliases spelled like the name or like each other are dropped.
+func (s *Service) Change(name ServiceName, aliases []ServiceName, defaultPrice *Money, category string) error {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return fmt.Errorf("invalid service: name is not provided")
+	}
+
+	seen := map[string]bool{NormalizeServiceName(name): true}
+	kept := make([]ServiceName, 0, len(aliases))
+	for _, alias := range aliases {
+		alias = strings.Join(strings.Fields(alias), " ")
+		if alias == "" {
+			return fmt.Errorf("invalid service: alias must not be empty")
+		}
+		if key := NormalizeServiceName(alias); !seen[key] {
+			seen[key] = true
+			kept = append(kept, alias)
+		}
+	}
+
+	if defaultPrice != nil {
+		price, err := NewMoney(defaultPrice.Amount, defaultPrice.Currency)
+		if err != nil {
+			return fmt.Errorf("invalid service: invalid default price: %w", err)
+		}
+		defaultPrice = &price
+	}
+
+	s.Name = name
+	s.Aliases = kept
+	s.DefaultPrice = defaultPrice
+	s.Category = NormalizeCategory(category)
+
+	return nil
+}
+
+// Keys returns the normalized spellings the service is looked up by, the name first.
+func (s *Service) Keys() []string {
+	keys := make([]string, 0, len(s.Aliases)+1)
+	keys = append(keys, NormalizeServiceName(s.Name))
+	for _, alias := range s.Aliases {
+		keys = append(keys, NormalizeServiceName(alias))
+	}
+
+	return keys
+}
+
+// NormalizeServiceName folds case and whitespace, so "Yandex Plus" and "yandex  plus " are the same service.
+func NormalizeServiceName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// NormalizeCategory folds case and whitespace, so "Streaming " and "streaming" are the same category.
+func NormalizeCategory(category string) string {
+	return strings.ToLower(strings.Join(strings.Fields(category), " "))
+}
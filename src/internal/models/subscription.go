@@ -11,18 +11,22 @@ import (
 type PersonID = uuid.UUID
 type ServiceName = string
 type SubscriptionID = uuid.UUID
-type currencyRUB = int64
 
 type Subscription struct {
 	ID          SubscriptionID
+	Tenant      TenantID
 	ServiceName ServiceName
-	PriceRUB    currencyRUB
+	Price       Money
 	StartedAt   time.Time
 	CompletedAt *time.Time
 	Owner       PersonID
+	Period      BillingPeriod
+	DeletedAt   *time.Time
+	// Version is incremented on every change, it guards against lost updates.
+	Version int64
 }
 
-func NewSubscription(owner PersonID, priceRUB int64, service ServiceName, startTime time.Time, endTime *time.Time) (sub *Subscription, err error) {
+func NewSubscription(owner PersonID, price Money, service ServiceName, period BillingPeriod, startTime time.Time, endTime *time.Time) (sub *Subscription, err error) {
 	startTime = startTime.UTC()
 	if time.Now().UTC().Before(startTime) {
 		return nil, fmt.Errorf("can not create subscription: date has not come yet")
@@ -37,8 +41,15 @@ func NewSubscription(owner PersonID, priceRUB int64, service ServiceName, startT
 		return nil, fmt.Errorf("can not create subscription: subscribed service is not provided")
 	}
 
-	if priceRUB < 0 {
-		return nil, fmt.Errorf("can not create subscription: invalid subscription price")
+	price, err = NewMoney(price.Amount, price.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("can not create subscription: invalid subscription price: %w", err)
+	}
+
+	if period == "" {
+		period = BillingMonthly
+	} else if !period.IsValid() {
+		return nil, fmt.Errorf("can not create subscription: unknown billing period %q", period)
 	}
 
 	if endTime != nil {
@@ -54,7 +65,7 @@ func NewSubscription(owner PersonID, priceRUB int64, service ServiceName, startT
 		return nil, fmt.Errorf("can not create subscription: could not generate subscription id")
 	}
 
-	return &Subscription{ID: id, ServiceName: service, PriceRUB: priceRUB, StartedAt: startTime, CompletedAt: endTime, Owner: owner}, nil
+	return &Subscription{ID: id, ServiceName: service, Price: price, StartedAt: startTime, CompletedAt: endTime, Owner: owner, Period: period, Version: 1}, nil
 }
 
 func (s *Subscription) IsCompleted() bool {
@@ -105,12 +116,23 @@ func (s *Subscription) ChangeOwner(owner PersonID) error {
 	return nil
 }
 
-func (s *Subscription) ChangePrice(price int64) error {
-	if price < 0 {
-		return fmt.Errorf("can not update subscription: invalid subscription price")
+func (s *Subscription) ChangePrice(price Money) error {
+	price, err := NewMoney(price.Amount, price.Currency)
+	if err != nil {
+		return fmt.Errorf("can not update subscription: invalid subscription price: %w", err)
+	}
+
+	s.Price = price
+
+	return nil
+}
+
+func (s *Subscription) ChangeBillingPeriod(period BillingPeriod) error {
+	if !period.IsValid() {
+		return fmt.Errorf("can not update subscription: unknown billing period %q", period)
 	}
 
-	s.PriceRUB = price
+	s.Period = period
 
 	return nil
 }
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// TenantID names the business unit owning a piece of data, data of different tenants never mix.
+type TenantID = string
+
+// DefaultTenant owns the data created before tenants were introduced.
+const DefaultTenant TenantID = "default"
+
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+func ValidateTenantID(t TenantID) error {
+	if t == "" {
+		return fmt.Errorf("tenant id was not provided")
+	}
+	if !tenantIDPattern.MatchString(t) {
+		return fmt.Errorf("tenant id %q must be up to 64 lowercase letters, digits, dashes or underscores", t)
+	}
+
+	return nil
+}
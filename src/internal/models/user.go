@@ -0,0 +1,76 @@
+package models
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTimezone is used for users who did not choose a timezone.
+const DefaultTimezone = "UTC"
+
+// User owns subscriptions, their id is the PersonID stored on every subscription.
+type User struct {
+	ID          PersonID
+	Tenant      TenantID
+	DisplayName string
+	// Email is empty only for users created from owners of subscriptions stored before users existed.
+	Email     string
+	Timezone  string
+	CreatedAt time.Time
+}
+
+func NewUser(displayName, email, timezone string) (*User, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("can not create user: could not generate user id")
+	}
+
+	u := &User{ID: id, CreatedAt: time.Now().UTC()}
+	if err := u.Change(displayName, email, timezone); err != nil {
+		return nil, err
+	}
+
+	return u, nil
+}
+
+// Change replaces the display name, email and timezone of the user, an empty timezone means UTC.
+func (u *User) Change(displayName, email, timezone string) error {
+	displayName = strings.TrimSpace(displayName)
+	if displayName == "" {
+		return fmt.Errorf("invalid user: display name is not provided")
+	}
+
+	email = strings.TrimSpace(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return fmt.Errorf("invalid user: %q is not an email address", email)
+	}
+
+	timezone = strings.TrimSpace(timezone)
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	if _, err := time.LoadLocation(timezone); err != nil {
+		return fmt.Errorf("invalid user: unknown timezone %q", timezone)
+	}
+
+	u.DisplayName = displayName
+	u.Email = email
+	u.Timezone = timezone
+
+	return nil
+}
+
+// Location returns the timezone of the user, UTC when it can not be loaded.
+func (u *User) Location() *time.Location {
+	loc, err := time.LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return loc
+}
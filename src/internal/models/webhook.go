@@ -0,0 +1,113 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventSubscriptionCreated EventType = "subscription.created"
+	EventSubscriptionUpdated EventType = "subscription.updated"
+	EventSubscriptionDeleted EventType = "subscription.deleted"
+)
+
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventSubscriptionCreated, EventSubscriptionUpdated, EventSubscriptionDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// SubscriptionEvent tells downstream consumers about a change in the lifecycle of a subscription.
+type SubscriptionEvent struct {
+	ID             uuid.UUID
+	Type           EventType
+	Tenant         TenantID
+	SubscriptionID SubscriptionID
+	OccurredAt     time.Time
+	// Subscription is the state after the change, nil once the subscription is deleted.
+	Subscription *Subscription
+	// Attempt counts the attempts to publish the event, the first one is 1.
+	Attempt int
+}
+
+type WebhookID = uuid.UUID
+
+// Webhook receives the events of the listed types about subscriptions of its tenant at its URL,
+// every delivery is signed with the secret.
+type Webhook struct {
+	ID        WebhookID
+	Tenant    TenantID
+	URL       string
+	Secret    string
+	Events    []EventType
+	CreatedAt time.Time
+}
+
+func NewWebhook(rawURL, secret string, events []EventType) (*Webhook, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, fmt.Errorf("can not create webhook: could not generate webhook id")
+	}
+
+	w := &Webhook{ID: id, CreatedAt: time.Now().UTC()}
+	if err := w.Change(rawURL, secret, events); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// Change replaces the URL, secret and event types of the webhook, repeated event types are dropped.
+func (w *Webhook) Change(rawURL, secret string, events []EventType) error {
+	rawURL = strings.TrimSpace(rawURL)
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook: %q is not an http or https url", rawURL)
+	}
+
+	if secret == "" {
+		return fmt.Errorf("invalid webhook: secret is not provided")
+	}
+
+	if len(events) == 0 {
+		return fmt.Errorf("invalid webhook: no event types are provided")
+	}
+	unique := make([]EventType, 0, len(events))
+	for _, e := range events {
+		if !e.IsValid() {
+			return fmt.Errorf("invalid webhook: unknown event type %q", e)
+		}
+		if !slices.Contains(unique, e) {
+			unique = append(unique, e)
+		}
+	}
+
+	w.URL = rawURL
+	w.Secret = secret
+	w.Events = unique
+
+	return nil
+}
+
+// DeadLetter is an event delivery to a webhook that failed after every retry.
+type DeadLetter struct {
+	ID        int64
+	WebhookID WebhookID
+	EventID   uuid.UUID
+	EventType EventType
+	Payload   json.RawMessage
+	Attempts  int
+	LastError string
+	FailedAt  time.Time
+}
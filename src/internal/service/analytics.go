@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// NewAnalyticsService creates the service answering aggregate questions about spending.
+// Service names are resolved through the catalog, so an alias selects the spending on its canonical service.
+func NewAnalyticsService(s storage.AnalyticsStorage, catalog ServiceCatalog, log *slog.Logger) AnalyticsService {
+	return analyticsService{
+		analyticsStorage: s,
+		catalog:          catalog,
+		log:              log.With(slog.String("component", "AnalyticsService")),
+	}
+}
+
+type analyticsService struct {
+	analyticsStorage storage.AnalyticsStorage
+	catalog          ServiceCatalog
+	log              *slog.Logger
+}
+
+type SpendingArgs struct {
+	UserID    models.PersonID
+	Service   models.ServiceName
+	Category  string
+	StartTime *time.Time
+	EndTime   *time.Time
+	GroupBy   []storage.SpendingDimension
+}
+
+type spendingGroup struct {
+	Month       *time.Time
+	ServiceName *models.ServiceName
+	UserID      *models.PersonID
+	Category    *string
+	Amount      models.Money
+	Charges     int64
+}
+
+type spendingReport struct {
+	From    time.Time
+	To      time.Time
+	GroupBy []storage.SpendingDimension
+	// Groups are ordered by the grouping dimensions and then by currency.
+	Groups []spendingGroup
+}
+
+func (s analyticsService) GetSpending(ctx context.Context, a SpendingArgs) (spendingReport, error) {
+	const op = "internal.service.analytics.GetSpending"
+
+	owner, err := scopeOwner(ctx, a.UserID)
+	if err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("user_id", a.UserID))
+		return spendingReport{}, err
+	}
+	a.UserID = owner
+
+	if a.StartTime != nil && a.EndTime != nil && !a.EndTime.After(*a.StartTime) {
+		s.log.Warn("invalid input: end time must be after start time", slog.String("op", op))
+		return spendingReport{}, NewInvalidInputError("end time must be after start time")
+	}
+	for i, d := range a.GroupBy {
+		switch d {
+		case storage.SpendingByService, storage.SpendingByUser, storage.SpendingByMonth, storage.SpendingByCategory:
+		default:
+			s.log.Warn("invalid input: unknown grouping", slog.String("op", op), slog.Any("group_by", d))
+			return spendingReport{}, NewInvalidInputError(fmt.Sprintf("can not group by %q", d))
+		}
+		if slices.Contains(a.GroupBy[:i], d) {
+			s.log.Warn("invalid input: repeated grouping", slog.String("op", op), slog.Any("group_by", d))
+			return spendingReport{}, NewInvalidInputError(fmt.Sprintf("group_by lists %q more than once", d))
+		}
+	}
+
+	serviceName, err := filterServiceName(ctx, s.catalog, a.Service)
+	if err != nil {
+		s.log.Error("failed to resolve service name", slog.String("op", op), sl.Err(err))
+		return spendingReport{}, err
+	}
+
+	report := spendingReport{GroupBy: a.GroupBy}
+	if a.StartTime != nil {
+		report.From = a.StartTime.UTC()
+	}
+	if a.EndTime != nil {
+		report.To = a.EndTime.UTC()
+	} else {
+		report.To = time.Now().UTC()
+	}
+
+	rows, err := s.analyticsStorage.Spending(ctx, storage.SpendingQuery{
+		Tenant:      TenantFromContext(ctx),
+		From:        report.From,
+		To:          report.To,
+		OwnerID:     a.UserID,
+		ServiceName: serviceName,
+		Category:    models.NormalizeCategory(a.Category),
+		GroupBy:     a.GroupBy,
+	})
+	if err != nil {
+		s.log.Error("failed to calculate spending", slog.String("op", op), sl.Err(err))
+		return spendingReport{}, NewInternalError("failed to calculate spending")
+	}
+
+	report.Groups = make([]spendingGroup, len(rows))
+	for i, r := range rows {
+		report.Groups[i] = spendingGroup{
+			Month:       r.Month,
+			ServiceName: r.ServiceName,
+			UserID:      r.OwnerID,
+			Category:    r.Category,
+			Amount:      r.Amount,
+			Charges:     r.Charges,
+		}
+	}
+
+	s.log.Info("spending calculated", slog.String("op", op), slog.Int("groups", len(report.Groups)))
+	return report, nil
+}
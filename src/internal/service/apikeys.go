@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// NewAPIKeyService creates the service issuing and checking api keys of machine clients.
+func NewAPIKeyService(s storage.APIKeysStorage, log *slog.Logger) APIKeyService {
+	return apiKeyService{
+		apiKeysStorage: s,
+		log:            log.With(slog.String("component", "APIKeyService")),
+	}
+}
+
+type apiKeyService struct {
+	apiKeysStorage storage.APIKeysStorage
+	log            *slog.Logger
+}
+
+type IssueAPIKeyArgs struct {
+	Name   string
+	UserID models.PersonID
+	Scopes []models.APIKeyScope
+	// ExpiresAt is nil for a key that never expires.
+	ExpiresAt *time.Time
+}
+
+type issuedAPIKey struct {
+	*models.APIKey
+	// Key is the secret the client authenticates with, it can not be recovered later.
+	Key string
+}
+
+func (s apiKeyService) IssueAPIKey(ctx context.Context, a IssueAPIKeyArgs) (issuedAPIKey, error) {
+	const op = "internal.service.apikeys.IssueAPIKey"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op))
+		return issuedAPIKey{}, err
+	}
+
+	k, key, err := models.NewAPIKey(tenantOfNewData(ctx), a.Name, a.UserID, a.Scopes, a.ExpiresAt)
+	if err != nil {
+		s.log.Debug("validation failed", slog.String("op", op), sl.Err(err))
+		return issuedAPIKey{}, NewInvalidInputError(err.Error())
+	}
+
+	if err := s.apiKeysStorage.Add(ctx, *k); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			s.log.Warn("invalid input: unknown owner", slog.String("op", op), slog.Any("user_id", a.UserID))
+			return issuedAPIKey{}, NewInvalidInputError(fmt.Sprintf("user %s does not exist", a.UserID))
+		}
+		s.log.Error("failed to add api key", slog.String("op", op), sl.Err(err), slog.Any("api_key_id", k.ID))
+		return issuedAPIKey{}, NewInternalError("failed to issue api key")
+	}
+
+	s.log.Info("api key issued", slog.String("op", op), slog.Any("api_key_id", k.ID), slog.Any("user_id", k.Owner))
+	return issuedAPIKey{APIKey: k, Key: key}, nil
+}
+
+func (s apiKeyService) RevokeAPIKey(ctx context.Context, id models.APIKeyID) error {
+	const op = "internal.service.apikeys.RevokeAPIKey"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("api_key_id", id))
+		return err
+	}
+
+	if err := s.apiKeysStorage.Revoke(ctx, TenantFromContext(ctx), id, time.Now().UTC()); err != nil {
+		if errors.Is(err, storage.ErrAPIKeyNotFound) {
+			s.log.Warn("api key not found", slog.String("op", op), slog.Any("api_key_id", id))
+			return NewNotFoundError("api key not found")
+		}
+		s.log.Error("failed to revoke api key", slog.String("op", op), sl.Err(err), slog.Any("api_key_id", id))
+		return NewInternalError("failed to revoke api key")
+	}
+
+	s.log.Info("api key revoked", slog.String("op", op), slog.Any("api_key_id", id))
+	return nil
+}
+
+func (s apiKeyService) FindAPIKeyByID(ctx context.Context, id models.APIKeyID) (*models.APIKey, error) {
+	const op = "internal.service.apikeys.FindAPIKeyByID"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("api_key_id", id))
+		return nil, err
+	}
+
+	k, err := s.apiKeysStorage.FindByID(ctx, TenantFromContext(ctx), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrAPIKeyNotFound) {
+			s.log.Warn("api key not found", slog.String("op", op), slog.Any("api_key_id", id))
+			return nil, NewNotFoundError("api key not found")
+		}
+		s.log.Error("failed to find api key", slog.String("op", op), sl.Err(err), slog.Any("api_key_id", id))
+		return nil, NewInternalError("failed to fetch api key")
+	}
+
+	s.log.Info("api key fetched", slog.String("op", op), slog.Any("api_key_id", id))
+	return k, nil
+}
+
+func (s apiKeyService) GetAPIKeys(ctx context.Context) ([]*models.APIKey, error) {
+	const op = "internal.service.apikeys.GetAPIKeys"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op))
+		return nil, err
+	}
+
+	keys, err := s.apiKeysStorage.FindAll(ctx, TenantFromContext(ctx))
+	if err != nil {
+		s.log.Error("failed to fetch api keys", slog.String("op", op), sl.Err(err))
+		return nil, NewInternalError("failed to fetch api keys")
+	}
+
+	s.log.Info("api keys fetched", slog.String("op", op), slog.Int("count", len(keys)))
+	return keys, nil
+}
+
+func (s apiKeyService) AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error) {
+	const op = "internal.service.apikeys.AuthenticateAPIKey"
+
+	if !strings.HasPrefix(key, models.APIKeyPrefix) {
+		s.log.Warn("malformed api key", slog.String("op", op))
+		return nil, NewUnauthenticatedError("invalid api key")
+	}
+
+	k, err := s.apiKeysStorage.FindByHash(ctx, models.HashAPIKey(key))
+	if err != nil {
+		if errors.Is(err, storage.ErrAPIKeyNotFound) {
+			s.log.Warn("unknown api key", slog.String("op", op))
+			return nil, NewUnauthenticatedError("invalid api key")
+		}
+		s.log.Error("failed to find api key", slog.String("op", op), sl.Err(err))
+		return nil, NewInternalError("failed to authenticate api key")
+	}
+
+	now := time.Now().UTC()
+	if !k.IsActive(now) {
+		s.log.Warn("api key is revoked or expired", slog.String("op", op), slog.Any("api_key_id", k.ID))
+		return nil, NewUnauthenticatedError("api key is revoked or expired")
+	}
+
+	// the key is valid even if its usage can not be recorded
+	if err := s.apiKeysStorage.TouchLastUsed(ctx, k.ID, now); err != nil {
+		s.log.Error("failed to record api key usage", slog.String("op", op), sl.Err(err), slog.Any("api_key_id", k.ID))
+	}
+
+	s.log.Info("api key authenticated", slog.String("op", op), slog.Any("api_key_id", k.ID))
+	return k, nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeAPIKeysStorage finds keys by their hash and records the keys it stores and the uses it is told of.
+type fakeAPIKeysStorage struct {
+	storage.APIKeysStorage
+	keys    []*models.APIKey
+	added   []models.APIKey
+	touched []models.APIKeyID
+}
+
+func (s *fakeAPIKeysStorage) Add(ctx context.Context, k models.APIKey) error {
+	s.added = append(s.added, k)
+	return nil
+}
+
+func (s *fakeAPIKeysStorage) FindByHash(ctx context.Context, hash []byte) (*models.APIKey, error) {
+	for _, k := range s.keys {
+		if string(k.Hash) == string(hash) {
+			return k, nil
+		}
+	}
+	return nil, storage.ErrAPIKeyNotFound
+}
+
+func (s *fakeAPIKeysStorage) TouchLastUsed(ctx context.Context, id models.APIKeyID, at time.Time) error {
+	s.touched = append(s.touched, id)
+	return nil
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	issue := func(mod func(k *models.APIKey)) (*models.APIKey, string) {
+		k, key, err := models.NewAPIKey("acme", "cron", uuid.New(), []models.APIKeyScope{models.ScopeRead}, &future)
+		if err != nil {
+			t.Fatalf("NewAPIKey() error = %v", err)
+		}
+		mod(k)
+		return k, key
+	}
+
+	active, activeKey := issue(func(k *models.APIKey) {})
+	expired, expiredKey := issue(func(k *models.APIKey) { k.ExpiresAt = &past })
+	revoked, revokedKey := issue(func(k *models.APIKey) { k.RevokedAt = &past })
+
+	tests := []struct {
+		name     string
+		key      string
+		wantKey  *models.APIKey
+		wantCode ErrorCode
+	}{
+		{name: "active", key: activeKey, wantKey: active},
+		{name: "expired", key: expiredKey, wantCode: ErrUnauthenticated},
+		{name: "revoked", key: revokedKey, wantCode: ErrUnauthenticated},
+		{name: "unknown", key: models.APIKeyPrefix + "unknown", wantCode: ErrUnauthenticated},
+		{name: "malformed", key: "not-a-key", wantCode: ErrUnauthenticated},
+		// the stored hash must not work as the key
+		{name: "hash", key: string(active.Hash), wantCode: ErrUnauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys := &fakeAPIKeysStorage{keys: []*models.APIKey{active, expired, revoked}}
+			s := NewAPIKeyService(keys, testLogger())
+
+			k, err := s.AuthenticateAPIKey(context.Background(), tt.key)
+			if tt.wantCode != "" {
+				var svcErr *ServiceError
+				if !errors.As(err, &svcErr) || svcErr.Code != tt.wantCode {
+					t.Fatalf("AuthenticateAPIKey() error = %v, want %s", err, tt.wantCode)
+				}
+				if len(keys.touched) != 0 {
+					t.Errorf("recorded use of a rejected key")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AuthenticateAPIKey() error = %v", err)
+			}
+			if k != tt.wantKey {
+				t.Errorf("AuthenticateAPIKey() = %+v, want %+v", k, tt.wantKey)
+			}
+			if len(keys.touched) != 1 || keys.touched[0] != k.ID {
+				t.Errorf("recorded uses %v, want the key use recorded", keys.touched)
+			}
+		})
+	}
+}
+
+func TestIssueAPIKeyBindsRequestTenant(t *testing.T) {
+	keys := &fakeAPIKeysStorage{}
+	s := NewAPIKeyService(keys, testLogger())
+	ctx := WithTenant(WithPrincipal(context.Background(), Principal{Role: RoleAdmin}), "acme")
+
+	issued, err := s.IssueAPIKey(ctx, IssueAPIKeyArgs{Name: "cron", UserID: uuid.New(), Scopes: []models.APIKeyScope{models.ScopeRead}})
+	if err != nil {
+		t.Fatalf("IssueAPIKey() error = %v", err)
+	}
+
+	if len(keys.added) != 1 || keys.added[0].Tenant != "acme" {
+		t.Fatalf("stored %+v, want one key of tenant acme", keys.added)
+	}
+	keys.keys = []*models.APIKey{&keys.added[0]}
+	if _, err := s.AuthenticateAPIKey(context.Background(), issued.Key); err != nil {
+		t.Errorf("AuthenticateAPIKey() error = %v, want the issued key accepted", err)
+	}
+}
@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"errors"
+	"fmt"
+	"log/slog"
+)
+
+// UnknownServicePolicy decides what happens to a service name missing from the catalog.
+type UnknownServicePolicy string
+
+const (
+	// CreateUnknownServices adds a missing name to the catalog as a new service.
+	CreateUnknownServices UnknownServicePolicy = "create"
+	// RejectUnknownServices refuses a missing name as invalid input.
+	RejectUnknownServices UnknownServicePolicy = "reject"
+)
+
+func (p UnknownServicePolicy) IsValid() bool {
+	return p == CreateUnknownServices || p == RejectUnknownServices
+}
+
+// NewServiceCatalog creates the service managing catalog entries and resolving service names.
+func NewServiceCatalog(s storage.ServicesStorage, policy UnknownServicePolicy, log *slog.Logger) ServiceCatalog {
+	return serviceCatalog{
+		servicesStorage: s,
+		unknownServices: policy,
+		log:             log.With(slog.String("component", "ServiceCatalog")),
+	}
+}
+
+type serviceCatalog struct {
+	servicesStorage storage.ServicesStorage
+	unknownServices UnknownServicePolicy
+	log             *slog.Logger
+}
+
+type CreateServiceArgs struct {
+	Name         models.ServiceName
+	Aliases      []models.ServiceName
+	DefaultPrice *models.Money
+	Category     string
+}
+
+type UpdateServiceArgs struct {
+	models.ServiceID
+	Name         models.ServiceName
+	Aliases      []models.ServiceName
+	DefaultPrice *models.Money
+	Category     string
+}
+
+func (c serviceCatalog) CreateService(ctx context.Context, a CreateServiceArgs) (*models.Service, error) {
+	const op = "internal.service.catalog.CreateService"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		c.log.Warn("access denied", slog.String("op", op))
+		return nil, err
+	}
+
+	svc, err := models.NewService(a.Name, a.Aliases, a.DefaultPrice, a.Category)
+	if err != nil {
+		c.log.Debug("validation failed", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+	svc.Tenant = tenantOfNewData(ctx)
+
+	if err := c.servicesStorage.Add(ctx, *svc); err != nil {
+		if errors.Is(err, storage.ErrServiceNameTaken) {
+			c.log.Warn("service name is taken", slog.String("op", op), slog.String("name", svc.Name))
+			return nil, NewConflictError("name or alias is already used by another service")
+		}
+		c.log.Error("failed to add service", slog.String("op", op), sl.Err(err), slog.Any("service_id", svc.ID))
+		return nil, NewInternalError("failed to create service")
+	}
+
+	c.log.Info("service created", slog.String("op", op), slog.Any("service_id", svc.ID))
+	return svc, nil
+}
+
+func (c serviceCatalog) UpdateService(ctx context.Context, a UpdateServiceArgs) (*models.Service, error) {
+	const op = "internal.service.catalog.UpdateService"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		c.log.Warn("access denied", slog.String("op", op), slog.Any("service_id", a.ServiceID))
+		return nil, err
+	}
+
+	svc := &models.Service{ID: a.ServiceID, Tenant: tenantOfNewData(ctx)}
+	if err := svc.Change(a.Name, a.Aliases, a.DefaultPrice, a.Category); err != nil {
+		c.log.Debug("validation failed", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+
+	if err := c.servicesStorage.Update(ctx, *svc); err != nil {
+		if errors.Is(err, storage.ErrServiceNotFound) {
+			c.log.Warn("service not found", slog.String("op", op), slog.Any("service_id", a.ServiceID))
+			return nil, NewNotFoundError("service not found")
+		}
+		if errors.Is(err, storage.ErrServiceNameTaken) {
+			c.log.Warn("service name is taken", slog.String("op", op), slog.String("name", svc.Name))
+			return nil, NewConflictError("name or alias is already used by another service")
+		}
+		c.log.Error("failed to update service", slog.String("op", op), sl.Err(err), slog.Any("service_id", a.ServiceID))
+		return nil, NewInternalError("failed to update service")
+	}
+
+	c.log.Info("service updated", slog.String("op", op), slog.Any("service_id", svc.ID))
+	return svc, nil
+}
+
+func (c serviceCatalog) RemoveService(ctx context.Context, id models.ServiceID) error {
+	const op = "internal.service.catalog.RemoveService"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		c.log.Warn("access denied", slog.String("op", op), slog.Any("service_id", id))
+		return err
+	}
+
+	if err := c.servicesStorage.RemoveByID(ctx, TenantFromContext(ctx), id); err != nil {
+		if errors.Is(err, storage.ErrServiceNotFound) {
+			c.log.Warn("service not found", slog.String("op", op), slog.Any("service_id", id))
+			return NewNotFoundError("service not found")
+		}
+		c.log.Error("failed to remove service", slog.String("op", op), sl.Err(err), slog.Any("service_id", id))
+		return NewInternalError("failed to remove service")
+	}
+
+	c.log.Info("service removed", slog.String("op", op), slog.Any("service_id", id))
+	return nil
+}
+
+func (c serviceCatalog) FindServiceByID(ctx context.Context, id models.ServiceID) (*models.Service, error) {
+	const op = "internal.service.catalog.FindServiceByID"
+
+	svc, err := c.servicesStorage.FindByID(ctx, TenantFromContext(ctx), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrServiceNotFound) {
+			c.log.Warn("service not found", slog.String("op", op), slog.Any("service_id", id))
+			return nil, NewNotFoundError("service not found")
+		}
+		c.log.Error("failed to find service", slog.String("op", op), sl.Err(err), slog.Any("service_id", id))
+		return nil, NewInternalError("failed to fetch service")
+	}
+
+	c.log.Info("service fetched", slog.String("op", op), slog.Any("service_id", id))
+	return svc, nil
+}
+
+func (c serviceCatalog) GetServices(ctx context.Context) ([]*models.Service, error) {
+	const op = "internal.service.catalog.GetServices"
+
+	services, err := c.servicesStorage.FindAll(ctx, TenantFromContext(ctx))
+	if err != nil {
+		c.log.Error("failed to fetch services", slog.String("op", op), sl.Err(err))
+		return nil, NewInternalError("failed to fetch services")
+	}
+
+	c.log.Info("services fetched", slog.String("op", op), slog.Int("count", len(services)))
+	return services, nil
+}
+
+func (c serviceCatalog) FindServiceByName(ctx context.Context, name models.ServiceName) (*models.Service, error) {
+	const op = "internal.service.catalog.FindServiceByName"
+
+	svc, err := c.servicesStorage.FindByName(ctx, tenantOfNewData(ctx), models.NormalizeServiceName(name))
+	if err != nil {
+		if errors.Is(err, storage.ErrServiceNotFound) {
+			c.log.Warn("service not found", slog.String("op", op), slog.String("name", name))
+			return nil, NewNotFoundError("service not found")
+		}
+		c.log.Error("failed to find service", slog.String("op", op), sl.Err(err), slog.String("name", name))
+		return nil, NewInternalError("failed to fetch service")
+	}
+
+	c.log.Info("service fetched", slog.String("op", op), slog.Any("service_id", svc.ID))
+	return svc, nil
+}
+
+func (c serviceCatalog) ResolveServiceName(ctx context.Context, name models.ServiceName) (*models.Service, error) {
+	const op = "internal.service.catalog.ResolveServiceName"
+
+	// names resolve in the catalog of the tenant the subscription is created in
+	tenant := tenantOfNewData(ctx)
+	key := models.NormalizeServiceName(name)
+	if key == "" {
+		c.log.Warn("invalid input: service name is empty", slog.String("op", op))
+		return nil, NewInvalidInputError("service name is not provided")
+	}
+
+	svc, err := c.servicesStorage.FindByName(ctx, tenant, key)
+	if err == nil {
+		return svc, nil
+	}
+	if !errors.Is(err, storage.ErrServiceNotFound) {
+		c.log.Error("failed to resolve service name", slog.String("op", op), sl.Err(err))
+		return nil, NewInternalError("failed to resolve service name")
+	}
+
+	if c.unknownServices != CreateUnknownServices {
+		c.log.Warn("invalid input: unknown service", slog.String("op", op), slog.String("name", name))
+		return nil, NewInvalidInputError(fmt.Sprintf("service %q is not in the catalog", name))
+	}
+
+	svc, err = models.NewService(name, nil, nil, "")
+	if err != nil {
+		c.log.Warn("invalid input: bad service name", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+	svc.Tenant = tenant
+	err = c.servicesStorage.Add(ctx, *svc)
+	if errors.Is(err, storage.ErrServiceNameTaken) {
+		// a concurrent request has just created the same service
+		svc, err = c.servicesStorage.FindByName(ctx, tenant, key)
+	}
+	if err != nil {
+		c.log.Error("failed to add unknown service", slog.String("op", op), sl.Err(err))
+		return nil, NewInternalError("failed to resolve service name")
+	}
+
+	c.log.Info("unknown service added to catalog", slog.String("op", op), slog.Any("service_id", svc.ID))
+	return svc, nil
+}
+
+// filterServiceName turns a service name filter into the canonical name stored on subscriptions, so any
+// alias or spelling of a service matches its subscriptions. A name missing from the catalog is kept as given
+// and matches nothing instead of being added to the catalog.
+func filterServiceName(ctx context.Context, catalog ServiceCatalog, name models.ServiceName) (models.ServiceName, error) {
+	if models.NormalizeServiceName(name) == "" {
+		return "", nil
+	}
+
+	svc, err := catalog.FindServiceByName(ctx, name)
+	if err == nil {
+		return svc.Name, nil
+	}
+
+	var svcErr *ServiceError
+	if errors.As(err, &svcErr) && svcErr.Code == ErrNotFound {
+		return name, nil
+	}
+	return "", err
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+// fakeCatalog knows services by their normalized name or alias.
+type fakeCatalog struct {
+	ServiceCatalog
+	services []*models.Service
+	err      error
+}
+
+func (c fakeCatalog) FindServiceByName(ctx context.Context, name models.ServiceName) (*models.Service, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	key := models.NormalizeServiceName(name)
+	for _, svc := range c.services {
+		if models.NormalizeServiceName(svc.Name) == key {
+			return svc, nil
+		}
+		for _, alias := range svc.Aliases {
+			if models.NormalizeServiceName(alias) == key {
+				return svc, nil
+			}
+		}
+	}
+	return nil, NewNotFoundError("service not found")
+}
+
+func (c fakeCatalog) ResolveServiceName(ctx context.Context, name models.ServiceName) (*models.Service, error) {
+	panic("filters must not add services to the catalog")
+}
+
+type fakeSubscriptionsStorage struct {
+	storage.SubscriptionsStorage
+	filters []storage.SubscriptionsFilter
+	stored  *models.Subscription
+	updated *models.Subscription
+}
+
+func (s *fakeSubscriptionsStorage) FindByID(ctx context.Context, tenant models.TenantID, id models.SubscriptionID) (*models.Subscription, error) {
+	if s.stored == nil || s.stored.ID != id {
+		return nil, storage.ErrSubscriptionNotFound
+	}
+	sub := *s.stored
+	return &sub, nil
+}
+
+func (s *fakeSubscriptionsStorage) Update(ctx context.Context, sub models.Subscription) error {
+	s.updated = &sub
+	return nil
+}
+
+func (s *fakeSubscriptionsStorage) Find(ctx context.Context, f storage.SubscriptionsFilter) ([]*models.Subscription, error) {
+	s.filters = append(s.filters, f)
+	return nil, nil
+}
+
+func (s *fakeSubscriptionsStorage) Stream(ctx context.Context, f storage.SubscriptionsFilter, fn func(*models.Subscription) error) error {
+	s.filters = append(s.filters, f)
+	return nil
+}
+
+func testLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+var yandexPlus = &models.Service{Name: "Yandex Plus", Aliases: []models.ServiceName{"Яндекс Плюс"}}
+
+func TestFilterServiceName(t *testing.T) {
+	tests := []struct {
+		name    string
+		catalog fakeCatalog
+		filter  models.ServiceName
+		want    models.ServiceName
+		wantErr bool
+	}{
+		{name: "empty filter", filter: "", want: ""},
+		{name: "blank filter", filter: "  ", want: ""},
+		{name: "other spelling", catalog: fakeCatalog{services: []*models.Service{yandexPlus}}, filter: "yandex  plus ", want: "Yandex Plus"},
+		{name: "alias", catalog: fakeCatalog{services: []*models.Service{yandexPlus}}, filter: "яндекс плюс", want: "Yandex Plus"},
+		{name: "unknown service", catalog: fakeCatalog{services: []*models.Service{yandexPlus}}, filter: "Netflix", want: "Netflix"},
+		{name: "catalog failure", catalog: fakeCatalog{err: NewInternalError("failed to fetch service")}, filter: "Netflix", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterServiceName(context.Background(), tt.catalog, tt.filter)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("filterServiceName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("filterServiceName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSubscriptionsFiltersByCanonicalServiceName(t *testing.T) {
+	subs := &fakeSubscriptionsStorage{}
+	s := NewSubscriptionService(subs, nil, fakeCatalog{services: []*models.Service{yandexPlus}}, nil, testLogger())
+	ctx := WithPrincipal(context.Background(), Principal{Role: RoleAdmin})
+
+	if _, err := s.GetSubscriptions(ctx, ListSubscriptionsArgs{Service: "Яндекс Плюс"}); err != nil {
+		t.Fatalf("GetSubscriptions() error = %v", err)
+	}
+
+	if len(subs.filters) != 1 {
+		t.Fatalf("Find() called %d times, want once", len(subs.filters))
+	}
+	if got := subs.filters[0].ServiceName; got != "Yandex Plus" {
+		t.Errorf("filter service name = %q, want %q", got, "Yandex Plus")
+	}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"errors"
+	"log/slog"
+)
+
+// NewServiceCategories creates the service classifying services into categories.
+// Service names are resolved through the catalog, so an alias categorizes its canonical service.
+func NewServiceCategories(s storage.CategoriesStorage, catalog ServiceCatalog, log *slog.Logger) ServiceCategories {
+	return serviceCategories{
+		categoriesStorage: s,
+		catalog:           catalog,
+		log:               log.With(slog.String("component", "ServiceCategories")),
+	}
+}
+
+type serviceCategories struct {
+	categoriesStorage storage.CategoriesStorage
+	catalog           ServiceCatalog
+	log               *slog.Logger
+}
+
+func (c serviceCategories) SetServiceCategory(ctx context.Context, service models.ServiceName, category string) (*models.ServiceCategory, error) {
+	const op = "internal.service.categories.SetServiceCategory"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		c.log.Warn("access denied", slog.String("op", op), slog.String("service_name", service))
+		return nil, err
+	}
+
+	mapping, err := models.NewServiceCategory(service, category)
+	if err != nil {
+		c.log.Debug("validation failed", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+	// only catalog services are categorized, a typo must not add a service to the catalog
+	svc, err := c.catalog.FindServiceByName(ctx, mapping.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+	mapping.ServiceName = svc.Name
+	mapping.Tenant = svc.Tenant
+
+	if err := c.categoriesStorage.Set(ctx, *mapping); err != nil {
+		c.log.Error("failed to set service category", slog.String("op", op), sl.Err(err), slog.String("service_name", mapping.ServiceName))
+		return nil, NewInternalError("failed to set service category")
+	}
+
+	c.log.Info("service category set", slog.String("op", op), slog.String("service_name", mapping.ServiceName), slog.String("category", mapping.Category))
+	return mapping, nil
+}
+
+func (c serviceCategories) RemoveServiceCategory(ctx context.Context, service models.ServiceName) error {
+	const op = "internal.service.categories.RemoveServiceCategory"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		c.log.Warn("access denied", slog.String("op", op), slog.String("service_name", service))
+		return err
+	}
+
+	name, err := c.canonicalName(ctx, op, service)
+	if err != nil {
+		return err
+	}
+
+	if err := c.categoriesStorage.Remove(ctx, TenantFromContext(ctx), name); err != nil {
+		if errors.Is(err, storage.ErrCategoryNotFound) {
+			c.log.Warn("service category not found", slog.String("op", op), slog.String("service_name", name))
+			return NewNotFoundError("service has no category")
+		}
+		c.log.Error("failed to remove service category", slog.String("op", op), sl.Err(err), slog.String("service_name", name))
+		return NewInternalError("failed to remove service category")
+	}
+
+	c.log.Info("service category removed", slog.String("op", op), slog.String("service_name", name))
+	return nil
+}
+
+func (c serviceCategories) FindServiceCategory(ctx context.Context, service models.ServiceName) (*models.ServiceCategory, error) {
+	const op = "internal.service.categories.FindServiceCategory"
+
+	name, err := c.canonicalName(ctx, op, service)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := c.categoriesStorage.Find(ctx, TenantFromContext(ctx), name)
+	if err != nil {
+		if errors.Is(err, storage.ErrCategoryNotFound) {
+			c.log.Warn("service category not found", slog.String("op", op), slog.String("service_name", name))
+			return nil, NewNotFoundError("service has no category")
+		}
+		c.log.Error("failed to find service category", slog.String("op", op), sl.Err(err), slog.String("service_name", name))
+		return nil, NewInternalError("failed to fetch service category")
+	}
+
+	c.log.Info("service category fetched", slog.String("op", op), slog.String("service_name", name))
+	return mapping, nil
+}
+
+func (c serviceCategories) GetServiceCategories(ctx context.Context) ([]*models.ServiceCategory, error) {
+	const op = "internal.service.categories.GetServiceCategories"
+
+	mappings, err := c.categoriesStorage.FindAll(ctx, TenantFromContext(ctx))
+	if err != nil {
+		c.log.Error("failed to fetch service categories", slog.String("op", op), sl.Err(err))
+		return nil, NewInternalError("failed to fetch service categories")
+	}
+
+	c.log.Info("service categories fetched", slog.String("op", op), slog.Int("count", len(mappings)))
+	return mappings, nil
+}
+
+// canonicalName looks an existing mapping up by any spelling of the service without adding unknown names to the catalog.
+func (c serviceCategories) canonicalName(ctx context.Context, op string, service models.ServiceName) (models.ServiceName, error) {
+	svc, err := c.catalog.FindServiceByName(ctx, service)
+	if err == nil {
+		return svc.Name, nil
+	}
+
+	var svcErr *ServiceError
+	if errors.As(err, &svcErr) && svcErr.Code == ErrNotFound {
+		// a mapping outlives the removal of its service from the catalog
+		return service, nil
+	}
+
+	c.log.Error("failed to resolve service name", slog.String("op", op), sl.Err(err))
+	return "", err
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"errors"
+	"io"
+	"testing"
+)
+
+type fakeCategoriesStorage struct {
+	storage.CategoriesStorage
+	set []models.ServiceCategory
+}
+
+func (s *fakeCategoriesStorage) Set(ctx context.Context, c models.ServiceCategory) error {
+	s.set = append(s.set, c)
+	return nil
+}
+
+func TestSetServiceCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		service  models.ServiceName
+		wantName models.ServiceName
+		wantCode ErrorCode
+	}{
+		{name: "alias categorizes canonical service", service: "Яндекс Плюс", wantName: "Yandex Plus"},
+		{name: "unknown service", service: "Yandex Pluss", wantCode: ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			categories := &fakeCategoriesStorage{}
+			c := NewServiceCategories(categories, fakeCatalog{services: []*models.Service{yandexPlus}}, testLogger())
+			ctx := WithPrincipal(context.Background(), Principal{Role: RoleAdmin})
+
+			mapping, err := c.SetServiceCategory(ctx, tt.service, "Streaming")
+			if tt.wantCode != "" {
+				var svcErr *ServiceError
+				if !errors.As(err, &svcErr) || svcErr.Code != tt.wantCode {
+					t.Fatalf("SetServiceCategory() error = %v, want %s", err, tt.wantCode)
+				}
+				if len(categories.set) != 0 {
+					t.Errorf("stored %v, want nothing stored", categories.set)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("SetServiceCategory() error = %v", err)
+			}
+			if mapping.ServiceName != tt.wantName || mapping.Category != "streaming" {
+				t.Errorf("mapping = %+v, want %q in streaming", mapping, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestExportSubscriptionsFiltersByCategory(t *testing.T) {
+	subs := &fakeSubscriptionsStorage{}
+	s := NewSubscriptionService(subs, nil, fakeCatalog{}, nil, testLogger())
+	ctx := WithPrincipal(context.Background(), Principal{Role: RoleAdmin})
+
+	export, err := s.ExportSubscriptions(ctx, ExportSubscriptionsArgs{Category: " Streaming "})
+	if err != nil {
+		t.Fatalf("ExportSubscriptions() error = %v", err)
+	}
+	if err := export.write(io.Discard); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+
+	if len(subs.filters) != 1 {
+		t.Fatalf("Stream() called %d times, want once", len(subs.filters))
+	}
+	if got := subs.filters[0].Category; got != "streaming" {
+		t.Errorf("filter category = %q, want %q", got, "streaming")
+	}
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// pageCursor is the opaque position handed to clients as next_cursor.
+// It remembers the order it was issued for, so it can not be replayed with a different sort.
+type pageCursor struct {
+	Order     SubscriptionsOrder    `json:"o"`
+	ID        models.SubscriptionID `json:"id"`
+	StartTime time.Time             `json:"s"`
+	Price     int64                 `json:"p"`
+}
+
+func encodeCursor(order SubscriptionsOrder, last *models.Subscription) string {
+	c := pageCursor{
+		Order:     order,
+		ID:        last.ID,
+		StartTime: last.StartedAt,
+		Price:     last.Price.Amount,
+	}
+
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(order SubscriptionsOrder, cursor string) (*storage.SubscriptionsCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	var c pageCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("malformed cursor")
+	}
+
+	if c.Order != order {
+		return nil, fmt.Errorf("cursor was issued for a different sort order")
+	}
+
+	return &storage.SubscriptionsCursor{ID: c.ID, StartTime: c.StartTime, Price: c.Price}, nil
+}
+
+// toSort maps an API sort order to the storage sort, an empty order sorts by id. Prices of different
+// currencies do not compare, so sorting by price needs the subscriptions filtered by currency.
+func (o SubscriptionsOrder) toSort(currency models.Currency) (storage.SubscriptionsSort, error) {
+	switch o {
+	case "", OrderByID:
+		return storage.SubscriptionsSort{Field: storage.SortByID}, nil
+	case OrderByStartDate, OrderByStartDateDesc:
+		return storage.SubscriptionsSort{Field: storage.SortByStartTime, Desc: o == OrderByStartDateDesc}, nil
+	case OrderByPrice, OrderByPriceDesc:
+		if currency == "" {
+			return storage.SubscriptionsSort{}, fmt.Errorf("currency is required to sort by price")
+		}
+		return storage.SubscriptionsSort{Field: storage.SortByPrice, Desc: o == OrderByPriceDesc}, nil
+	default:
+		return storage.SubscriptionsSort{}, fmt.Errorf("unknown sort order %q", o)
+	}
+}
@@ -0,0 +1,33 @@
+package service
+
+import (
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"testing"
+)
+
+func TestSubscriptionsOrderToSort(t *testing.T) {
+	tests := []struct {
+		order    SubscriptionsOrder
+		currency models.Currency
+		want     storage.SubscriptionsSort
+		wantErr  bool
+	}{
+		{order: "", want: storage.SubscriptionsSort{Field: storage.SortByID}},
+		{order: OrderByStartDateDesc, want: storage.SubscriptionsSort{Field: storage.SortByStartTime, Desc: true}},
+		{order: OrderByPrice, currency: "USD", want: storage.SubscriptionsSort{Field: storage.SortByPrice}},
+		{order: OrderByPriceDesc, wantErr: true},
+		{order: "name", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.order.toSort(tt.currency)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("toSort(%q, %q) error = %v, wantErr %v", tt.order, tt.currency, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("toSort(%q, %q) = %+v, want %+v", tt.order, tt.currency, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// WebhookSignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the body keyed with the webhook secret.
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// WebhookDeliveryConfig tells how persistently events are delivered. Every publishing attempt posts the event
+// to each webhook once, a webhook still failing on attempt MaxAttempts gets the event in its dead-letter list.
+// Retries are left to the caller publishing the event again. Workers is the number of webhooks an event is
+// delivered to at the same time. Connections to non-public addresses are refused unless AllowPrivateTargets is set.
+type WebhookDeliveryConfig struct {
+	MaxAttempts         int
+	Timeout             time.Duration
+	Workers             int
+	AllowPrivateTargets bool
+}
+
+// NewWebhookDispatcher creates the publisher delivering subscription events to the webhooks subscribed to them.
+// Publish does not wait between attempts, the outbox relay publishes a failed event again later and holds
+// back the next events of its subscription meanwhile, so they arrive in order.
+func NewWebhookDispatcher(s storage.WebhooksStorage, cfg WebhookDeliveryConfig, log *slog.Logger) *WebhookDispatcher {
+	cfg.MaxAttempts = max(cfg.MaxAttempts, 1)
+	cfg.Workers = max(cfg.Workers, 1)
+	return &WebhookDispatcher{
+		webhooksStorage: s,
+		cfg:             cfg,
+		client:          &http.Client{Timeout: cfg.Timeout, Transport: webhookTransport(cfg.AllowPrivateTargets)},
+		log:             log.With(slog.String("component", "WebhookDispatcher")),
+	}
+}
+
+type WebhookDispatcher struct {
+	webhooksStorage storage.WebhooksStorage
+	cfg             WebhookDeliveryConfig
+	client          *http.Client
+	log             *slog.Logger
+}
+
+// eventPayload is the JSON body posted to webhooks.
+type eventPayload struct {
+	ID             string               `json:"id"`
+	Type           models.EventType     `json:"type"`
+	OccurredAt     time.Time            `json:"occurred_at"`
+	SubscriptionID string               `json:"subscription_id"`
+	Subscription   *subscriptionPayload `json:"subscription,omitempty"`
+}
+
+type subscriptionPayload struct {
+	ID            string               `json:"id"`
+	TenantID      string               `json:"tenant_id"`
+	UserID        string               `json:"user_id"`
+	ServiceName   string               `json:"service_name"`
+	Price         int64                `json:"price"`
+	Currency      models.Currency      `json:"currency"`
+	BillingPeriod models.BillingPeriod `json:"billing_period"`
+	StartDate     string               `json:"start_date"`
+	EndDate       *string              `json:"end_date"`
+	Version       int64                `json:"version"`
+}
+
+// Publish delivers the event to every webhook subscribed to its type. A webhook failing on the last attempt
+// gets the event in its dead-letter list. An error means the event has to be published again: a webhook
+// failed before the last attempt, the delivery was interrupted by ctx or a dead letter could not be stored.
+// Webhooks that accepted the event on an earlier attempt get it again, receivers tell repeated deliveries
+// by the X-Webhook-Delivery header.
+func (d *WebhookDispatcher) Publish(ctx context.Context, e models.SubscriptionEvent) error {
+	webhooks, err := d.webhooksStorage.FindByEvent(ctx, e.Tenant, e.Type)
+	if err != nil {
+		return fmt.Errorf("can not find webhooks: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	body, err := json.Marshal(newEventPayload(e))
+	if err != nil {
+		return fmt.Errorf("can not encode event: %w", err)
+	}
+
+	errs := make([]error, len(webhooks))
+	workers := make(chan struct{}, d.cfg.Workers)
+	var wg sync.WaitGroup
+	for i, w := range webhooks {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+			errs[i] = d.deliver(ctx, w, e, body)
+		}()
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, w *models.Webhook, e models.SubscriptionEvent, body []byte) error {
+	attempt := max(e.Attempt, 1)
+	log := d.log.With(slog.Any("webhook_id", w.ID), slog.Any("event_id", e.ID), slog.Int("attempt", attempt))
+
+	err := d.send(ctx, w, e, body)
+	if err == nil {
+		log.Info("event delivered")
+		return nil
+	}
+
+	// an interrupted delivery is no verdict on the webhook, the event stays pending and is delivered again
+	if ctx.Err() != nil {
+		log.Info("event delivery interrupted")
+		return fmt.Errorf("delivery to webhook %s interrupted: %w", w.ID, ctx.Err())
+	}
+
+	log.Warn("event delivery failed", sl.Err(err))
+	if attempt < d.cfg.MaxAttempts {
+		return fmt.Errorf("delivery to webhook %s failed: %w", w.ID, err)
+	}
+
+	letter := models.DeadLetter{
+		WebhookID: w.ID,
+		EventID:   e.ID,
+		EventType: e.Type,
+		Payload:   body,
+		Attempts:  attempt,
+		LastError: err.Error(),
+		FailedAt:  time.Now().UTC(),
+	}
+	if err := d.webhooksStorage.AddDeadLetter(ctx, letter); err != nil {
+		log.Error("failed to store dead letter", sl.Err(err))
+		return fmt.Errorf("can not store dead letter for webhook %s: %w", w.ID, err)
+	}
+
+	log.Warn("event moved to dead letters")
+	return nil
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, w *models.Webhook, e models.SubscriptionEvent, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("can not create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, string(e.Type))
+	req.Header.Set(WebhookDeliveryHeader, e.ID.String())
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(w.Secret, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can not post event: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
+
+// webhookTransport checks the address every delivery connects to, so a host resolving to a public
+// address when the webhook was created can not later point deliveries at the internal network.
+func webhookTransport(allowPrivateTargets bool) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if allowPrivateTargets {
+		return t
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !isPublicIP(ip) {
+				return fmt.Errorf("webhook target %s is not a public address", host)
+			}
+			return nil
+		},
+	}
+	t.DialContext = dialer.DialContext
+	// a proxy would be the checked address instead of the webhook host
+	t.Proxy = nil
+
+	return t
+}
+
+// SignWebhookPayload returns the value of the signature header for the body, receivers recompute it to verify deliveries.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newEventPayload(e models.SubscriptionEvent) eventPayload {
+	p := eventPayload{
+		ID:             e.ID.String(),
+		Type:           e.Type,
+		OccurredAt:     e.OccurredAt,
+		SubscriptionID: e.SubscriptionID.String(),
+	}
+
+	if sub := e.Subscription; sub != nil {
+		p.Subscription = &subscriptionPayload{
+			ID:            sub.ID.String(),
+			TenantID:      sub.Tenant,
+			UserID:        sub.Owner.String(),
+			ServiceName:   sub.ServiceName,
+			Price:         sub.Price.Amount,
+			Currency:      sub.Price.Currency,
+			BillingPeriod: sub.Period,
+			StartDate:     sub.StartedAt.Format(time.DateOnly),
+			Version:       sub.Version,
+		}
+		if sub.IsCompleted() {
+			endDate := sub.CompletedAt.Format(time.DateOnly)
+			p.Subscription.EndDate = &endDate
+		}
+	}
+
+	return p
+}
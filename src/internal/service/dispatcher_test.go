@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"effective-mobile/internal/models"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// webhookEndpoint answers deliveries with the given statuses in turn, repeating the last one.
+type webhookEndpoint struct {
+	mu       sync.Mutex
+	statuses []int
+	headers  []http.Header
+	bodies   [][]byte
+	times    []time.Time
+}
+
+func newWebhookEndpoint(t *testing.T, statuses ...int) (*webhookEndpoint, *models.Webhook) {
+	t.Helper()
+
+	e := &webhookEndpoint{statuses: statuses}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		e.mu.Lock()
+		e.headers = append(e.headers, r.Header.Clone())
+		e.bodies = append(e.bodies, body)
+		e.times = append(e.times, time.Now())
+		status := e.statuses[min(len(e.times), len(e.statuses))-1]
+		e.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return e, &models.Webhook{ID: uuid.New(), Tenant: "acme", URL: srv.URL, Secret: "s3cret"}
+}
+
+func testEvent() models.SubscriptionEvent {
+	return models.SubscriptionEvent{
+		ID:             uuid.New(),
+		Type:           models.EventSubscriptionCreated,
+		Tenant:         "acme",
+		SubscriptionID: uuid.New(),
+		OccurredAt:     time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func newTestDispatcher(webhooks *fakeWebhooksStorage, cfg WebhookDeliveryConfig) *WebhookDispatcher {
+	cfg.Timeout = time.Second
+	return NewWebhookDispatcher(webhooks, cfg, testLogger())
+}
+
+func TestWebhookDispatcherSignsDeliveries(t *testing.T) {
+	endpoint, hook := newWebhookEndpoint(t, http.StatusNoContent)
+	d := newTestDispatcher(&fakeWebhooksStorage{webhooks: []*models.Webhook{hook}}, WebhookDeliveryConfig{MaxAttempts: 1, AllowPrivateTargets: true})
+
+	e := testEvent()
+	if err := d.Publish(context.Background(), e); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if len(endpoint.bodies) != 1 {
+		t.Fatalf("webhook received %d deliveries, want 1", len(endpoint.bodies))
+	}
+	header, body := endpoint.headers[0], endpoint.bodies[0]
+
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	if got, want := header.Get(WebhookSignatureHeader), "sha256="+hex.EncodeToString(mac.Sum(nil)); got != want {
+		t.Errorf("%s = %q, want %q", WebhookSignatureHeader, got, want)
+	}
+	if got := header.Get(WebhookEventHeader); got != string(e.Type) {
+		t.Errorf("%s = %q, want %q", WebhookEventHeader, got, e.Type)
+	}
+	if got := header.Get(WebhookDeliveryHeader); got != e.ID.String() {
+		t.Errorf("%s = %q, want %q", WebhookDeliveryHeader, got, e.ID)
+	}
+	if !strings.Contains(string(body), e.SubscriptionID.String()) {
+		t.Errorf("body %s does not name subscription %s", body, e.SubscriptionID)
+	}
+}
+
+func TestWebhookDispatcherDeliversWithinTenant(t *testing.T) {
+	own, hook := newWebhookEndpoint(t, http.StatusOK)
+	foreign, other := newWebhookEndpoint(t, http.StatusOK)
+	other.Tenant = "globex"
+	d := newTestDispatcher(&fakeWebhooksStorage{webhooks: []*models.Webhook{hook, other}}, WebhookDeliveryConfig{MaxAttempts: 1, AllowPrivateTargets: true})
+
+	if err := d.Publish(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if len(own.bodies) != 1 {
+		t.Errorf("webhook of the tenant received %d deliveries, want 1", len(own.bodies))
+	}
+	if len(foreign.bodies) != 0 {
+		t.Errorf("webhook of another tenant received %d deliveries, want none", len(foreign.bodies))
+	}
+}
+
+func TestWebhookDispatcherLeavesRetriesToCaller(t *testing.T) {
+	endpoint, hook := newWebhookEndpoint(t, http.StatusInternalServerError)
+	webhooks := &fakeWebhooksStorage{webhooks: []*models.Webhook{hook}}
+	d := newTestDispatcher(webhooks, WebhookDeliveryConfig{MaxAttempts: 3, AllowPrivateTargets: true})
+
+	e := testEvent()
+	e.Attempt = 2
+	if err := d.Publish(context.Background(), e); err == nil {
+		t.Error("Publish() succeeded, want an error so the event is published again")
+	}
+
+	if len(endpoint.times) != 1 {
+		t.Errorf("webhook received %d deliveries, want 1", len(endpoint.times))
+	}
+	if len(webhooks.deadLetters) != 0 {
+		t.Errorf("event with attempts left was moved to dead letters: %+v", webhooks.deadLetters)
+	}
+}
+
+func TestWebhookDispatcherStoresDeadLetter(t *testing.T) {
+	_, failing := newWebhookEndpoint(t, http.StatusServiceUnavailable)
+	healthy, ok := newWebhookEndpoint(t, http.StatusOK)
+	webhooks := &fakeWebhooksStorage{webhooks: []*models.Webhook{failing, ok}}
+	d := newTestDispatcher(webhooks, WebhookDeliveryConfig{MaxAttempts: 3, Workers: 2, AllowPrivateTargets: true})
+
+	e := testEvent()
+	e.Attempt = 3
+	if err := d.Publish(context.Background(), e); err != nil {
+		t.Fatalf("Publish() error = %v, want the event handled once the dead letter is stored", err)
+	}
+
+	if len(webhooks.deadLetters) != 1 {
+		t.Fatalf("stored %d dead letters, want 1", len(webhooks.deadLetters))
+	}
+	letter := webhooks.deadLetters[0]
+	if letter.WebhookID != failing.ID || letter.EventID != e.ID || letter.EventType != e.Type {
+		t.Errorf("dead letter = %+v, want webhook %s and event %s", letter, failing.ID, e.ID)
+	}
+	if letter.Attempts != 3 {
+		t.Errorf("dead letter attempts = %d, want 3", letter.Attempts)
+	}
+	if !strings.Contains(letter.LastError, "503") {
+		t.Errorf("dead letter error = %q, want the last response status", letter.LastError)
+	}
+	if len(healthy.bodies) != 1 {
+		t.Errorf("healthy webhook received %d deliveries, want 1", len(healthy.bodies))
+	}
+}
+
+func TestWebhookDispatcherKeepsEventPending(t *testing.T) {
+	t.Run("dead letter not stored", func(t *testing.T) {
+		_, hook := newWebhookEndpoint(t, http.StatusServiceUnavailable)
+		webhooks := &fakeWebhooksStorage{webhooks: []*models.Webhook{hook}, deadLetterErr: errors.New("database is down")}
+		d := newTestDispatcher(webhooks, WebhookDeliveryConfig{MaxAttempts: 1, AllowPrivateTargets: true})
+
+		if err := d.Publish(context.Background(), testEvent()); err == nil {
+			t.Error("Publish() succeeded, want an error so the event is published again")
+		}
+	})
+
+	t.Run("delivery interrupted", func(t *testing.T) {
+		_, hook := newWebhookEndpoint(t, http.StatusServiceUnavailable)
+		webhooks := &fakeWebhooksStorage{webhooks: []*models.Webhook{hook}}
+		d := newTestDispatcher(webhooks, WebhookDeliveryConfig{MaxAttempts: 1, AllowPrivateTargets: true})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := d.Publish(ctx, testEvent()); err == nil {
+			t.Error("Publish() succeeded, want an error so the event is published again")
+		}
+		if len(webhooks.deadLetters) != 0 {
+			t.Errorf("interrupted delivery was moved to dead letters: %+v", webhooks.deadLetters)
+		}
+	})
+}
+
+func TestWebhookDispatcherRefusesPrivateTargets(t *testing.T) {
+	endpoint, hook := newWebhookEndpoint(t, http.StatusOK)
+	webhooks := &fakeWebhooksStorage{webhooks: []*models.Webhook{hook}}
+	d := newTestDispatcher(webhooks, WebhookDeliveryConfig{MaxAttempts: 1})
+
+	if err := d.Publish(context.Background(), testEvent()); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+
+	if len(endpoint.bodies) != 0 {
+		t.Errorf("loopback webhook received %d deliveries, want none", len(endpoint.bodies))
+	}
+	if len(webhooks.deadLetters) != 1 || !strings.Contains(webhooks.deadLetters[0].LastError, "not a public address") {
+		t.Errorf("dead letters = %+v, want the refused delivery", webhooks.deadLetters)
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"effective-mobile/pkg/xlsx"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson"
+	ExportXLSX   ExportFormat = "xlsx"
+)
+
+// ContentType is the media type of an export in this format.
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportCSV:
+		return "text/csv; charset=utf-8"
+	case ExportNDJSON:
+		return "application/x-ndjson"
+	case ExportXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// exportColumns match the import header, so an export can be imported back. The price is exported in whole
+// units like the API shows it, price_minor holds the exact amount the import takes back.
+var exportColumns = []string{"id", "user_id", "service_name", "price", "price_minor", "currency", "billing_period", "start_date", "end_date"}
+
+type ExportSubscriptionsArgs struct {
+	Format   ExportFormat
+	UserID   models.PersonID
+	Service  models.ServiceName
+	Category string
+	ActiveAt *time.Time
+	MinPrice *int64
+	MaxPrice *int64
+	Currency string
+	Order    SubscriptionsOrder
+}
+
+// subscriptionsExport is a validated export that has not been written yet.
+type subscriptionsExport struct {
+	Format   ExportFormat
+	FileName string
+	write    func(w io.Writer) error
+}
+
+// Write streams the export into w. Once it started, a failure leaves w with a truncated file.
+func (e subscriptionsExport) Write(w io.Writer) error {
+	return e.write(w)
+}
+
+type exportRecord struct {
+	ID            models.SubscriptionID `json:"id"`
+	UserID        models.PersonID       `json:"user_id"`
+	ServiceName   string                `json:"service_name"`
+	Price         int64                 `json:"price"`
+	PriceMinor    int64                 `json:"price_minor"`
+	Currency      models.Currency       `json:"currency"`
+	BillingPeriod models.BillingPeriod  `json:"billing_period"`
+	StartDate     string                `json:"start_date"`
+	EndDate       *string               `json:"end_date"`
+}
+
+func newExportRecord(sub *models.Subscription) exportRecord {
+	r := exportRecord{
+		ID:            sub.ID,
+		UserID:        sub.Owner,
+		ServiceName:   sub.ServiceName,
+		Price:         sub.Price.Units(),
+		PriceMinor:    sub.Price.Amount,
+		Currency:      sub.Price.Currency,
+		BillingPeriod: sub.Period,
+		StartDate:     sub.StartedAt.UTC().Format(importDateLayout),
+	}
+	if sub.IsCompleted() {
+		endDate := sub.CompletedAt.UTC().Format(importDateLayout)
+		r.EndDate = &endDate
+	}
+
+	return r
+}
+
+func (s subscriptionService) ExportSubscriptions(ctx context.Context, a ExportSubscriptionsArgs) (subscriptionsExport, error) {
+	const op = "internal.service.impl.ExportSubscriptions"
+
+	if a.Format == "" {
+		a.Format = ExportCSV
+	}
+	if a.Format != ExportCSV && a.Format != ExportNDJSON && a.Format != ExportXLSX {
+		s.log.Warn("invalid input: unknown export format", slog.String("op", op), slog.String("format", string(a.Format)))
+		return subscriptionsExport{}, NewInvalidInputError(fmt.Sprintf("unknown export format %q", a.Format))
+	}
+	currency, err := checkPriceRange(a.MinPrice, a.MaxPrice, a.Currency)
+	if err != nil {
+		s.log.Warn("invalid input: price range", slog.String("op", op), sl.Err(err))
+		return subscriptionsExport{}, err
+	}
+	owner, err := scopeOwner(ctx, a.UserID)
+	if err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("user_id", a.UserID))
+		return subscriptionsExport{}, err
+	}
+	sort, err := a.Order.toSort(currency)
+	if err != nil {
+		s.log.Warn("invalid input: order", slog.String("op", op), slog.String("order", string(a.Order)), sl.Err(err))
+		return subscriptionsExport{}, NewInvalidInputError(err.Error())
+	}
+	serviceName, err := filterServiceName(ctx, s.catalog, a.Service)
+	if err != nil {
+		s.log.Error("failed to resolve service name", slog.String("op", op), sl.Err(err))
+		return subscriptionsExport{}, err
+	}
+
+	f := storage.SubscriptionsFilter{
+		Tenant:      TenantFromContext(ctx),
+		OwnerID:     owner,
+		ServiceName: serviceName,
+		Category:    models.NormalizeCategory(a.Category),
+		ActiveAt:    a.ActiveAt,
+		MinPrice:    a.MinPrice,
+		MaxPrice:    a.MaxPrice,
+		Currency:    currency,
+		Sort:        sort,
+	}
+
+	export := subscriptionsExport{
+		Format:   a.Format,
+		FileName: fmt.Sprintf("subscriptions-%s.%s", time.Now().UTC().Format(importDateLayout), a.Format),
+	}
+	export.write = func(w io.Writer) error {
+		var err error
+		switch a.Format {
+		case ExportCSV:
+			err = s.exportCSV(ctx, f, w)
+		case ExportNDJSON:
+			err = s.exportNDJSON(ctx, f, w)
+		case ExportXLSX:
+			err = s.exportXLSX(ctx, f, w)
+		}
+		if err != nil {
+			s.log.Error("failed to export subscriptions", slog.String("op", op), sl.Err(err))
+			return NewInternalError("failed to export subscriptions")
+		}
+
+		s.log.Info("subscriptions exported", slog.String("op", op), slog.String("format", string(a.Format)))
+		return nil
+	}
+
+	return export, nil
+}
+
+func (s subscriptionService) exportCSV(ctx context.Context, f storage.SubscriptionsFilter, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return err
+	}
+
+	err := s.subscriptionsStorage.Stream(ctx, f, func(sub *models.Subscription) error {
+		r := newExportRecord(sub)
+		endDate := ""
+		if r.EndDate != nil {
+			endDate = *r.EndDate
+		}
+		return cw.Write([]string{
+			r.ID.String(),
+			r.UserID.String(),
+			r.ServiceName,
+			strconv.FormatInt(r.Price, 10),
+			strconv.FormatInt(r.PriceMinor, 10),
+			string(r.Currency),
+			string(r.BillingPeriod),
+			r.StartDate,
+			endDate,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func (s subscriptionService) exportNDJSON(ctx context.Context, f storage.SubscriptionsFilter, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	encoder := json.NewEncoder(bw)
+
+	err := s.subscriptionsStorage.Stream(ctx, f, func(sub *models.Subscription) error {
+		return encoder.Encode(newExportRecord(sub))
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+func (s subscriptionService) exportXLSX(ctx context.Context, f storage.SubscriptionsFilter, w io.Writer) error {
+	xw, err := xlsx.NewWriter(w, "Subscriptions")
+	if err != nil {
+		return err
+	}
+
+	header := make([]any, len(exportColumns))
+	for i, c := range exportColumns {
+		header[i] = c
+	}
+	if err := xw.WriteRow(header...); err != nil {
+		return err
+	}
+
+	err = s.subscriptionsStorage.Stream(ctx, f, func(sub *models.Subscription) error {
+		r := newExportRecord(sub)
+		var endDate any
+		if r.EndDate != nil {
+			endDate = *r.EndDate
+		}
+		return xw.WriteRow(r.ID.String(), r.UserID.String(), r.ServiceName, r.Price, r.PriceMinor, string(r.Currency), string(r.BillingPeriod), r.StartDate, endDate)
+	})
+	if err != nil {
+		return err
+	}
+
+	return xw.Close()
+}
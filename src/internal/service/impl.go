@@ -6,32 +6,57 @@ import (
 	"effective-mobile/internal/storage"
 	"effective-mobile/pkg/logger/sl"
 	"errors"
+	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
-
-	"github.com/google/uuid"
 )
 
-func NewSubscriptionService(s storage.SubscriptionsStorage, log *slog.Logger) SubscriptionService {
+// NewSubscriptionService creates the subscription service. Owners of stored subscriptions must be known users
+// and service names are resolved through the catalog. Exchange rates are optional,
+// without them totals can not be converted to a requested currency.
+func NewSubscriptionService(s storage.SubscriptionsStorage, users storage.UsersStorage, catalog ServiceCatalog, rates ExchangeRates, log *slog.Logger) SubscriptionService {
 	return subscriptionService{
 		subscriptionsStorage: s,
+		usersStorage:         users,
+		catalog:              catalog,
+		exchangeRates:        rates,
 		log:                  log.With(slog.String("component", "SubscriptionService")),
 	}
 }
 
 type subscriptionService struct {
 	subscriptionsStorage storage.SubscriptionsStorage
+	usersStorage         storage.UsersStorage
+	catalog              ServiceCatalog
+	exchangeRates        ExchangeRates
 	log                  *slog.Logger
 }
 
 func (s subscriptionService) CreateNewSubscription(ctx context.Context, c CreateNewSubscriptionArgs) (*models.Subscription, error) {
 	const op = "internal.service.impl.CreateNewSubscription"
 
-	sub, err := models.NewSubscription(c.UserID, c.PriceRUB, c.Service, c.StartTime, c.EndTime)
+	price := models.Money{Amount: c.Price, Currency: c.Currency}
+	if c.PriceInUnits {
+		price = models.MoneyFromUnits(c.Price, c.Currency)
+	}
+	sub, err := models.NewSubscription(c.UserID, price, c.Service, c.BillingPeriod, c.StartTime, c.EndTime)
 	if err != nil {
 		s.log.Debug("validation failed", slog.String("op", op), sl.Err(err))
 		return nil, NewInvalidInputError(err.Error())
 	}
+	if err := authorizeOwner(ctx, sub.Owner); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("user_id", sub.Owner))
+		return nil, err
+	}
+	sub.Tenant = tenantOfNewData(ctx)
+	if err := s.ensureOwnerExists(ctx, sub.Tenant, sub.Owner); err != nil {
+		return nil, err
+	}
+	if err := s.useCanonicalServiceName(ctx, sub); err != nil {
+		return nil, err
+	}
 
 	err = s.subscriptionsStorage.Add(ctx, *sub)
 	if err != nil {
@@ -46,7 +71,7 @@ func (s subscriptionService) CreateNewSubscription(ctx context.Context, c Create
 func (s subscriptionService) UpdateExistingSubscription(ctx context.Context, u UpdateExistingSubscriptionArgs) (*models.Subscription, error) {
 	const op = "internal.service.impl.UpdateExistingSubscription"
 
-	sub, err := s.subscriptionsStorage.FindByID(ctx, u.SubscriptionID)
+	sub, err := s.subscriptionsStorage.FindByID(ctx, TenantFromContext(ctx), u.SubscriptionID)
 	if err != nil {
 		if errors.Is(err, storage.ErrSubscriptionNotFound) {
 			s.log.Warn("subscription not found", slog.String("op", op), slog.Any("subscription_id", u.SubscriptionID))
@@ -55,11 +80,26 @@ func (s subscriptionService) UpdateExistingSubscription(ctx context.Context, u U
 		s.log.Error("failed to find subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", u.SubscriptionID))
 		return nil, NewInternalError("failed to update subscription")
 	}
+	if err := authorizeOwner(ctx, sub.Owner); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+		return nil, err
+	}
+	if u.IfMatch != nil && *u.IfMatch != sub.Version {
+		s.log.Warn("subscription version mismatch", slog.String("op", op), slog.Any("subscription_id", sub.ID), slog.Int64("version", sub.Version))
+		return nil, NewPreconditionFailedError("subscription was modified, fetch it again")
+	}
 
 	if err := sub.ChangeOwner(u.UserID); err != nil {
 		s.log.Warn("invalid user id", slog.String("op", op), sl.Err(err))
 		return nil, NewInvalidInputError(err.Error())
 	}
+	if err := authorizeOwner(ctx, sub.Owner); err != nil {
+		s.log.Warn("access denied: subscription can not be handed to another user", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+		return nil, err
+	}
+	if err := s.ensureOwnerExists(ctx, sub.Tenant, sub.Owner); err != nil {
+		return nil, err
+	}
 	sub.ResetEndTime()
 	if err := sub.ChangeStartTime(u.StartTime); err != nil {
 		s.log.Warn("invalid start time", slog.String("op", op), sl.Err(err))
@@ -71,7 +111,11 @@ func (s subscriptionService) UpdateExistingSubscription(ctx context.Context, u U
 			return nil, NewInvalidInputError(err.Error())
 		}
 	}
-	if err := sub.ChangePrice(u.PriceRUB); err != nil {
+	price := models.Money{Amount: u.Price, Currency: u.Currency}
+	if u.PriceInUnits {
+		price = models.MoneyFromUnits(u.Price, u.Currency)
+	}
+	if err := sub.ChangePrice(price); err != nil {
 		s.log.Warn("invalid price", slog.String("op", op), sl.Err(err))
 		return nil, NewInvalidInputError(err.Error())
 	}
@@ -79,21 +123,142 @@ func (s subscriptionService) UpdateExistingSubscription(ctx context.Context, u U
 		s.log.Warn("invalid service name", slog.String("op", op), sl.Err(err))
 		return nil, NewInvalidInputError(err.Error())
 	}
+	if err := s.useCanonicalServiceName(ctx, sub); err != nil {
+		return nil, err
+	}
+	period := u.BillingPeriod
+	if period == "" {
+		period = models.BillingMonthly
+	}
+	if err := sub.ChangeBillingPeriod(period); err != nil {
+		s.log.Warn("invalid billing period", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
 
 	err = s.subscriptionsStorage.Update(ctx, *sub)
 	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			s.log.Warn("subscription not found", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+			return nil, NewNotFoundError("subscription not found")
+		}
+		if errors.Is(err, storage.ErrVersionConflict) {
+			s.log.Warn("subscription was modified concurrently", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+			return nil, NewPreconditionFailedError("subscription was modified, fetch it again")
+		}
 		s.log.Error("failed to update subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", sub.ID))
 		return nil, NewInternalError("failed to update subscription")
 	}
+	sub.Version++
 
 	s.log.Info("subscription updated", slog.String("op", op), slog.Any("subscription_id", sub.ID))
 	return sub, nil
 }
 
+func (s subscriptionService) PatchSubscription(ctx context.Context, p PatchSubscriptionArgs) (*models.Subscription, error) {
+	const op = "internal.service.impl.PatchSubscription"
+
+	sub, err := s.subscriptionsStorage.FindByID(ctx, TenantFromContext(ctx), p.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			s.log.Warn("subscription not found", slog.String("op", op), slog.Any("subscription_id", p.SubscriptionID))
+			return nil, NewNotFoundError("subscription not found")
+		}
+		s.log.Error("failed to find subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", p.SubscriptionID))
+		return nil, NewInternalError("failed to update subscription")
+	}
+	if err := authorizeOwner(ctx, sub.Owner); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+		return nil, err
+	}
+	if p.IfMatch != nil && *p.IfMatch != sub.Version {
+		s.log.Warn("subscription version mismatch", slog.String("op", op), slog.Any("subscription_id", sub.ID), slog.Int64("version", sub.Version))
+		return nil, NewPreconditionFailedError("subscription was modified, fetch it again")
+	}
+
+	if p.UserID != nil {
+		if err := sub.ChangeOwner(*p.UserID); err != nil {
+			s.log.Warn("invalid user id", slog.String("op", op), sl.Err(err))
+			return nil, NewInvalidInputError(err.Error())
+		}
+		if err := authorizeOwner(ctx, sub.Owner); err != nil {
+			s.log.Warn("access denied: subscription can not be handed to another user", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+			return nil, err
+		}
+		if err := s.ensureOwnerExists(ctx, sub.Tenant, sub.Owner); err != nil {
+			return nil, err
+		}
+	}
+	// the end time is dropped before the start time moves so both can be shifted past the old end in one patch
+	if p.ClearEndTime || p.EndTime != nil {
+		sub.ResetEndTime()
+	}
+	if p.StartTime != nil {
+		if err := sub.ChangeStartTime(*p.StartTime); err != nil {
+			s.log.Warn("invalid start time", slog.String("op", op), sl.Err(err))
+			return nil, NewInvalidInputError(err.Error())
+		}
+	}
+	if !p.ClearEndTime && p.EndTime != nil {
+		if err := sub.ChangeEndTime(*p.EndTime); err != nil {
+			s.log.Warn("invalid end time", slog.String("op", op), sl.Err(err))
+			return nil, NewInvalidInputError(err.Error())
+		}
+	}
+	if p.Price != nil || p.Currency != nil {
+		price := sub.Price
+		if p.Price != nil {
+			price.Amount = *p.Price
+		}
+		if p.Currency != nil {
+			price.Currency = *p.Currency
+		}
+		if p.Price != nil && p.PriceInUnits {
+			price = models.MoneyFromUnits(*p.Price, price.Currency)
+		}
+		if err := sub.ChangePrice(price); err != nil {
+			s.log.Warn("invalid price", slog.String("op", op), sl.Err(err))
+			return nil, NewInvalidInputError(err.Error())
+		}
+	}
+	if p.Service != nil {
+		if err := sub.ChangeServiceName(*p.Service); err != nil {
+			s.log.Warn("invalid service name", slog.String("op", op), sl.Err(err))
+			return nil, NewInvalidInputError(err.Error())
+		}
+		if err := s.useCanonicalServiceName(ctx, sub); err != nil {
+			return nil, err
+		}
+	}
+	if p.BillingPeriod != nil {
+		if err := sub.ChangeBillingPeriod(*p.BillingPeriod); err != nil {
+			s.log.Warn("invalid billing period", slog.String("op", op), sl.Err(err))
+			return nil, NewInvalidInputError(err.Error())
+		}
+	}
+
+	err = s.subscriptionsStorage.Update(ctx, *sub)
+	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			s.log.Warn("subscription not found", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+			return nil, NewNotFoundError("subscription not found")
+		}
+		if errors.Is(err, storage.ErrVersionConflict) {
+			s.log.Warn("subscription was modified concurrently", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+			return nil, NewPreconditionFailedError("subscription was modified, fetch it again")
+		}
+		s.log.Error("failed to update subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", sub.ID))
+		return nil, NewInternalError("failed to update subscription")
+	}
+	sub.Version++
+
+	s.log.Info("subscription patched", slog.String("op", op), slog.Any("subscription_id", sub.ID))
+	return sub, nil
+}
+
 func (s subscriptionService) FindSubscriptionByID(ctx context.Context, id models.SubscriptionID) (*models.Subscription, error) {
 	const op = "internal.service.impl.FindSubscriptionByID"
 
-	sub, err := s.subscriptionsStorage.FindByID(ctx, id)
+	sub, err := s.subscriptionsStorage.FindByID(ctx, TenantFromContext(ctx), id)
 	if err != nil {
 		if errors.Is(err, storage.ErrSubscriptionNotFound) {
 			s.log.Warn("subscription not found", slog.String("op", op), slog.Any("subscription_id", id))
@@ -102,33 +267,119 @@ func (s subscriptionService) FindSubscriptionByID(ctx context.Context, id models
 		s.log.Error("failed to find subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", id))
 		return nil, NewInternalError("failed to fetch subscription")
 	}
+	if err := authorizeOwner(ctx, sub.Owner); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("subscription_id", id))
+		return nil, err
+	}
 
 	s.log.Info("subscription fetched", slog.String("op", op), slog.Any("subscription_id", id))
 	return sub, nil
 }
 
-func (s subscriptionService) GetSubscriptions(ctx context.Context) []*models.Subscription {
+func (s subscriptionService) GetSubscriptions(ctx context.Context, l ListSubscriptionsArgs) (subscriptionsPage, error) {
 	const op = "internal.service.impl.GetSubscriptions"
 
-	subs, err := s.subscriptionsStorage.Find(ctx, storage.SubscriptionsFilter{})
+	return s.listSubscriptions(ctx, op, l, false)
+}
+
+func (s subscriptionService) GetDeletedSubscriptions(ctx context.Context, l ListSubscriptionsArgs) (subscriptionsPage, error) {
+	const op = "internal.service.impl.GetDeletedSubscriptions"
+
+	return s.listSubscriptions(ctx, op, l, true)
+}
+
+func (s subscriptionService) listSubscriptions(ctx context.Context, op string, l ListSubscriptionsArgs, deleted bool) (subscriptionsPage, error) {
+	owner, err := scopeOwner(ctx, l.UserID)
+	if err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("user_id", l.UserID))
+		return subscriptionsPage{}, err
+	}
+	l.UserID = owner
+
+	if l.Limit == 0 {
+		l.Limit = DefaultPageLimit
+	}
+	if l.Limit < 0 || l.Limit > MaxPageLimit {
+		s.log.Warn("invalid input: limit out of range", slog.String("op", op), slog.Int("limit", l.Limit))
+		return subscriptionsPage{}, NewInvalidInputError(fmt.Sprintf("limit must be between 1 and %d", MaxPageLimit))
+	}
+	currency, err := checkPriceRange(l.MinPrice, l.MaxPrice, l.Currency)
+	if err != nil {
+		s.log.Warn("invalid input: price range", slog.String("op", op), sl.Err(err))
+		return subscriptionsPage{}, err
+	}
+	serviceName, err := filterServiceName(ctx, s.catalog, l.Service)
+	if err != nil {
+		s.log.Error("failed to resolve service name", slog.String("op", op), sl.Err(err))
+		return subscriptionsPage{}, err
+	}
+	if l.Order == "" {
+		l.Order = OrderByID
+	}
+	sort, err := l.Order.toSort(currency)
+	if err != nil {
+		s.log.Warn("invalid input: sort order", slog.String("op", op), slog.Any("sort", l.Order), sl.Err(err))
+		return subscriptionsPage{}, NewInvalidInputError(err.Error())
+	}
+
+	f := storage.SubscriptionsFilter{
+		Tenant:      TenantFromContext(ctx),
+		OwnerID:     l.UserID,
+		ServiceName: serviceName,
+		Category:    models.NormalizeCategory(l.Category),
+		ActiveAt:    l.ActiveAt,
+		MinPrice:    l.MinPrice,
+		MaxPrice:    l.MaxPrice,
+		Currency:    currency,
+		Deleted:     deleted,
+		Sort:        sort,
+		// one extra row tells whether there is a next page
+		Limit: l.Limit + 1,
+	}
+
+	if l.Cursor != "" {
+		after, err := decodeCursor(l.Order, l.Cursor)
+		if err != nil {
+			s.log.Warn("invalid cursor", slog.String("op", op), sl.Err(err))
+			return subscriptionsPage{}, NewInvalidInputError(err.Error())
+		}
+		f.After = after
+	}
+
+	subs, err := s.subscriptionsStorage.Find(ctx, f)
 	if err != nil {
 		s.log.Error("failed to fetch subscriptions", slog.String("op", op), sl.Err(err))
-		return nil
+		return subscriptionsPage{}, NewInternalError("failed to fetch subscriptions")
 	}
 
-	s.log.Info("subscriptions fetched", slog.String("op", op), slog.Int("count", len(subs)))
-	return subs
+	page := subscriptionsPage{Subscriptions: subs}
+	if len(subs) > l.Limit {
+		page.Subscriptions = subs[:l.Limit]
+		next := encodeCursor(l.Order, page.Subscriptions[l.Limit-1])
+		page.NextCursor = &next
+	}
+
+	s.log.Info("subscriptions fetched", slog.String("op", op), slog.Int("count", len(page.Subscriptions)))
+	return page, nil
 }
 
-func (s subscriptionService) RemoveExistingSubscription(ctx context.Context, id models.SubscriptionID) error {
+func (s subscriptionService) RemoveExistingSubscription(ctx context.Context, id models.SubscriptionID, ifMatch *int64) error {
 	const op = "internal.service.impl.RemoveExistingSubscription"
 
-	err := s.subscriptionsStorage.RemoveByID(ctx, id)
+	if err := s.authorizeSubscription(ctx, op, id); err != nil {
+		return err
+	}
+
+	err := s.subscriptionsStorage.RemoveByID(ctx, TenantFromContext(ctx), id, expectedVersion(ifMatch))
 	if err != nil {
 		if errors.Is(err, storage.ErrSubscriptionNotFound) {
 			s.log.Warn("subscription not found", slog.String("op", op), slog.Any("subscription_id", id))
 			return NewNotFoundError("subscription not found")
 		}
+		if errors.Is(err, storage.ErrVersionConflict) {
+			s.log.Warn("subscription version mismatch", slog.String("op", op), slog.Any("subscription_id", id))
+			return NewPreconditionFailedError("subscription was modified, fetch it again")
+		}
 		s.log.Error("failed to remove subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", id))
 		return NewInternalError("failed to remove subscription")
 	}
@@ -137,40 +388,300 @@ func (s subscriptionService) RemoveExistingSubscription(ctx context.Context, id
 	return nil
 }
 
-func (s subscriptionService) CalculateTotalSubscriptionsPrice(ctx context.Context, userID models.PersonID, serviceName models.ServiceName, startTime, endTime *time.Time) (totalSubscriptionsPrice, error) {
-	const op = "internal.service.impl.CalculateTotalSubscriptionsPrice"
+func (s subscriptionService) RestoreSubscription(ctx context.Context, id models.SubscriptionID) (*models.Subscription, error) {
+	const op = "internal.service.impl.RestoreSubscription"
 
-	if userID == uuid.Nil {
-		s.log.Warn("invalid input: user_id is empty", slog.String("op", op))
-		return totalSubscriptionsPrice{}, NewInvalidInputError("user_id is required")
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("subscription_id", id))
+		return nil, err
 	}
-	if serviceName == "" {
-		s.log.Warn("invalid input: service_name is empty", slog.String("op", op))
-		return totalSubscriptionsPrice{}, NewInvalidInputError("service_name is required")
+
+	err := s.subscriptionsStorage.Restore(ctx, TenantFromContext(ctx), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			s.log.Warn("deleted subscription not found", slog.String("op", op), slog.Any("subscription_id", id))
+			return nil, NewNotFoundError("deleted subscription not found")
+		}
+		s.log.Error("failed to restore subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", id))
+		return nil, NewInternalError("failed to restore subscription")
 	}
-	if startTime != nil && endTime != nil && !endTime.After(*startTime) {
+
+	sub, err := s.subscriptionsStorage.FindByID(ctx, TenantFromContext(ctx), id)
+	if err != nil {
+		s.log.Error("failed to fetch restored subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", id))
+		return nil, NewInternalError("failed to restore subscription")
+	}
+
+	s.log.Info("subscription restored", slog.String("op", op), slog.Any("subscription_id", id))
+	return sub, nil
+}
+
+func (s subscriptionService) PurgeSubscription(ctx context.Context, id models.SubscriptionID, ifMatch *int64) error {
+	const op = "internal.service.impl.PurgeSubscription"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("subscription_id", id))
+		return err
+	}
+
+	err := s.subscriptionsStorage.Purge(ctx, TenantFromContext(ctx), id, expectedVersion(ifMatch))
+	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			s.log.Warn("subscription not found", slog.String("op", op), slog.Any("subscription_id", id))
+			return NewNotFoundError("subscription not found")
+		}
+		if errors.Is(err, storage.ErrVersionConflict) {
+			s.log.Warn("subscription version mismatch", slog.String("op", op), slog.Any("subscription_id", id))
+			return NewPreconditionFailedError("subscription was modified, fetch it again")
+		}
+		s.log.Error("failed to purge subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", id))
+		return NewInternalError("failed to remove subscription")
+	}
+
+	s.log.Info("subscription purged", slog.String("op", op), slog.Any("subscription_id", id))
+	return nil
+}
+
+func (s subscriptionService) PurgeExpiredSubscriptions(ctx context.Context, retention time.Duration) (int64, error) {
+	const op = "internal.service.impl.PurgeExpiredSubscriptions"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op))
+		return 0, err
+	}
+
+	purged, err := s.subscriptionsStorage.PurgeDeletedBefore(ctx, time.Now().UTC().Add(-retention))
+	if err != nil {
+		s.log.Error("failed to purge deleted subscriptions", slog.String("op", op), sl.Err(err))
+		return 0, NewInternalError("failed to purge deleted subscriptions")
+	}
+
+	s.log.Info("deleted subscriptions purged", slog.String("op", op), slog.Int64("count", purged))
+	return purged, nil
+}
+
+func (s subscriptionService) GetSubscriptionHistory(ctx context.Context, id models.SubscriptionID) ([]*models.SubscriptionChange, error) {
+	const op = "internal.service.impl.GetSubscriptionHistory"
+
+	if err := s.authorizeSubscription(ctx, op, id); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.subscriptionsStorage.History(ctx, TenantFromContext(ctx), id)
+	if err != nil {
+		s.log.Error("failed to fetch subscription history", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", id))
+		return nil, NewInternalError("failed to fetch subscription history")
+	}
+
+	if len(changes) == 0 {
+		// subscriptions created before the history was introduced have no entries yet
+		if _, err := s.subscriptionsStorage.FindByID(ctx, TenantFromContext(ctx), id); err != nil {
+			if errors.Is(err, storage.ErrSubscriptionNotFound) {
+				s.log.Warn("subscription not found", slog.String("op", op), slog.Any("subscription_id", id))
+				return nil, NewNotFoundError("subscription not found")
+			}
+			s.log.Error("failed to find subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", id))
+			return nil, NewInternalError("failed to fetch subscription history")
+		}
+	}
+
+	s.log.Info("subscription history fetched", slog.String("op", op), slog.Any("subscription_id", id), slog.Int("count", len(changes)))
+	return changes, nil
+}
+
+func (s subscriptionService) CalculateTotalSubscriptionsPrice(ctx context.Context, a CalculateTotalPriceArgs) (totalSubscriptionsPrice, error) {
+	const op = "internal.service.impl.CalculateTotalSubscriptionsPrice"
+
+	owner, err := scopeOwner(ctx, a.UserID)
+	if err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("user_id", a.UserID))
+		return totalSubscriptionsPrice{}, err
+	}
+	a.UserID = owner
+
+	if a.StartTime != nil && a.EndTime != nil && !a.EndTime.After(*a.StartTime) {
 		s.log.Warn("invalid input: end time must be after start time", slog.String("op", op))
 		return totalSubscriptionsPrice{}, NewInvalidInputError("end time must be after start time")
 	}
+	if a.Currency != nil {
+		if !a.Currency.IsValid() {
+			s.log.Warn("invalid input: unknown currency", slog.String("op", op), slog.Any("currency", *a.Currency))
+			return totalSubscriptionsPrice{}, NewInvalidInputError("currency must be an ISO 4217 code")
+		}
+		if s.exchangeRates == nil {
+			s.log.Warn("invalid input: currency conversion is not configured", slog.String("op", op))
+			return totalSubscriptionsPrice{}, NewInvalidInputError("currency conversion is not configured")
+		}
+	}
+	serviceName, err := filterServiceName(ctx, s.catalog, a.Service)
+	if err != nil {
+		s.log.Error("failed to resolve service name", slog.String("op", op), sl.Err(err))
+		return totalSubscriptionsPrice{}, err
+	}
 
 	f := storage.SubscriptionsFilter{
-		OwnerID:     userID,
+		Tenant:      TenantFromContext(ctx),
+		OwnerID:     a.UserID,
 		ServiceName: serviceName,
-		StartTime:   startTime,
-		EndTime:     endTime,
+		Category:    models.NormalizeCategory(a.Category),
+		StartTime:   a.StartTime,
+		EndTime:     a.EndTime,
+		// subscriptions started before the window are still charged within it
+		Range: storage.RangeActiveDuring,
 	}
 
 	subs, err := s.subscriptionsStorage.Find(ctx, f)
 	if err != nil {
-		s.log.Error("failed to fetch subscriptions", slog.String("op", op), sl.Err(err), slog.Any("owner_id", userID))
+		s.log.Error("failed to fetch subscriptions", slog.String("op", op), sl.Err(err), slog.Any("owner_id", a.UserID))
 		return totalSubscriptionsPrice{}, NewInternalError("failed to calculate total cost")
 	}
 
-	var totalCost int64
+	var windowStart, windowEnd time.Time
+	if a.StartTime != nil {
+		windowStart = *a.StartTime
+	}
+	if a.EndTime != nil {
+		windowEnd = *a.EndTime
+	} else {
+		windowEnd = time.Now().UTC()
+	}
+
+	total := totalSubscriptionsPrice{Subscriptions: make([]subscriptionCost, 0, len(subs))}
+	perCurrency := make(map[models.Currency]int64)
 	for _, sub := range subs {
-		totalCost += sub.PriceRUB
+		charges := sub.ChargesWithin(windowStart, windowEnd)
+		cost := sub.Price.Times(len(charges))
+		if a.Currency != nil {
+			cost, err = s.convertCharges(ctx, sub.Price, charges, *a.Currency)
+			if err != nil {
+				if errors.Is(err, ErrRateNotFound) {
+					s.log.Warn("missing exchange rate", slog.String("op", op), slog.Any("from", sub.Price.Currency), slog.Any("to", *a.Currency))
+					return totalSubscriptionsPrice{}, NewInvalidInputError(fmt.Sprintf("no exchange rate from %s to %s", sub.Price.Currency, *a.Currency))
+				}
+				s.log.Error("failed to convert subscription cost", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", sub.ID))
+				return totalSubscriptionsPrice{}, NewInternalError("failed to calculate total cost")
+			}
+		}
+
+		perCurrency[cost.Currency] += cost.Amount
+		total.Subscriptions = append(total.Subscriptions, subscriptionCost{
+			SubscriptionID: sub.ID,
+			ServiceName:    sub.ServiceName,
+			Price:          sub.Price,
+			BillingPeriod:  sub.Period,
+			BilledPeriods:  len(charges),
+			Cost:           cost,
+		})
+	}
+	if a.Currency != nil && len(perCurrency) == 0 {
+		perCurrency[*a.Currency] = 0
+	}
+
+	total.Totals = make([]models.Money, 0, len(perCurrency))
+	for currency, amount := range perCurrency {
+		total.Totals = append(total.Totals, models.Money{Amount: amount, Currency: currency})
+	}
+	slices.SortFunc(total.Totals, func(a, b models.Money) int {
+		return strings.Compare(string(a.Currency), string(b.Currency))
+	})
+
+	s.log.Info("total cost calculated", slog.String("op", op), slog.Any("owner_id", a.UserID), slog.Any("totals", total.Totals))
+	return total, nil
+}
+
+// convertCharges converts every charge of the price to the target currency using the rate of its billing date.
+func (s subscriptionService) convertCharges(ctx context.Context, price models.Money, charges []time.Time, target models.Currency) (models.Money, error) {
+	cost := models.Money{Currency: target}
+	for _, chargedAt := range charges {
+		rate, err := s.exchangeRates.Rate(ctx, price.Currency, target, chargedAt)
+		if err != nil {
+			return models.Money{}, err
+		}
+		cost.Amount += price.Convert(rate, target).Amount
+	}
+
+	return cost, nil
+}
+
+// authorizeSubscription checks that a caller who is not an admin owns the live subscription.
+func (s subscriptionService) authorizeSubscription(ctx context.Context, op string, id models.SubscriptionID) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return NewUnauthenticatedError("caller is not authenticated")
+	}
+	if p.privileged() {
+		return nil
+	}
+
+	sub, err := s.subscriptionsStorage.FindByID(ctx, TenantFromContext(ctx), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrSubscriptionNotFound) {
+			s.log.Warn("subscription not found", slog.String("op", op), slog.Any("subscription_id", id))
+			return NewNotFoundError("subscription not found")
+		}
+		s.log.Error("failed to find subscription", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", id))
+		return NewInternalError("failed to check access to subscription")
+	}
+	if err := authorizeOwner(ctx, sub.Owner); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("subscription_id", id))
+		return err
+	}
+
+	return nil
+}
+
+// ensureOwnerExists rejects an owner who is not a known user of the tenant.
+func (s subscriptionService) ensureOwnerExists(ctx context.Context, tenant models.TenantID, owner models.PersonID) error {
+	const op = "internal.service.impl.ensureOwnerExists"
+
+	if _, err := s.usersStorage.FindByID(ctx, tenant, owner); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			s.log.Warn("invalid input: unknown owner", slog.String("op", op), slog.Any("user_id", owner))
+			return NewInvalidInputError(fmt.Sprintf("user %s does not exist", owner))
+		}
+		s.log.Error("failed to find owner", slog.String("op", op), sl.Err(err), slog.Any("user_id", owner))
+		return NewInternalError("failed to validate subscription owner")
+	}
+
+	return nil
+}
+
+// useCanonicalServiceName replaces the service name of the subscription with the name of its catalog entry.
+func (s subscriptionService) useCanonicalServiceName(ctx context.Context, sub *models.Subscription) error {
+	svc, err := s.catalog.ResolveServiceName(ctx, sub.ServiceName)
+	if err != nil {
+		return err
+	}
+
+	sub.ServiceName = svc.Name
+	return nil
+}
+
+// checkPriceRange validates the price bounds of a listing, they are in minor units of the
+// returned currency since prices in different currencies do not compare.
+func checkPriceRange(minPrice, maxPrice *int64, currency string) (models.Currency, error) {
+	if minPrice != nil && maxPrice != nil && *minPrice > *maxPrice {
+		return "", NewInvalidInputError("min_price must not be greater than max_price")
+	}
+	if currency == "" {
+		if minPrice != nil || maxPrice != nil {
+			return "", NewInvalidInputError("currency is required with min_price or max_price")
+		}
+		return "", nil
+	}
+	c, err := models.ParseCurrency(currency)
+	if err != nil {
+		return "", NewInvalidInputError(err.Error())
+	}
+
+	return c, nil
+}
+
+// expectedVersion turns an optional precondition into the storage convention where zero matches any version.
+func expectedVersion(ifMatch *int64) int64 {
+	if ifMatch == nil {
+		return 0
 	}
 
-	s.log.Info("total cost calculated", slog.String("op", op), slog.Any("owner_id", userID), slog.Int64("total_cost", totalCost))
-	return totalSubscriptionsPrice{TotalPriceRUB: totalCost}, nil
+	return *ifMatch
 }
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// fakeUsersStorage knows every user.
+type fakeUsersStorage struct {
+	storage.UsersStorage
+}
+
+func (fakeUsersStorage) FindByID(ctx context.Context, tenant models.TenantID, id models.PersonID) (*models.User, error) {
+	return &models.User{ID: id, Tenant: tenant}, nil
+}
+
+// namingCatalog keeps every service name as it is.
+type namingCatalog struct {
+	ServiceCatalog
+}
+
+func (namingCatalog) ResolveServiceName(ctx context.Context, name models.ServiceName) (*models.Service, error) {
+	return &models.Service{Name: name}, nil
+}
+
+func TestUpdateExistingSubscriptionReplacesEveryField(t *testing.T) {
+	stored := &models.Subscription{
+		ID:          uuid.New(),
+		Tenant:      models.DefaultTenant,
+		Owner:       uuid.New(),
+		ServiceName: "Netflix",
+		Price:       models.Money{Amount: 1099, Currency: "USD"},
+		Period:      models.BillingYearly,
+		StartedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Version:     3,
+	}
+	start := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		args       UpdateExistingSubscriptionArgs
+		wantPrice  models.Money
+		wantPeriod models.BillingPeriod
+	}{
+		{
+			name:       "omitted currency and billing period get the defaults",
+			args:       UpdateExistingSubscriptionArgs{Price: 400, PriceInUnits: true},
+			wantPrice:  models.Money{Amount: 40000, Currency: models.DefaultCurrency},
+			wantPeriod: models.BillingMonthly,
+		},
+		{
+			name:       "given currency and billing period",
+			args:       UpdateExistingSubscriptionArgs{Price: 1299, Currency: "EUR", BillingPeriod: models.BillingWeekly},
+			wantPrice:  models.Money{Amount: 1299, Currency: "EUR"},
+			wantPeriod: models.BillingWeekly,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subs := &fakeSubscriptionsStorage{stored: stored}
+			s := NewSubscriptionService(subs, fakeUsersStorage{}, namingCatalog{}, nil, testLogger())
+			ctx := WithPrincipal(context.Background(), Principal{Role: RoleAdmin})
+
+			args := tt.args
+			args.SubscriptionID, args.UserID, args.Service, args.StartTime = stored.ID, stored.Owner, "Spotify", start
+			if _, err := s.UpdateExistingSubscription(ctx, args); err != nil {
+				t.Fatalf("UpdateExistingSubscription() error = %v", err)
+			}
+
+			if subs.updated == nil {
+				t.Fatal("subscription was not updated")
+			}
+			if subs.updated.Price != tt.wantPrice || subs.updated.Period != tt.wantPeriod {
+				t.Errorf("updated price = %+v, period = %q, want %+v and %q", subs.updated.Price, subs.updated.Period, tt.wantPrice, tt.wantPeriod)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ImportFormat string
+
+const (
+	ImportCSV    ImportFormat = "csv"
+	ImportNDJSON ImportFormat = "ndjson"
+)
+
+type ImportMode string
+
+const (
+	// ImportAtomic stores either every record or none of them.
+	ImportAtomic ImportMode = "atomic"
+	// ImportBestEffort stores every valid record on its own.
+	ImportBestEffort ImportMode = "best_effort"
+)
+
+type ImportStatus string
+
+const (
+	ImportAccepted ImportStatus = "accepted"
+	ImportRejected ImportStatus = "rejected"
+	// ImportSkipped marks a valid record left out because the atomic import failed.
+	ImportSkipped ImportStatus = "skipped"
+)
+
+// MaxImportRecords bounds the number of records accepted by one import.
+const MaxImportRecords = 10000
+
+const importDateLayout = time.DateOnly
+
+type ImportSubscriptionsArgs struct {
+	Format ImportFormat
+	Mode   ImportMode
+	Data   io.Reader
+}
+
+type importLine struct {
+	Line           int
+	Status         ImportStatus
+	SubscriptionID *models.SubscriptionID
+	Error          string
+}
+
+type importReport struct {
+	Accepted int
+	Rejected int
+	Lines    []importLine
+}
+
+// importRecord is a single subscription as it appears in an uploaded file. Like in the API, Price is in whole
+// units of the currency and PriceMinor in minor units. A record given both, as exports are, takes PriceMinor
+// once Price matches its whole units.
+type importRecord struct {
+	UserID        string  `json:"user_id"`
+	ServiceName   string  `json:"service_name"`
+	Price         *int64  `json:"price"`
+	PriceMinor    *int64  `json:"price_minor"`
+	Currency      string  `json:"currency"`
+	BillingPeriod string  `json:"billing_period"`
+	StartDate     string  `json:"start_date"`
+	EndDate       *string `json:"end_date"`
+}
+
+// parsedRecord is a record after validation, err is set when it can not be imported.
+type parsedRecord struct {
+	line int
+	sub  *models.Subscription
+	err  error
+}
+
+func (s subscriptionService) ImportSubscriptions(ctx context.Context, a ImportSubscriptionsArgs) (importReport, error) {
+	const op = "internal.service.impl.ImportSubscriptions"
+
+	if a.Mode == "" {
+		a.Mode = ImportAtomic
+	}
+	if a.Mode != ImportAtomic && a.Mode != ImportBestEffort {
+		s.log.Warn("invalid input: unknown import mode", slog.String("op", op), slog.String("mode", string(a.Mode)))
+		return importReport{}, NewInvalidInputError(fmt.Sprintf("unknown import mode %q", a.Mode))
+	}
+
+	var records []parsedRecord
+	var err error
+	switch a.Format {
+	case ImportCSV:
+		records, err = parseCSVImport(a.Data)
+	case ImportNDJSON:
+		records, err = parseNDJSONImport(a.Data)
+	default:
+		s.log.Warn("invalid input: unknown import format", slog.String("op", op), slog.String("format", string(a.Format)))
+		return importReport{}, NewInvalidInputError(fmt.Sprintf("unknown import format %q", a.Format))
+	}
+	if err != nil {
+		s.log.Warn("invalid input: unreadable import", slog.String("op", op), sl.Err(err))
+		return importReport{}, NewInvalidInputError(err.Error())
+	}
+	if len(records) == 0 {
+		s.log.Warn("invalid input: nothing to import", slog.String("op", op))
+		return importReport{}, NewInvalidInputError("import contains no records")
+	}
+
+	tenant := tenantOfNewData(ctx)
+	report := importReport{Lines: make([]importLine, len(records))}
+	valid := make([]int, 0, len(records))
+	for i, r := range records {
+		report.Lines[i] = importLine{Line: r.line}
+		if r.err != nil {
+			report.Lines[i].Status = ImportRejected
+			report.Lines[i].Error = r.err.Error()
+			continue
+		}
+		err := authorizeOwner(ctx, r.sub.Owner)
+		if err == nil {
+			err = s.ensureOwnerExists(ctx, tenant, r.sub.Owner)
+		}
+		if err == nil {
+			err = s.useCanonicalServiceName(ctx, r.sub)
+		}
+		if err != nil {
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) || (svcErr.Code != ErrInvalidInput && svcErr.Code != ErrForbidden) {
+				s.log.Error("failed to validate imported subscription", slog.String("op", op), sl.Err(err), slog.Int("line", r.line))
+				return importReport{}, NewInternalError("failed to import subscriptions")
+			}
+			report.Lines[i].Status = ImportRejected
+			report.Lines[i].Error = svcErr.Message
+			continue
+		}
+		r.sub.Tenant = tenant
+		valid = append(valid, i)
+	}
+
+	if a.Mode == ImportBestEffort {
+		for _, i := range valid {
+			sub := records[i].sub
+			if err := s.subscriptionsStorage.Add(ctx, *sub); err != nil {
+				s.log.Error("failed to add imported subscription", slog.String("op", op), sl.Err(err), slog.Int("line", records[i].line))
+				report.Lines[i].Status = ImportRejected
+				report.Lines[i].Error = "failed to store subscription"
+				continue
+			}
+			report.Lines[i].Status = ImportAccepted
+			report.Lines[i].SubscriptionID = &sub.ID
+		}
+	} else if len(valid) == len(records) {
+		subs := make([]models.Subscription, len(records))
+		for i, r := range records {
+			subs[i] = *r.sub
+		}
+
+		err = s.subscriptionsStorage.AddMany(ctx, subs)
+		if err != nil {
+			var itemErr *storage.BatchItemError
+			if !errors.As(err, &itemErr) {
+				s.log.Error("failed to add imported subscriptions", slog.String("op", op), sl.Err(err))
+				return importReport{}, NewInternalError("failed to import subscriptions")
+			}
+			s.log.Warn("imported subscription was refused", slog.String("op", op), sl.Err(err), slog.Int("line", records[itemErr.Index].line))
+			for i := range report.Lines {
+				report.Lines[i].Status = ImportSkipped
+			}
+			report.Lines[itemErr.Index].Status = ImportRejected
+			report.Lines[itemErr.Index].Error = "failed to store subscription"
+		} else {
+			for i, r := range records {
+				report.Lines[i].Status = ImportAccepted
+				report.Lines[i].SubscriptionID = &r.sub.ID
+			}
+		}
+	} else {
+		for _, i := range valid {
+			report.Lines[i].Status = ImportSkipped
+		}
+	}
+
+	for _, l := range report.Lines {
+		switch l.Status {
+		case ImportAccepted:
+			report.Accepted++
+		case ImportRejected:
+			report.Rejected++
+		}
+	}
+
+	s.log.Info("subscriptions imported", slog.String("op", op), slog.String("mode", string(a.Mode)), slog.Int("accepted", report.Accepted), slog.Int("rejected", report.Rejected))
+	return report, nil
+}
+
+func parseCSVImport(data io.Reader) ([]parsedRecord, error) {
+	r := csv.NewReader(data)
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("can not read csv header: %w", err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"user_id", "service_name", "start_date"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("csv header misses column %q", required)
+		}
+	}
+	_, hasPrice := columns["price"]
+	_, hasPriceMinor := columns["price_minor"]
+	if !hasPrice && !hasPriceMinor {
+		return nil, fmt.Errorf("csv header misses column \"price\" or \"price_minor\"")
+	}
+	r.FieldsPerRecord = len(header)
+
+	var records []parsedRecord
+	for {
+		fields, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if len(records) == MaxImportRecords {
+			return nil, fmt.Errorf("import is limited to %d records", MaxImportRecords)
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			records = append(records, parsedRecord{line: parseErr.Line, err: parseErr.Err})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can not read csv: %w", err)
+		}
+
+		line, _ := r.FieldPos(0)
+		field := func(name string) string {
+			if i, ok := columns[name]; ok {
+				return strings.TrimSpace(fields[i])
+			}
+			return ""
+		}
+
+		rec := importRecord{
+			UserID:        field("user_id"),
+			ServiceName:   field("service_name"),
+			Currency:      field("currency"),
+			BillingPeriod: field("billing_period"),
+			StartDate:     field("start_date"),
+		}
+		if price := field("price"); price != "" {
+			amount, err := strconv.ParseInt(price, 10, 64)
+			if err != nil {
+				records = append(records, parsedRecord{line: line, err: fmt.Errorf("price must be an integer amount of whole units")})
+				continue
+			}
+			rec.Price = &amount
+		}
+		if price := field("price_minor"); price != "" {
+			amount, err := strconv.ParseInt(price, 10, 64)
+			if err != nil {
+				records = append(records, parsedRecord{line: line, err: fmt.Errorf("price_minor must be an integer amount of minor units")})
+				continue
+			}
+			rec.PriceMinor = &amount
+		}
+		if endDate := field("end_date"); endDate != "" {
+			rec.EndDate = &endDate
+		}
+
+		sub, err := rec.toSubscription()
+		records = append(records, parsedRecord{line: line, sub: sub, err: err})
+	}
+
+	return records, nil
+}
+
+func parseNDJSONImport(data io.Reader) ([]parsedRecord, error) {
+	scanner := bufio.NewScanner(data)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var records []parsedRecord
+	line := 0
+	for scanner.Scan() {
+		line++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		if len(records) == MaxImportRecords {
+			return nil, fmt.Errorf("import is limited to %d records", MaxImportRecords)
+		}
+
+		var rec importRecord
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&rec); err != nil {
+			records = append(records, parsedRecord{line: line, err: fmt.Errorf("invalid json: %w", err)})
+			continue
+		}
+
+		sub, err := rec.toSubscription()
+		records = append(records, parsedRecord{line: line, sub: sub, err: err})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("can not read ndjson: %w", err)
+	}
+
+	return records, nil
+}
+
+// toSubscription validates the record the same way a single POST does.
+func (r importRecord) toSubscription() (*models.Subscription, error) {
+	owner, err := uuid.Parse(r.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user_id must be a uuid")
+	}
+	if r.Price == nil && r.PriceMinor == nil {
+		return nil, fmt.Errorf("price or price_minor is required")
+	}
+	startTime, err := time.Parse(importDateLayout, r.StartDate)
+	if err != nil {
+		return nil, fmt.Errorf("start_date must be a date in YYYY-MM-DD format")
+	}
+	var endTime *time.Time
+	if r.EndDate != nil {
+		t, err := time.Parse(importDateLayout, *r.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("end_date must be a date in YYYY-MM-DD format")
+		}
+		endTime = &t
+	}
+
+	currency := models.Currency(strings.ToUpper(r.Currency))
+	var price models.Money
+	if r.PriceMinor != nil {
+		price = models.Money{Amount: *r.PriceMinor, Currency: currency}
+		if currency == "" {
+			price.Currency = models.DefaultCurrency
+		}
+		if r.Price != nil && *r.Price != price.Units() {
+			return nil, fmt.Errorf("price does not match the whole units of price_minor")
+		}
+	} else {
+		price = models.MoneyFromUnits(*r.Price, currency)
+	}
+	return models.NewSubscription(owner, price, r.ServiceName, models.BillingPeriod(r.BillingPeriod), startTime, endTime)
+}
@@ -0,0 +1,58 @@
+package service
+
+import (
+	"effective-mobile/internal/models"
+	"strings"
+	"testing"
+)
+
+func TestParseCSVImportPrice(t *testing.T) {
+	const owner = "60601fee-2bf1-4721-ae6f-7636e79a0cba"
+
+	tests := []struct {
+		name    string
+		header  string
+		values  string
+		want    models.Money
+		wantErr bool
+	}{
+		{name: "whole units", header: "price,currency", values: "400,RUB", want: models.Money{Amount: 40000, Currency: "RUB"}},
+		{name: "minor units", header: "price_minor,currency", values: "39999,USD", want: models.Money{Amount: 39999, Currency: "USD"}},
+		{name: "export", header: "price,price_minor,currency", values: "399,39999,USD", want: models.Money{Amount: 39999, Currency: "USD"}},
+		{name: "price not matching price_minor", header: "price,price_minor,currency", values: "400,39999,USD", wantErr: true},
+		{name: "no price", header: "price,price_minor,currency", values: ",,USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := "user_id,service_name,start_date," + tt.header + "\n" + owner + ",Netflix,2024-03-01," + tt.values + "\n"
+			records, err := parseCSVImport(strings.NewReader(data))
+			if err != nil {
+				t.Fatalf("parseCSVImport() error = %v", err)
+			}
+			if len(records) != 1 {
+				t.Fatalf("parseCSVImport() returned %d records, want 1", len(records))
+			}
+
+			r := records[0]
+			if tt.wantErr {
+				if r.err == nil {
+					t.Errorf("record = %+v, want it rejected", r.sub)
+				}
+				return
+			}
+			if r.err != nil {
+				t.Fatalf("record error = %v", r.err)
+			}
+			if r.sub.Price != tt.want {
+				t.Errorf("price = %+v, want %+v", r.sub.Price, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCSVImportRequiresPriceColumn(t *testing.T) {
+	if _, err := parseCSVImport(strings.NewReader("user_id,service_name,start_date\n")); err == nil {
+		t.Error("parseCSVImport() succeeded, want the missing price column reported")
+	}
+}
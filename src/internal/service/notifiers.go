@@ -0,0 +1,149 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// NewLogNotifier writes renewal reminders to the log instead of delivering them.
+func NewLogNotifier(log *slog.Logger) Notifier {
+	return logNotifier{log: log.With(slog.String("component", "LogNotifier"))}
+}
+
+type logNotifier struct {
+	log *slog.Logger
+}
+
+func (n logNotifier) NotifyRenewal(_ context.Context, r RenewalReminder) error {
+	n.log.Info("subscription renews soon",
+		slog.Any("user_id", r.User.ID),
+		slog.Any("subscription_id", r.SubscriptionID),
+		slog.String("service_name", r.ServiceName),
+		slog.String("amount", r.Amount.String()),
+		slog.String("charge_date", r.ChargeAt.Format(time.DateOnly)),
+	)
+
+	return nil
+}
+
+// SMTPNotifierConfig is the mail server renewal reminders are sent through,
+// authentication is skipped when User is empty.
+type SMTPNotifierConfig struct {
+	Host     string
+	Port     int
+	User     string
+	Password string
+	From     string
+}
+
+// NewSMTPNotifier mails renewal reminders to users, users without an email are skipped.
+func NewSMTPNotifier(cfg SMTPNotifierConfig) (Notifier, error) {
+	if cfg.Host == "" || cfg.From == "" {
+		return nil, fmt.Errorf("smtp notifier requires a host and a sender address")
+	}
+
+	n := smtpNotifier{addr: fmt.Sprintf("%s:%d", cfg.Host, cfg.Port), from: cfg.From}
+	if cfg.User != "" {
+		n.auth = smtp.PlainAuth("", cfg.User, cfg.Password, cfg.Host)
+	}
+
+	return n, nil
+}
+
+type smtpNotifier struct {
+	addr string
+	auth smtp.Auth
+	from string
+}
+
+func (n smtpNotifier) NotifyRenewal(_ context.Context, r RenewalReminder) error {
+	if r.User.Email == "" {
+		return nil
+	}
+
+	msg := strings.Builder{}
+	msg.WriteString(fmt.Sprintf("From: %s\r\n", n.from))
+	msg.WriteString(fmt.Sprintf("To: %s\r\n", r.User.Email))
+	msg.WriteString(fmt.Sprintf("Subject: %s renews on %s\r\n", r.ServiceName, r.ChargeAt.Format(time.DateOnly)))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(fmt.Sprintf("Hello, %s!\r\n\r\nYour subscription to %s will be charged %s on %s.\r\n",
+		r.User.DisplayName, r.ServiceName, r.Amount, r.ChargeAt.Format(time.DateOnly)))
+
+	if err := smtp.SendMail(n.addr, n.auth, n.from, []string{r.User.Email}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("can not send renewal reminder: %w", err)
+	}
+
+	return nil
+}
+
+// NewWebhookNotifier posts renewal reminders as JSON to the given URL with the client,
+// which must time out so an unresponsive endpoint does not stall the reminders job.
+func NewWebhookNotifier(url string, client *http.Client) (Notifier, error) {
+	if url == "" {
+		return nil, fmt.Errorf("webhook notifier requires a url")
+	}
+	if client == nil || client.Timeout <= 0 {
+		return nil, fmt.Errorf("webhook notifier requires a client with a timeout")
+	}
+
+	return webhookNotifier{url: url, client: client}, nil
+}
+
+type webhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+type renewalReminderPayload struct {
+	Event          string `json:"event"`
+	UserID         string `json:"user_id"`
+	Email          string `json:"email,omitempty"`
+	SubscriptionID string `json:"subscription_id"`
+	ServiceName    string `json:"service_name"`
+	Amount         int64  `json:"amount"`
+	Currency       string `json:"currency"`
+	ChargeDate     string `json:"charge_date"`
+}
+
+func (n webhookNotifier) NotifyRenewal(ctx context.Context, r RenewalReminder) error {
+	body, err := json.Marshal(renewalReminderPayload{
+		Event:          "subscription.renewal_reminder",
+		UserID:         r.User.ID.String(),
+		Email:          r.User.Email,
+		SubscriptionID: r.SubscriptionID.String(),
+		ServiceName:    r.ServiceName,
+		Amount:         r.Amount.Amount,
+		Currency:       string(r.Amount.Currency),
+		ChargeDate:     r.ChargeAt.Format(time.DateOnly),
+	})
+	if err != nil {
+		return fmt.Errorf("can not encode renewal reminder: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("can not create renewal reminder request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("can not deliver renewal reminder: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("can not deliver renewal reminder: webhook responded with %s", resp.Status)
+	}
+
+	return nil
+}
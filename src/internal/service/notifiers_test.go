@@ -0,0 +1,197 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"effective-mobile/internal/models"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func testReminder(email string) RenewalReminder {
+	return RenewalReminder{
+		User:           &models.User{ID: uuid.New(), DisplayName: "Ann", Email: email},
+		SubscriptionID: uuid.New(),
+		ServiceName:    "Netflix",
+		Amount:         models.Money{Amount: 79900, Currency: "RUB"},
+		ChargeAt:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		delay   time.Duration
+		wantErr bool
+	}{
+		{name: "delivered", status: http.StatusNoContent},
+		{name: "endpoint fails", status: http.StatusInternalServerError, wantErr: true},
+		{name: "endpoint hangs", status: http.StatusNoContent, delay: time.Second, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payloads := make(chan renewalReminderPayload, 1)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("Content-Type = %q, want application/json", ct)
+				}
+				var p renewalReminderPayload
+				if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+					t.Errorf("can not decode reminder: %v", err)
+				}
+				payloads <- p
+				select {
+				case <-time.After(tt.delay):
+				case <-r.Context().Done():
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			n, err := NewWebhookNotifier(srv.URL, &http.Client{Timeout: 100 * time.Millisecond})
+			if err != nil {
+				t.Fatalf("NewWebhookNotifier() error = %v", err)
+			}
+
+			r := testReminder("ann@example.com")
+			err = n.NotifyRenewal(context.Background(), r)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NotifyRenewal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			want := renewalReminderPayload{
+				Event:          "subscription.renewal_reminder",
+				UserID:         r.User.ID.String(),
+				Email:          "ann@example.com",
+				SubscriptionID: r.SubscriptionID.String(),
+				ServiceName:    "Netflix",
+				Amount:         79900,
+				Currency:       "RUB",
+				ChargeDate:     "2024-03-01",
+			}
+			if got := <-payloads; got != want {
+				t.Errorf("payload = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestWebhookNotifierRequiresTimeout(t *testing.T) {
+	for _, client := range []*http.Client{nil, {}} {
+		if _, err := NewWebhookNotifier("http://localhost", client); err == nil {
+			t.Errorf("NewWebhookNotifier(%v) succeeded, want an error", client)
+		}
+	}
+}
+
+// smtpStandIn accepts one mail per connection and hands its data over.
+func smtpStandIn(t *testing.T) (host string, port int, mails <-chan string) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can not listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	out := make(chan string, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(s string) { conn.Write([]byte(s + "\r\n")) }
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.ToUpper(strings.TrimSpace(line))
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "DATA"):
+				reply("354 go ahead")
+				data := strings.Builder{}
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(l)
+				}
+				out <- data.String()
+				reply("250 queued")
+			case strings.HasPrefix(cmd, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	addr := ln.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, out
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	host, port, mails := smtpStandIn(t)
+	n, err := NewSMTPNotifier(SMTPNotifierConfig{Host: host, Port: port, From: "billing@example.com"})
+	if err != nil {
+		t.Fatalf("NewSMTPNotifier() error = %v", err)
+	}
+
+	if err := n.NotifyRenewal(context.Background(), testReminder("ann@example.com")); err != nil {
+		t.Fatalf("NotifyRenewal() error = %v", err)
+	}
+
+	select {
+	case mail := <-mails:
+		for _, want := range []string{
+			"To: ann@example.com\r\n",
+			"Subject: Netflix renews on 2024-03-01\r\n",
+			"Hello, Ann!",
+		} {
+			if !strings.Contains(mail, want) {
+				t.Errorf("mail %q does not contain %q", mail, want)
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatal("no mail was sent")
+	}
+}
+
+func TestSMTPNotifierSkipsUsersWithoutEmail(t *testing.T) {
+	// nothing listens on the port, so any attempt to send fails
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("can not listen: %v", err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	ln.Close()
+
+	n, err := NewSMTPNotifier(SMTPNotifierConfig{Host: "127.0.0.1", Port: port, From: "billing@example.com"})
+	if err != nil {
+		t.Fatalf("NewSMTPNotifier() error = %v", err)
+	}
+	if err := n.NotifyRenewal(context.Background(), testReminder("")); err != nil {
+		t.Errorf("NotifyRenewal() error = %v, want the user skipped", err)
+	}
+}
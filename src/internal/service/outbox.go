@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OutboxRelayConfig tells how claimed events are published. A relay claims an event for Lease, so another
+// instance takes it over when the relay stops before publishing it. A failed event is published again after
+// InitialBackoff, the pause doubles with every failed attempt up to MaxBackoff. Workers is the number of
+// events of different subscriptions published at the same time.
+type OutboxRelayConfig struct {
+	Lease          time.Duration
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Workers        int
+}
+
+// NewOutboxRelay creates the relay handing lifecycle events stored in the outbox to the publisher.
+// An event is published at least once: it is marked dispatched only after the publisher accepted it.
+func NewOutboxRelay(s storage.OutboxStorage, publisher EventPublisher, cfg OutboxRelayConfig, log *slog.Logger) OutboxRelay {
+	cfg.Workers = max(cfg.Workers, 1)
+	return outboxRelay{
+		outboxStorage: s,
+		publisher:     publisher,
+		cfg:           cfg,
+		log:           log.With(slog.String("component", "OutboxRelay")),
+	}
+}
+
+type outboxRelay struct {
+	outboxStorage storage.OutboxStorage
+	publisher     EventPublisher
+	cfg           OutboxRelayConfig
+	log           *slog.Logger
+}
+
+func (r outboxRelay) RelayEvents(ctx context.Context, batchSize int) (int, error) {
+	const op = "internal.service.outbox.RelayEvents"
+
+	total := 0
+	for ctx.Err() == nil {
+		claimedUntil := time.Now().UTC().Add(r.cfg.Lease)
+		events, err := r.outboxStorage.Claim(ctx, batchSize, claimedUntil)
+		if err != nil {
+			r.log.Error("failed to claim events", slog.String("op", op), sl.Err(err), slog.Int("relayed", total))
+			return total, NewInternalError("failed to relay events")
+		}
+		// failed events are due later, nothing claimed means the rest waits for them
+		if len(events) == 0 {
+			break
+		}
+
+		var relayed atomic.Int64
+		workers := make(chan struct{}, r.cfg.Workers)
+		var wg sync.WaitGroup
+		for _, e := range events {
+			workers <- struct{}{}
+			wg.Add(1)
+			go func() {
+				defer func() {
+					<-workers
+					wg.Done()
+				}()
+				if r.relay(ctx, e, claimedUntil) {
+					relayed.Add(1)
+				}
+			}()
+		}
+		wg.Wait()
+		total += int(relayed.Load())
+	}
+
+	if total > 0 {
+		r.log.Info("events relayed", slog.String("op", op), slog.Int("count", total))
+	}
+	return total, nil
+}
+
+// relay publishes a claimed event and tells whether it was dispatched. Publishing stops with the claim, so
+// no other instance publishes the event at the same time. A failed event is scheduled for another attempt,
+// an event whose outcome is not stored stays claimed until the claim runs out.
+func (r outboxRelay) relay(ctx context.Context, e models.SubscriptionEvent, claimedUntil time.Time) bool {
+	const op = "internal.service.outbox.relay"
+	log := r.log.With(slog.String("op", op), slog.Any("event_id", e.ID), slog.Int("attempt", e.Attempt))
+
+	publishCtx, cancel := context.WithDeadline(ctx, claimedUntil)
+	err := r.publisher.Publish(publishCtx, e)
+	cancel()
+	if ctx.Err() != nil {
+		log.Info("event publishing interrupted")
+		return false
+	}
+
+	if err != nil {
+		at := time.Now().UTC().Add(r.backoff(e.Attempt))
+		log.Warn("event was not published", sl.Err(err), slog.Time("next_attempt_at", at))
+		if err := r.outboxStorage.Retry(ctx, e.ID, at); err != nil {
+			log.Error("failed to schedule event", sl.Err(err))
+		}
+		return false
+	}
+
+	if err := r.outboxStorage.MarkDispatched(ctx, e.ID); err != nil {
+		log.Error("failed to mark event dispatched", sl.Err(err))
+		return false
+	}
+	return true
+}
+
+// backoff is the pause after the given failed attempt.
+func (r outboxRelay) backoff(attempt int) time.Duration {
+	pause := r.cfg.InitialBackoff
+	for i := 1; i < attempt && pause < r.cfg.MaxBackoff; i++ {
+		pause *= 2
+	}
+	return min(pause, r.cfg.MaxBackoff)
+}
+
+func (r outboxRelay) PurgeDispatchedEvents(ctx context.Context, retention time.Duration) (int64, error) {
+	const op = "internal.service.outbox.PurgeDispatchedEvents"
+
+	purged, err := r.outboxStorage.PurgeDispatchedBefore(ctx, time.Now().UTC().Add(-retention))
+	if err != nil {
+		r.log.Error("failed to purge dispatched events", slog.String("op", op), sl.Err(err))
+		return 0, NewInternalError("failed to purge dispatched events")
+	}
+
+	r.log.Info("dispatched events purged", slog.String("op", op), slog.Int64("count", purged))
+	return purged, nil
+}
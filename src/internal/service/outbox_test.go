@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type outboxEntry struct {
+	event         models.SubscriptionEvent
+	dispatched    bool
+	attempts      int
+	nextAttemptAt time.Time
+}
+
+// fakeOutboxStorage claims the oldest pending event of every subscription like the database does.
+type fakeOutboxStorage struct {
+	storage.OutboxStorage
+
+	mu      sync.Mutex
+	entries []*outboxEntry
+}
+
+func (s *fakeOutboxStorage) Claim(_ context.Context, limit int, claimedUntil time.Time) ([]models.SubscriptionEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	waiting := make(map[models.SubscriptionID]bool)
+	var claimed []models.SubscriptionEvent
+	for _, e := range s.entries {
+		if e.dispatched || len(claimed) == limit {
+			continue
+		}
+		if !waiting[e.event.SubscriptionID] && !e.nextAttemptAt.After(now) {
+			e.nextAttemptAt = claimedUntil
+			ev := e.event
+			ev.Attempt = e.attempts + 1
+			claimed = append(claimed, ev)
+		}
+		waiting[e.event.SubscriptionID] = true
+	}
+
+	return claimed, nil
+}
+
+func (s *fakeOutboxStorage) MarkDispatched(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.entry(id).dispatched = true
+	return nil
+}
+
+func (s *fakeOutboxStorage) Retry(_ context.Context, id uuid.UUID, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e := s.entry(id)
+	e.attempts++
+	e.nextAttemptAt = at
+	return nil
+}
+
+func (s *fakeOutboxStorage) entry(id uuid.UUID) *outboxEntry {
+	for _, e := range s.entries {
+		if e.event.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// fakePublisher fails the events of the listed subscriptions.
+type fakePublisher struct {
+	failing map[models.SubscriptionID]bool
+
+	mu        sync.Mutex
+	published []uuid.UUID
+}
+
+func (p *fakePublisher) Publish(_ context.Context, e models.SubscriptionEvent) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.published = append(p.published, e.ID)
+	if p.failing[e.SubscriptionID] {
+		return errors.New("webhook responded with 503 Service Unavailable")
+	}
+	return nil
+}
+
+func TestOutboxRelayFailingEventHoldsUpOnlyItsSubscription(t *testing.T) {
+	failing, healthy := uuid.New(), uuid.New()
+	event := func(sub models.SubscriptionID) *outboxEntry {
+		return &outboxEntry{event: models.SubscriptionEvent{ID: uuid.New(), Type: models.EventSubscriptionUpdated, Tenant: "acme", SubscriptionID: sub}}
+	}
+	outbox := &fakeOutboxStorage{entries: []*outboxEntry{event(failing), event(healthy), event(failing), event(healthy)}}
+	publisher := &fakePublisher{failing: map[models.SubscriptionID]bool{failing: true}}
+	relay := NewOutboxRelay(outbox, publisher, OutboxRelayConfig{Lease: time.Minute, InitialBackoff: time.Hour, MaxBackoff: time.Hour, Workers: 2}, testLogger())
+
+	before := time.Now()
+	n, err := relay.RelayEvents(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("RelayEvents() error = %v", err)
+	}
+
+	if n != 2 {
+		t.Errorf("RelayEvents() = %d, want both events of the healthy subscription", n)
+	}
+	first, later := outbox.entries[0], outbox.entries[2]
+	if first.dispatched || first.attempts != 1 || first.nextAttemptAt.Before(before.Add(time.Hour)) {
+		t.Errorf("failed event = %+v, want it retried after an hour", first)
+	}
+	for _, id := range publisher.published {
+		if id == later.event.ID {
+			t.Error("event published before the failed event of its subscription")
+		}
+	}
+	if len(publisher.published) != 3 {
+		t.Errorf("published %d events, want 3", len(publisher.published))
+	}
+}
+
+func TestOutboxRelayBackoff(t *testing.T) {
+	relay := NewOutboxRelay(&fakeOutboxStorage{}, &fakePublisher{}, OutboxRelayConfig{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}, testLogger()).(outboxRelay)
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 4, want: 5 * time.Second},
+		{attempt: 100, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := relay.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %s, want %s", tt.attempt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+
+	"github.com/google/uuid"
+)
+
+type Role string
+
+const (
+	// RoleUser may only act on their own subscriptions and user account.
+	RoleUser Role = "user"
+	// RoleAdmin may act on behalf of any user and manage shared resources.
+	RoleAdmin Role = "admin"
+	// RoleOperator runs the platform, it has the rights of an admin in whichever tenant it requests.
+	RoleOperator Role = "operator"
+	// RoleSystem is the role of internal callers such as background jobs, API callers can not take it.
+	RoleSystem Role = "system"
+)
+
+// IsValid reports whether API callers may take the role.
+func (r Role) IsValid() bool {
+	return r == RoleUser || r == RoleAdmin || r == RoleOperator
+}
+
+// Principal is the authenticated caller the services act for.
+type Principal struct {
+	UserID models.PersonID
+	Role   Role
+}
+
+// SystemPrincipal is the principal internal callers such as background jobs act as.
+var SystemPrincipal = Principal{Role: RoleSystem}
+
+// privileged reports whether the principal may act on anyone's data.
+func (p Principal) privileged() bool {
+	return p.Role == RoleAdmin || p.Role == RoleOperator || p.Role == RoleSystem
+}
+
+type principalKey struct{}
+
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+// PrincipalFromContext returns the caller of the request. Calls without a principal are denied,
+// internal callers act as SystemPrincipal.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(Principal)
+	return p, ok
+}
+
+// authorizeOwner lets admins act on anyone's data and regular users only on their own.
+func authorizeOwner(ctx context.Context, owner models.PersonID) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return NewUnauthenticatedError("caller is not authenticated")
+	}
+	if p.privileged() || p.UserID == owner {
+		return nil
+	}
+
+	return NewForbiddenError("access to data of another user is denied")
+}
+
+// authorizeAdmin rejects callers who are not admins.
+func authorizeAdmin(ctx context.Context) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return NewUnauthenticatedError("caller is not authenticated")
+	}
+	if p.privileged() {
+		return nil
+	}
+
+	return NewForbiddenError("admin role is required")
+}
+
+// scopeOwner narrows an owner filter to the caller for regular users, an empty filter becomes the caller's id.
+func scopeOwner(ctx context.Context, owner models.PersonID) (models.PersonID, error) {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return owner, NewUnauthenticatedError("caller is not authenticated")
+	}
+	if p.privileged() {
+		return owner, nil
+	}
+	if owner == uuid.Nil {
+		return p.UserID, nil
+	}
+	if owner != p.UserID {
+		return owner, NewForbiddenError("access to data of another user is denied")
+	}
+
+	return owner, nil
+}
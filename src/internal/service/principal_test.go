@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestAuthorization(t *testing.T) {
+	owner, other := uuid.New(), uuid.New()
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		wantOwner ErrorCode
+		wantAdmin ErrorCode
+		wantScope ErrorCode
+	}{
+		{
+			name:      "no principal",
+			ctx:       context.Background(),
+			wantOwner: ErrUnauthenticated,
+			wantAdmin: ErrUnauthenticated,
+			wantScope: ErrUnauthenticated,
+		},
+		{name: "system", ctx: WithPrincipal(context.Background(), SystemPrincipal)},
+		{name: "admin", ctx: WithPrincipal(context.Background(), Principal{UserID: other, Role: RoleAdmin})},
+		{name: "operator", ctx: WithPrincipal(context.Background(), Principal{UserID: other, Role: RoleOperator})},
+		{
+			name:      "owner",
+			ctx:       WithPrincipal(context.Background(), Principal{UserID: owner, Role: RoleUser}),
+			wantAdmin: ErrForbidden,
+		},
+		{
+			name:      "other user",
+			ctx:       WithPrincipal(context.Background(), Principal{UserID: other, Role: RoleUser}),
+			wantOwner: ErrForbidden,
+			wantAdmin: ErrForbidden,
+			wantScope: ErrForbidden,
+		},
+	}
+
+	check := func(t *testing.T, fn string, err error, want ErrorCode) {
+		t.Helper()
+
+		var svcErr *ServiceError
+		switch {
+		case want == "" && err != nil:
+			t.Errorf("%s() error = %v", fn, err)
+		case want != "" && (!errors.As(err, &svcErr) || svcErr.Code != want):
+			t.Errorf("%s() error = %v, want %s", fn, err, want)
+		}
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check(t, "authorizeOwner", authorizeOwner(tt.ctx, owner), tt.wantOwner)
+			check(t, "authorizeAdmin", authorizeAdmin(tt.ctx), tt.wantAdmin)
+			_, err := scopeOwner(tt.ctx, owner)
+			check(t, "scopeOwner", err, tt.wantScope)
+		})
+	}
+}
+
+func TestSystemRoleIsNotValidForCallers(t *testing.T) {
+	if RoleSystem.IsValid() {
+		t.Error("RoleSystem.IsValid() = true, want API callers unable to take it")
+	}
+}
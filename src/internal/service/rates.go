@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ilyakaznacheev/cleanenv"
+)
+
+// ErrRateNotFound is returned by ExchangeRates when a currency pair can not be converted.
+var ErrRateNotFound = errors.New("exchange rate not found")
+
+// NewFileExchangeRates loads fixed rates from a YAML file of the following shape:
+//
+//	base: RUB
+//	rates:
+//	  USD: 92.5
+//	  EUR: 100.1
+//
+// where every rate is the price of one unit of the currency in the base currency.
+// The rates do not change over time, so the date passed to Rate is ignored.
+func NewFileExchangeRates(path string) (ExchangeRates, error) {
+	var f struct {
+		Base  models.Currency             `yaml:"base" env-required:"true"`
+		Rates map[models.Currency]float64 `yaml:"rates"`
+	}
+	if err := cleanenv.ReadConfig(path, &f); err != nil {
+		return nil, fmt.Errorf("can not read exchange rates: %w", err)
+	}
+
+	if !f.Base.IsValid() {
+		return nil, fmt.Errorf("can not read exchange rates: invalid base currency %q", f.Base)
+	}
+
+	rates := fileExchangeRates{f.Base: 1}
+	for currency, rate := range f.Rates {
+		if !currency.IsValid() || rate <= 0 {
+			return nil, fmt.Errorf("can not read exchange rates: invalid rate for %q", currency)
+		}
+		rates[currency] = rate
+	}
+
+	return rates, nil
+}
+
+type fileExchangeRates map[models.Currency]float64
+
+func (r fileExchangeRates) Rate(_ context.Context, from, to models.Currency, _ time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	fromRate, ok := r[from]
+	if !ok {
+		return 0, ErrRateNotFound
+	}
+	toRate, ok := r[to]
+	if !ok {
+		return 0, ErrRateNotFound
+	}
+
+	return fromRate / toRate, nil
+}
+
+// NewStorageExchangeRates returns ExchangeRates backed by historical daily rates kept in storage.
+// When only the reverse pair is stored, its inverse is used.
+func NewStorageExchangeRates(s storage.ExchangeRatesStorage) ExchangeRates {
+	return storageExchangeRates{ratesStorage: s}
+}
+
+type storageExchangeRates struct {
+	ratesStorage storage.ExchangeRatesStorage
+}
+
+func (r storageExchangeRates) Rate(ctx context.Context, from, to models.Currency, on time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	rate, err := r.ratesStorage.FindRate(ctx, from, to, on)
+	if err == nil {
+		return rate, nil
+	}
+	if !errors.Is(err, storage.ErrExchangeRateNotFound) {
+		return 0, err
+	}
+
+	rate, err = r.ratesStorage.FindRate(ctx, to, from, on)
+	if err != nil {
+		if errors.Is(err, storage.ErrExchangeRateNotFound) {
+			return 0, ErrRateNotFound
+		}
+		return 0, err
+	}
+
+	return 1 / rate, nil
+}
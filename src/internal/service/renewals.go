@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+const (
+	DefaultUpcomingDays = 30
+	MaxUpcomingDays     = 366
+)
+
+// NewRenewalService creates the service predicting charges of active subscriptions and reminding users of them.
+func NewRenewalService(subs storage.SubscriptionsStorage, users storage.UsersStorage, reminders storage.RemindersStorage, notifier Notifier, log *slog.Logger) RenewalService {
+	return renewalService{
+		subscriptionsStorage: subs,
+		usersStorage:         users,
+		remindersStorage:     reminders,
+		notifier:             notifier,
+		log:                  log.With(slog.String("component", "RenewalService")),
+	}
+}
+
+type renewalService struct {
+	subscriptionsStorage storage.SubscriptionsStorage
+	usersStorage         storage.UsersStorage
+	remindersStorage     storage.RemindersStorage
+	notifier             Notifier
+	log                  *slog.Logger
+}
+
+type upcomingCharge struct {
+	Tenant         models.TenantID
+	SubscriptionID models.SubscriptionID
+	Owner          models.PersonID
+	ServiceName    models.ServiceName
+	Amount         models.Money
+	BillingPeriod  models.BillingPeriod
+	ChargeAt       time.Time
+}
+
+// RenewalReminder tells a user that one of their subscriptions is about to be charged.
+type RenewalReminder struct {
+	User           *models.User
+	SubscriptionID models.SubscriptionID
+	ServiceName    models.ServiceName
+	Amount         models.Money
+	ChargeAt       time.Time
+}
+
+func (r renewalService) GetUpcomingCharges(ctx context.Context, userID models.PersonID, days int) ([]upcomingCharge, error) {
+	const op = "internal.service.renewals.GetUpcomingCharges"
+
+	if err := authorizeOwner(ctx, userID); err != nil {
+		r.log.Warn("access denied", slog.String("op", op), slog.Any("user_id", userID))
+		return nil, err
+	}
+
+	if days == 0 {
+		days = DefaultUpcomingDays
+	}
+	if days < 0 || days > MaxUpcomingDays {
+		r.log.Warn("invalid input: days out of range", slog.String("op", op), slog.Int("days", days))
+		return nil, NewInvalidInputError(fmt.Sprintf("days must be between 1 and %d", MaxUpcomingDays))
+	}
+
+	u, err := r.usersStorage.FindByID(ctx, TenantFromContext(ctx), userID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			r.log.Warn("user not found", slog.String("op", op), slog.Any("user_id", userID))
+			return nil, NewNotFoundError("user not found")
+		}
+		r.log.Error("failed to find user", slog.String("op", op), sl.Err(err), slog.Any("user_id", userID))
+		return nil, NewInternalError("failed to fetch upcoming charges")
+	}
+
+	// charge dates carry no time of day, so the window starts on the date it is today for the user
+	from := today(time.Now().In(u.Location()))
+	charges, err := r.upcomingCharges(ctx, storage.SubscriptionsFilter{Tenant: TenantFromContext(ctx), OwnerID: userID}, from, from.AddDate(0, 0, days))
+	if err != nil {
+		r.log.Error("failed to fetch subscriptions", slog.String("op", op), sl.Err(err), slog.Any("user_id", userID))
+		return nil, NewInternalError("failed to fetch upcoming charges")
+	}
+
+	r.log.Info("upcoming charges fetched", slog.String("op", op), slog.Any("user_id", userID), slog.Int("count", len(charges)))
+	return charges, nil
+}
+
+func (r renewalService) SendRenewalReminders(ctx context.Context, daysBefore int) (int, error) {
+	const op = "internal.service.renewals.SendRenewalReminders"
+
+	from := today(time.Now().UTC())
+	charges, err := r.upcomingCharges(ctx, storage.SubscriptionsFilter{}, from, from.AddDate(0, 0, daysBefore))
+	if err != nil {
+		r.log.Error("failed to fetch subscriptions", slog.String("op", op), sl.Err(err))
+		return 0, NewInternalError("failed to send renewal reminders")
+	}
+
+	users := make(map[models.PersonID]*models.User)
+	sent, failed := 0, 0
+	for _, c := range charges {
+		claimed, err := r.remindersStorage.Claim(ctx, c.Tenant, c.SubscriptionID, c.ChargeAt)
+		if err != nil {
+			r.log.Error("failed to claim reminder", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", c.SubscriptionID))
+			failed++
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		u, ok := users[c.Owner]
+		if !ok {
+			u, err = r.usersStorage.FindByID(ctx, c.Tenant, c.Owner)
+			if err == nil {
+				users[c.Owner] = u
+			}
+		}
+		if err == nil {
+			err = r.notifier.NotifyRenewal(ctx, RenewalReminder{
+				User:           u,
+				SubscriptionID: c.SubscriptionID,
+				ServiceName:    c.ServiceName,
+				Amount:         c.Amount,
+				ChargeAt:       c.ChargeAt,
+			})
+		}
+		if err != nil {
+			r.log.Error("failed to send reminder", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", c.SubscriptionID))
+			failed++
+			// the reminder is retried on the next run
+			if err := r.remindersStorage.Release(ctx, c.Tenant, c.SubscriptionID, c.ChargeAt); err != nil {
+				r.log.Error("failed to release reminder", slog.String("op", op), sl.Err(err), slog.Any("subscription_id", c.SubscriptionID))
+			}
+			continue
+		}
+		sent++
+	}
+
+	if failed > 0 {
+		return sent, NewInternalError(fmt.Sprintf("failed to send %d renewal reminders", failed))
+	}
+
+	r.log.Info("renewal reminders sent", slog.String("op", op), slog.Int("count", sent))
+	return sent, nil
+}
+
+// upcomingCharges returns the charges of subscriptions matching the filter within the [from, to] window, soonest first.
+func (r renewalService) upcomingCharges(ctx context.Context, f storage.SubscriptionsFilter, from, to time.Time) ([]upcomingCharge, error) {
+	f.Range = storage.RangeActiveDuring
+	f.StartTime = &from
+	f.EndTime = &to
+
+	var charges []upcomingCharge
+	err := r.subscriptionsStorage.Stream(ctx, f, func(sub *models.Subscription) error {
+		for _, chargeAt := range sub.ChargeDatesBetween(from, to) {
+			charges = append(charges, upcomingCharge{
+				Tenant:         sub.Tenant,
+				SubscriptionID: sub.ID,
+				Owner:          sub.Owner,
+				ServiceName:    sub.ServiceName,
+				Amount:         sub.Price,
+				BillingPeriod:  sub.Period,
+				ChargeAt:       chargeAt,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(charges, func(a, b upcomingCharge) int {
+		return a.ChargeAt.Compare(b.ChargeAt)
+	})
+
+	return charges, nil
+}
+
+// today returns the calendar date of t as midnight UTC, the way subscription dates are stored.
+func today(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -13,6 +13,14 @@ const (
 	ErrInvalidInput ErrorCode = "invalid_input"
 	ErrNotFound     ErrorCode = "not_found"
 	ErrInternal     ErrorCode = "internal"
+	// ErrPreconditionFailed means the resource changed since the version the caller expected.
+	ErrPreconditionFailed ErrorCode = "precondition_failed"
+	// ErrConflict means the change clashes with the state of another resource.
+	ErrConflict ErrorCode = "conflict"
+	// ErrForbidden means the caller is not allowed to act on the resource.
+	ErrForbidden ErrorCode = "forbidden"
+	// ErrUnauthenticated means the presented credentials are unknown, revoked or expired.
+	ErrUnauthenticated ErrorCode = "unauthenticated"
 )
 
 type ServiceError struct {
@@ -36,12 +44,32 @@ func NewInternalError(message string) *ServiceError {
 	return &ServiceError{Code: ErrInternal, Message: message}
 }
 
+func NewPreconditionFailedError(message string) *ServiceError {
+	return &ServiceError{Code: ErrPreconditionFailed, Message: message}
+}
+
+func NewConflictError(message string) *ServiceError {
+	return &ServiceError{Code: ErrConflict, Message: message}
+}
+
+func NewForbiddenError(message string) *ServiceError {
+	return &ServiceError{Code: ErrForbidden, Message: message}
+}
+
+func NewUnauthenticatedError(message string) *ServiceError {
+	return &ServiceError{Code: ErrUnauthenticated, Message: message}
+}
+
 type CreateNewSubscriptionArgs struct {
 	UserID    models.PersonID
 	Service   models.ServiceName
 	StartTime time.Time
 	EndTime   *time.Time
-	PriceRUB  int64
+	Price     int64
+	// PriceInUnits tells that Price is in whole units of the currency rather than in minor units.
+	PriceInUnits  bool
+	Currency      models.Currency
+	BillingPeriod models.BillingPeriod
 }
 
 type UpdateExistingSubscriptionArgs struct {
@@ -50,18 +78,209 @@ type UpdateExistingSubscriptionArgs struct {
 	Service   models.ServiceName
 	StartTime time.Time
 	EndTime   *time.Time
-	PriceRUB  int64
+	Price     int64
+	// PriceInUnits tells that Price is in whole units of the currency rather than in minor units.
+	PriceInUnits bool
+	// Currency and BillingPeriod replace the stored ones too, empty ones get the defaults of a new subscription.
+	Currency      models.Currency
+	BillingPeriod models.BillingPeriod
+	// IfMatch rejects the update unless the subscription still has this version.
+	IfMatch *int64
+}
+
+// PatchSubscriptionArgs carries a partial update, nil fields are left as they are.
+type PatchSubscriptionArgs struct {
+	models.SubscriptionID
+	UserID    *models.PersonID
+	Service   *models.ServiceName
+	StartTime *time.Time
+	EndTime   *time.Time
+	// ClearEndTime makes the subscription open-ended, it takes precedence over EndTime.
+	ClearEndTime bool
+	Price        *int64
+	// PriceInUnits tells that Price is in whole units of the currency rather than in minor units.
+	PriceInUnits  bool
+	Currency      *models.Currency
+	BillingPeriod *models.BillingPeriod
+	// IfMatch rejects the update unless the subscription still has this version.
+	IfMatch *int64
+}
+
+type SubscriptionsOrder string
+
+const (
+	OrderByID            SubscriptionsOrder = "id"
+	OrderByStartDate     SubscriptionsOrder = "start_date"
+	OrderByStartDateDesc SubscriptionsOrder = "-start_date"
+	OrderByPrice         SubscriptionsOrder = "price"
+	OrderByPriceDesc     SubscriptionsOrder = "-price"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+type ListSubscriptionsArgs struct {
+	UserID   models.PersonID
+	Service  models.ServiceName
+	Category string
+	ActiveAt *time.Time
+	MinPrice *int64
+	MaxPrice *int64
+	// Currency restricts the list to one currency, it is required with a price bound.
+	Currency string
+	Order    SubscriptionsOrder
+	// Cursor is the next_cursor of the previous page, empty for the first page.
+	Cursor string
+	Limit  int
+}
+
+type subscriptionsPage struct {
+	Subscriptions []*models.Subscription
+	// NextCursor is nil on the last page.
+	NextCursor *string
+}
+
+// CalculateTotalPriceArgs selects the subscriptions to sum up, an empty UserID or Service matches any.
+type CalculateTotalPriceArgs struct {
+	UserID    models.PersonID
+	Service   models.ServiceName
+	Category  string
+	StartTime *time.Time
+	EndTime   *time.Time
+	// Currency converts every charge to the given currency when set,
+	// otherwise totals are grouped per subscription currency.
+	Currency *models.Currency
+}
+
+type subscriptionCost struct {
+	SubscriptionID models.SubscriptionID
+	ServiceName    models.ServiceName
+	Price          models.Money
+	BillingPeriod  models.BillingPeriod
+	BilledPeriods  int
+	Cost           models.Money
 }
 
 type totalSubscriptionsPrice struct {
-	TotalPriceRUB int64
+	// Totals holds one sum per currency, ordered by currency code.
+	Totals        []models.Money
+	Subscriptions []subscriptionCost
 }
 
 type SubscriptionService interface {
 	CreateNewSubscription(ctx context.Context, c CreateNewSubscriptionArgs) (*models.Subscription, error)
 	UpdateExistingSubscription(ctx context.Context, u UpdateExistingSubscriptionArgs) (*models.Subscription, error)
+	// ImportSubscriptions validates and stores subscriptions read from an uploaded file, reporting on every record.
+	ImportSubscriptions(ctx context.Context, a ImportSubscriptionsArgs) (importReport, error)
+	// ExportSubscriptions validates the filters and prepares a streamed export of the matching subscriptions.
+	ExportSubscriptions(ctx context.Context, a ExportSubscriptionsArgs) (subscriptionsExport, error)
+	// PatchSubscription changes only the fields set in the arguments.
+	PatchSubscription(ctx context.Context, p PatchSubscriptionArgs) (*models.Subscription, error)
 	FindSubscriptionByID(ctx context.Context, id models.SubscriptionID) (*models.Subscription, error)
-	GetSubscriptions(ctx context.Context) []*models.Subscription
-	CalculateTotalSubscriptionsPrice(ctx context.Context, userID models.PersonID, serviceName models.ServiceName, startTime *time.Time, endTime *time.Time) (totalSubscriptionsPrice, error)
-	RemoveExistingSubscription(ctx context.Context, id models.SubscriptionID) error
+	GetSubscriptions(ctx context.Context, l ListSubscriptionsArgs) (subscriptionsPage, error)
+	CalculateTotalSubscriptionsPrice(ctx context.Context, a CalculateTotalPriceArgs) (totalSubscriptionsPrice, error)
+	// RemoveExistingSubscription soft-deletes a subscription, ifMatch makes it conditional on the current version.
+	RemoveExistingSubscription(ctx context.Context, id models.SubscriptionID, ifMatch *int64) error
+	GetDeletedSubscriptions(ctx context.Context, l ListSubscriptionsArgs) (subscriptionsPage, error)
+	RestoreSubscription(ctx context.Context, id models.SubscriptionID) (*models.Subscription, error)
+	GetSubscriptionHistory(ctx context.Context, id models.SubscriptionID) ([]*models.SubscriptionChange, error)
+	// PurgeSubscription physically removes a subscription, deleted or not.
+	PurgeSubscription(ctx context.Context, id models.SubscriptionID, ifMatch *int64) error
+	// PurgeExpiredSubscriptions physically removes subscriptions soft-deleted longer than retention ago.
+	PurgeExpiredSubscriptions(ctx context.Context, retention time.Duration) (int64, error)
+}
+
+// ExchangeRates provides conversion rates between currencies.
+type ExchangeRates interface {
+	// Rate returns how many units of the target currency one unit of the source currency was worth on the given date.
+	Rate(ctx context.Context, from, to models.Currency, on time.Time) (float64, error)
+}
+
+type AnalyticsService interface {
+	// GetSpending sums the charges within the window per currency and the requested dimensions.
+	GetSpending(ctx context.Context, a SpendingArgs) (spendingReport, error)
+}
+
+type ServiceCatalog interface {
+	CreateService(ctx context.Context, a CreateServiceArgs) (*models.Service, error)
+	// UpdateService replaces the name, aliases, default price and category of the service.
+	UpdateService(ctx context.Context, a UpdateServiceArgs) (*models.Service, error)
+	RemoveService(ctx context.Context, id models.ServiceID) error
+	FindServiceByID(ctx context.Context, id models.ServiceID) (*models.Service, error)
+	GetServices(ctx context.Context) ([]*models.Service, error)
+	// FindServiceByName looks the service up by its name or an alias without adding unknown names.
+	FindServiceByName(ctx context.Context, name models.ServiceName) (*models.Service, error)
+	// ResolveServiceName finds the catalog entry whose name or alias matches the given spelling,
+	// a missing entry is created or rejected according to the configured policy.
+	ResolveServiceName(ctx context.Context, name models.ServiceName) (*models.Service, error)
+}
+
+type ServiceCategories interface {
+	// SetServiceCategory assigns the category to the catalog service the name resolves to,
+	// a name missing from the catalog is reported as not found.
+	SetServiceCategory(ctx context.Context, service models.ServiceName, category string) (*models.ServiceCategory, error)
+	RemoveServiceCategory(ctx context.Context, service models.ServiceName) error
+	FindServiceCategory(ctx context.Context, service models.ServiceName) (*models.ServiceCategory, error)
+	GetServiceCategories(ctx context.Context) ([]*models.ServiceCategory, error)
+}
+
+type UserService interface {
+	CreateUser(ctx context.Context, a CreateUserArgs) (*models.User, error)
+	// UpdateUser replaces the display name, email and timezone of the user.
+	UpdateUser(ctx context.Context, a UpdateUserArgs) (*models.User, error)
+	// RemoveUser fails with a conflict while the user owns any subscription, deleted ones included.
+	RemoveUser(ctx context.Context, id models.PersonID) error
+	FindUserByID(ctx context.Context, id models.PersonID) (*models.User, error)
+	GetUsers(ctx context.Context, l ListUsersArgs) (usersPage, error)
+}
+
+type RenewalService interface {
+	// GetUpcomingCharges lists the charges of the user's subscriptions due within the given number of days, soonest first.
+	GetUpcomingCharges(ctx context.Context, userID models.PersonID, days int) ([]upcomingCharge, error)
+	// SendRenewalReminders notifies users of every charge due within daysBefore days they were not reminded of yet.
+	SendRenewalReminders(ctx context.Context, daysBefore int) (int, error)
+}
+
+// Notifier delivers renewal reminders to users.
+type Notifier interface {
+	NotifyRenewal(ctx context.Context, r RenewalReminder) error
+}
+
+type WebhookService interface {
+	CreateWebhook(ctx context.Context, a CreateWebhookArgs) (*models.Webhook, error)
+	// UpdateWebhook replaces the URL, secret and event types of the webhook.
+	UpdateWebhook(ctx context.Context, a UpdateWebhookArgs) (*models.Webhook, error)
+	RemoveWebhook(ctx context.Context, id models.WebhookID) error
+	FindWebhookByID(ctx context.Context, id models.WebhookID) (*models.Webhook, error)
+	GetWebhooks(ctx context.Context) ([]*models.Webhook, error)
+	// GetDeadLetters lists the events that could not be delivered to the webhook, the latest first.
+	GetDeadLetters(ctx context.Context, id models.WebhookID) ([]*models.DeadLetter, error)
+}
+
+type APIKeyService interface {
+	// IssueAPIKey creates a key for the user, the key string is returned only once.
+	IssueAPIKey(ctx context.Context, a IssueAPIKeyArgs) (issuedAPIKey, error)
+	// RevokeAPIKey stops the key from authenticating, the key stays listed.
+	RevokeAPIKey(ctx context.Context, id models.APIKeyID) error
+	FindAPIKeyByID(ctx context.Context, id models.APIKeyID) (*models.APIKey, error)
+	GetAPIKeys(ctx context.Context) ([]*models.APIKey, error)
+	// AuthenticateAPIKey returns the active key matching the key string and records its use,
+	// unknown, revoked and expired keys fail with ErrUnauthenticated.
+	AuthenticateAPIKey(ctx context.Context, key string) (*models.APIKey, error)
+}
+
+// EventPublisher hands subscription lifecycle events to downstream consumers.
+type EventPublisher interface {
+	// Publish returns once the event is handled for good, an error leaves it to be published again.
+	Publish(ctx context.Context, e models.SubscriptionEvent) error
+}
+
+type OutboxRelay interface {
+	// RelayEvents publishes due outbox events in batches of batchSize until none is left, failed events are
+	// scheduled for a later run.
+	RelayEvents(ctx context.Context, batchSize int) (int, error)
+	// PurgeDispatchedEvents removes events dispatched longer than retention ago.
+	PurgeDispatchedEvents(ctx context.Context, retention time.Duration) (int64, error)
 }
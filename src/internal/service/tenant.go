@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+)
+
+type tenantKey struct{}
+
+// WithTenant scopes every subscription read and write made with the context to the tenant.
+func WithTenant(ctx context.Context, t models.TenantID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, t)
+}
+
+// TenantFromContext returns the tenant of the request. Calls without a tenant come from
+// trusted internal callers such as background jobs and see the data of every tenant.
+func TenantFromContext(ctx context.Context) models.TenantID {
+	t, _ := ctx.Value(tenantKey{}).(models.TenantID)
+	return t
+}
+
+// tenantOfNewData returns the tenant new subscriptions created with the context belong to.
+func tenantOfNewData(ctx context.Context) models.TenantID {
+	if t := TenantFromContext(ctx); t != "" {
+		return t
+	}
+
+	return models.DefaultTenant
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+)
+
+// NewUserService creates the service managing users who own subscriptions.
+func NewUserService(s storage.UsersStorage, log *slog.Logger) UserService {
+	return userService{
+		usersStorage: s,
+		log:          log.With(slog.String("component", "UserService")),
+	}
+}
+
+type userService struct {
+	usersStorage storage.UsersStorage
+	log          *slog.Logger
+}
+
+type CreateUserArgs struct {
+	DisplayName string
+	Email       string
+	Timezone    string
+}
+
+type UpdateUserArgs struct {
+	models.PersonID
+	DisplayName string
+	Email       string
+	Timezone    string
+}
+
+type ListUsersArgs struct {
+	// Cursor is the next_cursor of the previous page, empty for the first page.
+	Cursor string
+	Limit  int
+}
+
+type usersPage struct {
+	Users []*models.User
+	// NextCursor is nil on the last page.
+	NextCursor *string
+}
+
+func (s userService) CreateUser(ctx context.Context, a CreateUserArgs) (*models.User, error) {
+	const op = "internal.service.users.CreateUser"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op))
+		return nil, err
+	}
+
+	u, err := models.NewUser(a.DisplayName, a.Email, a.Timezone)
+	if err != nil {
+		s.log.Debug("validation failed", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+	u.Tenant = tenantOfNewData(ctx)
+
+	if err := s.usersStorage.Add(ctx, *u); err != nil {
+		if errors.Is(err, storage.ErrUserEmailTaken) {
+			s.log.Warn("user email is taken", slog.String("op", op))
+			return nil, NewConflictError("email is already used by another user")
+		}
+		s.log.Error("failed to add user", slog.String("op", op), sl.Err(err), slog.Any("user_id", u.ID))
+		return nil, NewInternalError("failed to create user")
+	}
+
+	s.log.Info("user created", slog.String("op", op), slog.Any("user_id", u.ID))
+	return u, nil
+}
+
+func (s userService) UpdateUser(ctx context.Context, a UpdateUserArgs) (*models.User, error) {
+	const op = "internal.service.users.UpdateUser"
+
+	if err := authorizeOwner(ctx, a.PersonID); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("user_id", a.PersonID))
+		return nil, err
+	}
+
+	u, err := s.usersStorage.FindByID(ctx, TenantFromContext(ctx), a.PersonID)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			s.log.Warn("user not found", slog.String("op", op), slog.Any("user_id", a.PersonID))
+			return nil, NewNotFoundError("user not found")
+		}
+		s.log.Error("failed to find user", slog.String("op", op), sl.Err(err), slog.Any("user_id", a.PersonID))
+		return nil, NewInternalError("failed to update user")
+	}
+
+	if err := u.Change(a.DisplayName, a.Email, a.Timezone); err != nil {
+		s.log.Debug("validation failed", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+
+	if err := s.usersStorage.Update(ctx, *u); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			s.log.Warn("user not found", slog.String("op", op), slog.Any("user_id", u.ID))
+			return nil, NewNotFoundError("user not found")
+		}
+		if errors.Is(err, storage.ErrUserEmailTaken) {
+			s.log.Warn("user email is taken", slog.String("op", op), slog.Any("user_id", u.ID))
+			return nil, NewConflictError("email is already used by another user")
+		}
+		s.log.Error("failed to update user", slog.String("op", op), sl.Err(err), slog.Any("user_id", u.ID))
+		return nil, NewInternalError("failed to update user")
+	}
+
+	s.log.Info("user updated", slog.String("op", op), slog.Any("user_id", u.ID))
+	return u, nil
+}
+
+func (s userService) RemoveUser(ctx context.Context, id models.PersonID) error {
+	const op = "internal.service.users.RemoveUser"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("user_id", id))
+		return err
+	}
+
+	if err := s.usersStorage.RemoveByID(ctx, TenantFromContext(ctx), id); err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			s.log.Warn("user not found", slog.String("op", op), slog.Any("user_id", id))
+			return NewNotFoundError("user not found")
+		}
+		if errors.Is(err, storage.ErrUserHasSubscriptions) {
+			s.log.Warn("user still has subscriptions", slog.String("op", op), slog.Any("user_id", id))
+			return NewConflictError("user has subscriptions, purge them first")
+		}
+		s.log.Error("failed to remove user", slog.String("op", op), sl.Err(err), slog.Any("user_id", id))
+		return NewInternalError("failed to remove user")
+	}
+
+	s.log.Info("user removed", slog.String("op", op), slog.Any("user_id", id))
+	return nil
+}
+
+func (s userService) FindUserByID(ctx context.Context, id models.PersonID) (*models.User, error) {
+	const op = "internal.service.users.FindUserByID"
+
+	if err := authorizeOwner(ctx, id); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("user_id", id))
+		return nil, err
+	}
+
+	u, err := s.usersStorage.FindByID(ctx, TenantFromContext(ctx), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrUserNotFound) {
+			s.log.Warn("user not found", slog.String("op", op), slog.Any("user_id", id))
+			return nil, NewNotFoundError("user not found")
+		}
+		s.log.Error("failed to find user", slog.String("op", op), sl.Err(err), slog.Any("user_id", id))
+		return nil, NewInternalError("failed to fetch user")
+	}
+
+	s.log.Info("user fetched", slog.String("op", op), slog.Any("user_id", id))
+	return u, nil
+}
+
+func (s userService) GetUsers(ctx context.Context, l ListUsersArgs) (usersPage, error) {
+	const op = "internal.service.users.GetUsers"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op))
+		return usersPage{}, err
+	}
+
+	if l.Limit == 0 {
+		l.Limit = DefaultPageLimit
+	}
+	if l.Limit < 0 || l.Limit > MaxPageLimit {
+		s.log.Warn("invalid input: limit out of range", slog.String("op", op), slog.Int("limit", l.Limit))
+		return usersPage{}, NewInvalidInputError(fmt.Sprintf("limit must be between 1 and %d", MaxPageLimit))
+	}
+
+	// one extra row tells whether there is a next page
+	f := storage.UsersFilter{Tenant: TenantFromContext(ctx), Limit: l.Limit + 1}
+	if l.Cursor != "" {
+		after, err := decodeUserCursor(l.Cursor)
+		if err != nil {
+			s.log.Warn("invalid cursor", slog.String("op", op), sl.Err(err))
+			return usersPage{}, NewInvalidInputError(err.Error())
+		}
+		f.After = &after
+	}
+
+	users, err := s.usersStorage.Find(ctx, f)
+	if err != nil {
+		s.log.Error("failed to fetch users", slog.String("op", op), sl.Err(err))
+		return usersPage{}, NewInternalError("failed to fetch users")
+	}
+
+	page := usersPage{Users: users}
+	if len(users) > l.Limit {
+		page.Users = users[:l.Limit]
+		next := base64.RawURLEncoding.EncodeToString(page.Users[l.Limit-1].ID[:])
+		page.NextCursor = &next
+	}
+
+	s.log.Info("users fetched", slog.String("op", op), slog.Int("count", len(page.Users)))
+	return page, nil
+}
+
+func decodeUserCursor(cursor string) (models.PersonID, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return models.PersonID{}, fmt.Errorf("malformed cursor")
+	}
+
+	id, err := uuid.FromBytes(raw)
+	if err != nil {
+		return models.PersonID{}, fmt.Errorf("malformed cursor")
+	}
+
+	return id, nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+)
+
+// NewWebhookService creates the service managing webhooks subscribed to subscription events.
+// Webhook URLs must resolve to public addresses unless allowPrivateTargets is set,
+// so a webhook can not be used to reach services on the internal network.
+func NewWebhookService(s storage.WebhooksStorage, allowPrivateTargets bool, log *slog.Logger) WebhookService {
+	return webhookService{
+		webhooksStorage:     s,
+		allowPrivateTargets: allowPrivateTargets,
+		log:                 log.With(slog.String("component", "WebhookService")),
+	}
+}
+
+type webhookService struct {
+	webhooksStorage     storage.WebhooksStorage
+	allowPrivateTargets bool
+	log                 *slog.Logger
+}
+
+type CreateWebhookArgs struct {
+	URL    string
+	Secret string
+	Events []models.EventType
+}
+
+type UpdateWebhookArgs struct {
+	models.WebhookID
+	URL    string
+	Secret string
+	Events []models.EventType
+}
+
+func (s webhookService) CreateWebhook(ctx context.Context, a CreateWebhookArgs) (*models.Webhook, error) {
+	const op = "internal.service.webhooks.CreateWebhook"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op))
+		return nil, err
+	}
+
+	w, err := models.NewWebhook(a.URL, a.Secret, a.Events)
+	if err != nil {
+		s.log.Debug("validation failed", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+	if err := s.checkTarget(ctx, w.URL); err != nil {
+		s.log.Warn("invalid input: webhook target", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+	w.Tenant = tenantOfNewData(ctx)
+
+	if err := s.webhooksStorage.Add(ctx, *w); err != nil {
+		s.log.Error("failed to add webhook", slog.String("op", op), sl.Err(err), slog.Any("webhook_id", w.ID))
+		return nil, NewInternalError("failed to create webhook")
+	}
+
+	s.log.Info("webhook created", slog.String("op", op), slog.Any("webhook_id", w.ID))
+	return w, nil
+}
+
+func (s webhookService) UpdateWebhook(ctx context.Context, a UpdateWebhookArgs) (*models.Webhook, error) {
+	const op = "internal.service.webhooks.UpdateWebhook"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("webhook_id", a.WebhookID))
+		return nil, err
+	}
+
+	w, err := s.webhooksStorage.FindByID(ctx, TenantFromContext(ctx), a.WebhookID)
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			s.log.Warn("webhook not found", slog.String("op", op), slog.Any("webhook_id", a.WebhookID))
+			return nil, NewNotFoundError("webhook not found")
+		}
+		s.log.Error("failed to find webhook", slog.String("op", op), sl.Err(err), slog.Any("webhook_id", a.WebhookID))
+		return nil, NewInternalError("failed to update webhook")
+	}
+
+	if err := w.Change(a.URL, a.Secret, a.Events); err != nil {
+		s.log.Debug("validation failed", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+	if err := s.checkTarget(ctx, w.URL); err != nil {
+		s.log.Warn("invalid input: webhook target", slog.String("op", op), sl.Err(err))
+		return nil, NewInvalidInputError(err.Error())
+	}
+
+	if err := s.webhooksStorage.Update(ctx, *w); err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			s.log.Warn("webhook not found", slog.String("op", op), slog.Any("webhook_id", w.ID))
+			return nil, NewNotFoundError("webhook not found")
+		}
+		s.log.Error("failed to update webhook", slog.String("op", op), sl.Err(err), slog.Any("webhook_id", w.ID))
+		return nil, NewInternalError("failed to update webhook")
+	}
+
+	s.log.Info("webhook updated", slog.String("op", op), slog.Any("webhook_id", w.ID))
+	return w, nil
+}
+
+func (s webhookService) RemoveWebhook(ctx context.Context, id models.WebhookID) error {
+	const op = "internal.service.webhooks.RemoveWebhook"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("webhook_id", id))
+		return err
+	}
+
+	if err := s.webhooksStorage.RemoveByID(ctx, TenantFromContext(ctx), id); err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			s.log.Warn("webhook not found", slog.String("op", op), slog.Any("webhook_id", id))
+			return NewNotFoundError("webhook not found")
+		}
+		s.log.Error("failed to remove webhook", slog.String("op", op), sl.Err(err), slog.Any("webhook_id", id))
+		return NewInternalError("failed to remove webhook")
+	}
+
+	s.log.Info("webhook removed", slog.String("op", op), slog.Any("webhook_id", id))
+	return nil
+}
+
+func (s webhookService) FindWebhookByID(ctx context.Context, id models.WebhookID) (*models.Webhook, error) {
+	const op = "internal.service.webhooks.FindWebhookByID"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op), slog.Any("webhook_id", id))
+		return nil, err
+	}
+
+	w, err := s.webhooksStorage.FindByID(ctx, TenantFromContext(ctx), id)
+	if err != nil {
+		if errors.Is(err, storage.ErrWebhookNotFound) {
+			s.log.Warn("webhook not found", slog.String("op", op), slog.Any("webhook_id", id))
+			return nil, NewNotFoundError("webhook not found")
+		}
+		s.log.Error("failed to find webhook", slog.String("op", op), sl.Err(err), slog.Any("webhook_id", id))
+		return nil, NewInternalError("failed to fetch webhook")
+	}
+
+	s.log.Info("webhook fetched", slog.String("op", op), slog.Any("webhook_id", id))
+	return w, nil
+}
+
+func (s webhookService) GetWebhooks(ctx context.Context) ([]*models.Webhook, error) {
+	const op = "internal.service.webhooks.GetWebhooks"
+
+	if err := authorizeAdmin(ctx); err != nil {
+		s.log.Warn("access denied", slog.String("op", op))
+		return nil, err
+	}
+
+	webhooks, err := s.webhooksStorage.FindAll(ctx, TenantFromContext(ctx))
+	if err != nil {
+		s.log.Error("failed to fetch webhooks", slog.String("op", op), sl.Err(err))
+		return nil, NewInternalError("failed to fetch webhooks")
+	}
+
+	s.log.Info("webhooks fetched", slog.String("op", op), slog.Int("count", len(webhooks)))
+	return webhooks, nil
+}
+
+func (s webhookService) GetDeadLetters(ctx context.Context, id models.WebhookID) ([]*models.DeadLetter, error) {
+	const op = "internal.service.webhooks.GetDeadLetters"
+
+	if _, err := s.FindWebhookByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	letters, err := s.webhooksStorage.DeadLetters(ctx, TenantFromContext(ctx), id)
+	if err != nil {
+		s.log.Error("failed to fetch dead letters", slog.String("op", op), sl.Err(err), slog.Any("webhook_id", id))
+		return nil, NewInternalError("failed to fetch dead letters")
+	}
+
+	s.log.Info("dead letters fetched", slog.String("op", op), slog.Any("webhook_id", id), slog.Int("count", len(letters)))
+	return letters, nil
+}
+
+// checkTarget rejects webhook URLs whose host is or resolves to a non-public address.
+func (s webhookService) checkTarget(ctx context.Context, rawURL string) error {
+	if s.allowPrivateTargets {
+		return nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid webhook: %q is not a url", rawURL)
+	}
+
+	host := u.Hostname()
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil || len(addrs) == 0 {
+		return fmt.Errorf("invalid webhook: host %q can not be resolved", host)
+	}
+	for _, addr := range addrs {
+		if !isPublicIP(addr.IP) {
+			return fmt.Errorf("invalid webhook: host %q is not a public address", host)
+		}
+	}
+
+	return nil
+}
+
+// isPublicIP tells whether the address is reachable on the internet rather than
+// on the loopback interface, a private or link-local network such as cloud metadata endpoints.
+func isPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsUnspecified() &&
+		!ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() &&
+		!sharedAddressSpace.Contains(ip)
+}
+
+// sharedAddressSpace is the carrier-grade NAT range of RFC 6598, it is not routed on the internet either.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
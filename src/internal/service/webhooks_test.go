@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"errors"
+	"sync"
+	"testing"
+)
+
+type fakeWebhooksStorage struct {
+	storage.WebhooksStorage
+	webhooks []*models.Webhook
+	added    []models.Webhook
+
+	mu            sync.Mutex
+	deadLetters   []models.DeadLetter
+	deadLetterErr error
+}
+
+func (s *fakeWebhooksStorage) Add(ctx context.Context, w models.Webhook) error {
+	s.added = append(s.added, w)
+	return nil
+}
+
+func (s *fakeWebhooksStorage) FindByEvent(ctx context.Context, tenant models.TenantID, t models.EventType) ([]*models.Webhook, error) {
+	var found []*models.Webhook
+	for _, w := range s.webhooks {
+		if w.Tenant == tenant {
+			found = append(found, w)
+		}
+	}
+	return found, nil
+}
+
+func (s *fakeWebhooksStorage) AddDeadLetter(ctx context.Context, d models.DeadLetter) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.deadLetterErr != nil {
+		return s.deadLetterErr
+	}
+	s.deadLetters = append(s.deadLetters, d)
+	return nil
+}
+
+func TestCreateWebhookRejectsNonPublicTargets(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		allow   bool
+		wantErr bool
+	}{
+		{name: "public address", url: "https://8.8.8.8/hooks"},
+		{name: "loopback", url: "http://127.0.0.1:8080/hooks", wantErr: true},
+		{name: "localhost", url: "http://localhost/hooks", wantErr: true},
+		{name: "ipv6 loopback", url: "http://[::1]/hooks", wantErr: true},
+		{name: "private network", url: "http://10.1.2.3/hooks", wantErr: true},
+		{name: "cloud metadata", url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "unspecified", url: "http://0.0.0.0/hooks", wantErr: true},
+		{name: "shared address space", url: "http://100.64.0.1/hooks", wantErr: true},
+		{name: "ipv4 mapped loopback", url: "http://[::ffff:127.0.0.1]/hooks", wantErr: true},
+		{name: "unsupported scheme", url: "ftp://8.8.8.8/hooks", wantErr: true},
+		{name: "private allowed", url: "http://127.0.0.1:8080/hooks", allow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhooks := &fakeWebhooksStorage{}
+			s := NewWebhookService(webhooks, tt.allow, testLogger())
+			ctx := WithPrincipal(context.Background(), Principal{Role: RoleAdmin})
+
+			_, err := s.CreateWebhook(ctx, CreateWebhookArgs{
+				URL:    tt.url,
+				Secret: "secret",
+				Events: []models.EventType{models.EventSubscriptionCreated},
+			})
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("CreateWebhook() error = %v", err)
+				}
+				return
+			}
+
+			var svcErr *ServiceError
+			if !errors.As(err, &svcErr) || svcErr.Code != ErrInvalidInput {
+				t.Fatalf("CreateWebhook() error = %v, want invalid input", err)
+			}
+			if len(webhooks.added) != 0 {
+				t.Errorf("stored %d webhooks, want none", len(webhooks.added))
+			}
+		})
+	}
+}
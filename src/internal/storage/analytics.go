@@ -0,0 +1,48 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"time"
+)
+
+// SpendingDimension is a column spending can be grouped by.
+type SpendingDimension string
+
+const (
+	SpendingByService  SpendingDimension = "service"
+	SpendingByUser     SpendingDimension = "user"
+	SpendingByMonth    SpendingDimension = "month"
+	SpendingByCategory SpendingDimension = "category"
+)
+
+// Uncategorized is the category spending of services without a category is grouped under.
+const Uncategorized = "uncategorized"
+
+// SpendingQuery selects the charges of not deleted subscriptions within the [From, To] window.
+// Charges are always grouped by currency in addition to GroupBy.
+type SpendingQuery struct {
+	// Tenant keeps charges of the given tenant, empty matches every tenant.
+	Tenant      models.TenantID
+	From        time.Time
+	To          time.Time
+	OwnerID     models.PersonID
+	ServiceName models.ServiceName
+	Category    string
+	GroupBy     []SpendingDimension
+}
+
+// SpendingRow is one group of charges, only the dimensions the query was grouped by are set.
+type SpendingRow struct {
+	Month       *time.Time
+	ServiceName *models.ServiceName
+	OwnerID     *models.PersonID
+	Category    *string
+	Amount      models.Money
+	Charges     int64
+}
+
+type AnalyticsStorage interface {
+	// Spending sums the charges within the window using the same billing rules as models.Subscription.ChargesWithin.
+	Spending(ctx context.Context, q SpendingQuery) ([]SpendingRow, error)
+}
@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"errors"
+	"time"
+)
+
+// ErrAPIKeyNotFound is returned when an api key is not found in the database.
+var ErrAPIKeyNotFound = errors.New("api key not found")
+
+// APIKeysStorage keeps the keys of every tenant. Methods addressing a key by id only see it when it belongs
+// to the given tenant, an empty tenant matches every tenant.
+type APIKeysStorage interface {
+	// Add stores the key, it fails with ErrUserNotFound when the owner is not a user of the key tenant.
+	Add(ctx context.Context, k models.APIKey) error
+	FindByID(ctx context.Context, tenant models.TenantID, id models.APIKeyID) (*models.APIKey, error)
+	// FindByHash looks an issued key of any tenant up by the digest of the key string.
+	FindByHash(ctx context.Context, hash []byte) (*models.APIKey, error)
+	// FindAll returns every key of the tenant, revoked ones included, ordered by id.
+	FindAll(ctx context.Context, tenant models.TenantID) ([]*models.APIKey, error)
+	// Revoke marks the key revoked at the given moment, a revoked key keeps its first revocation time.
+	Revoke(ctx context.Context, tenant models.TenantID, id models.APIKeyID, at time.Time) error
+	// TouchLastUsed records that the key was used at the given moment unless it was recorded less than a minute before.
+	TouchLastUsed(ctx context.Context, id models.APIKeyID, at time.Time) error
+}
@@ -0,0 +1,20 @@
+package storage
+
+import "context"
+
+// ChangeMeta describes the origin of a change, it is recorded next to every history entry.
+type ChangeMeta struct {
+	Actor     string
+	RequestID string
+}
+
+type changeMetaKey struct{}
+
+func WithChangeMeta(ctx context.Context, m ChangeMeta) context.Context {
+	return context.WithValue(ctx, changeMetaKey{}, m)
+}
+
+func ChangeMetaFromContext(ctx context.Context) ChangeMeta {
+	m, _ := ctx.Value(changeMetaKey{}).(ChangeMeta)
+	return m
+}
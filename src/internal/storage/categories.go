@@ -0,0 +1,20 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"errors"
+)
+
+// ErrCategoryNotFound is returned when the service name has no category assigned.
+var ErrCategoryNotFound = errors.New("service category not found")
+
+// CategoriesStorage keeps the categories every tenant assigned to its service names.
+type CategoriesStorage interface {
+	// Set assigns the category to the service name of the mapping tenant, replacing the previous one.
+	Set(ctx context.Context, c models.ServiceCategory) error
+	Remove(ctx context.Context, tenant models.TenantID, service models.ServiceName) error
+	Find(ctx context.Context, tenant models.TenantID, service models.ServiceName) (*models.ServiceCategory, error)
+	// FindAll returns every mapping of the tenant ordered by category and service name.
+	FindAll(ctx context.Context, tenant models.TenantID) ([]*models.ServiceCategory, error)
+}
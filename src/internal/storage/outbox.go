@@ -0,0 +1,25 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxStorage holds the lifecycle events written in the same transaction as the subscription changes
+// until they are dispatched.
+type OutboxStorage interface {
+	// Claim hands out at most limit pending events that are due, in the order they were written, and keeps
+	// them from being claimed again until claimedUntil. Only the oldest pending event of a subscription can be
+	// claimed, so the events of a subscription are published one after another while a failing event holds up
+	// no other subscription. Concurrent callers never claim the same event.
+	Claim(ctx context.Context, limit int, claimedUntil time.Time) ([]models.SubscriptionEvent, error)
+	// MarkDispatched finishes the claimed event, which makes the next event of its subscription claimable.
+	MarkDispatched(ctx context.Context, id uuid.UUID) error
+	// Retry counts a failed attempt to publish the claimed event and makes it due again at the given moment.
+	Retry(ctx context.Context, id uuid.UUID, at time.Time) error
+	// PurgeDispatchedBefore removes the events dispatched before the given moment.
+	PurgeDispatchedBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,154 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgconn"
+)
+
+func NewAnalyticsStorage(c pgsql.Client, log *slog.Logger) storage.AnalyticsStorage {
+	log = log.With(slog.String("component", "AnalyticsStorage"))
+	return &analyticsStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type analyticsStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+// spendingColumns maps a dimension to the charges column it groups by.
+var spendingColumns = map[storage.SpendingDimension]string{
+	storage.SpendingByMonth:    "date_trunc('month', charged_at)",
+	storage.SpendingByService:  "service_name",
+	storage.SpendingByUser:     "owner_id",
+	storage.SpendingByCategory: "COALESCE(category, '" + storage.Uncategorized + "')",
+}
+
+func (s *analyticsStorage) Spending(ctx context.Context, q storage.SpendingQuery) ([]storage.SpendingRow, error) {
+	const op = "storage.postgresql.analytics.Spending"
+	// Monthly, yearly and one-off charges fall on the start date shifted by whole billing periods
+	// and count when their calendar month overlaps the window, weekly charges count by exact date.
+	// Postgres clamps "+ n months" to the end of a shorter month just like addMonthsClamped does.
+	const subsSql = `
+		WITH subs AS (
+			SELECT id
+				 , tenant_id
+				 , owner_id
+				 , service_name
+				 , price
+				 , currency
+				 , billing_period
+				 , start_time
+				 , LEAST($2::TIMESTAMP, COALESCE(end_time, $2::TIMESTAMP)) AS last_time
+				 , CASE billing_period WHEN 'yearly' THEN 12 WHEN 'one_off' THEN 0 ELSE 1 END AS step
+			  FROM subscriptions
+			 WHERE is_deleted = 0::BIT AND start_time <= $2`
+	const chargesSql = `
+		),
+		charges AS (
+			SELECT s.owner_id
+				 , s.service_name
+				 , sc.category
+				 , s.price
+				 , s.currency
+				 , c.charged_at
+			  FROM subs s
+			  LEFT JOIN service_categories sc ON sc.tenant_id = s.tenant_id AND sc.service_name = s.service_name
+			 CROSS JOIN LATERAL (
+				SELECT s.start_time + make_interval(days => 7 * k) AS charged_at
+				  FROM generate_series(0, FLOOR(EXTRACT(EPOCH FROM s.last_time - s.start_time) / 604800)::INT) AS k
+				 WHERE s.billing_period = 'weekly'
+				 UNION ALL
+				SELECT s.start_time + make_interval(months => s.step * k)
+				  FROM generate_series(0, CASE WHEN s.step = 0 THEN 0 ELSE ((EXTRACT(YEAR FROM s.last_time) - EXTRACT(YEAR FROM s.start_time)) * 12
+						+ EXTRACT(MONTH FROM s.last_time) - EXTRACT(MONTH FROM s.start_time))::INT / s.step END) AS k
+				 WHERE s.billing_period <> 'weekly'
+				   AND date_trunc('month', s.start_time) <= date_trunc('month', s.last_time)
+			 ) c
+			 WHERE (s.billing_period = 'weekly' AND c.charged_at >= $1)
+				OR (s.billing_period <> 'weekly' AND date_trunc('month', c.charged_at) >= date_trunc('month', $1::TIMESTAMP))
+		)
+		SELECT `
+
+	sqlB := strings.Builder{}
+	sqlB.WriteString(subsSql)
+	args := writeFilterPredicates(&sqlB, storage.SubscriptionsFilter{Tenant: q.Tenant, OwnerID: q.OwnerID, ServiceName: q.ServiceName, Category: q.Category}, []interface{}{q.From.UTC(), q.To.UTC()})
+	sqlB.WriteString(chargesSql)
+
+	groups := make([]string, 0, len(q.GroupBy)+1)
+	for _, d := range q.GroupBy {
+		column, ok := spendingColumns[d]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown spending dimension %q", op, d)
+		}
+		groups = append(groups, column)
+	}
+	groups = append(groups, "currency")
+
+	for _, g := range groups {
+		sqlB.WriteString(g)
+		sqlB.WriteString(", ")
+	}
+	sqlB.WriteString("SUM(price)::BIGINT, COUNT(*)\n\t\t  FROM charges\n\t\t GROUP BY ")
+	sqlB.WriteString(strings.Join(groups, ", "))
+	sqlB.WriteString("\n\t\t ORDER BY ")
+	sqlB.WriteString(strings.Join(groups, ", "))
+	sqlB.WriteString(";")
+
+	sql := sqlB.String()
+	s.log.Info("performing query", slog.String("sql", strings.ReplaceAll(sql, "\t", "")))
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var result []storage.SpendingRow
+	for rows.Next() {
+		var row storage.SpendingRow
+		dest := make([]interface{}, 0, len(groups)+2)
+		for _, d := range q.GroupBy {
+			switch d {
+			case storage.SpendingByMonth:
+				dest = append(dest, &row.Month)
+			case storage.SpendingByService:
+				dest = append(dest, &row.ServiceName)
+			case storage.SpendingByUser:
+				dest = append(dest, &row.OwnerID)
+			case storage.SpendingByCategory:
+				dest = append(dest, &row.Category)
+			}
+		}
+		dest = append(dest, &row.Amount.Currency, &row.Amount.Amount, &row.Charges)
+
+		if err = rows.Scan(dest...); err != nil {
+			s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		result = append(result, row)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully calculated spending", slog.String("op", op), slog.Int("groups", len(result)))
+	return result, nil
+}
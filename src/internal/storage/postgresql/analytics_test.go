@@ -0,0 +1,86 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TestSpendingMatchesChargesWithin keeps the SQL billing rules of Spending in line with models.Subscription.ChargesWithin.
+func TestSpendingMatchesChargesWithin(t *testing.T) {
+	c, tenant := testDB(t)
+	subs := NewSubscriptionStorage(c, testLogger())
+	analytics := NewAnalyticsStorage(c, testLogger())
+	owner := testUser(t, c, tenant)
+	ctx := context.Background()
+
+	rub := models.Money{Amount: 39999, Currency: "RUB"}
+	usd := models.Money{Amount: 1099, Currency: "USD"}
+	fixtures := []struct {
+		period models.BillingPeriod
+		price  models.Money
+		start  time.Time
+		end    *time.Time
+	}{
+		{period: models.BillingMonthly, price: rub, start: date(2024, 1, 31)},
+		{period: models.BillingMonthly, price: rub, start: date(2023, 11, 30), end: ptr(date(2024, 3, 10))},
+		{period: models.BillingMonthly, price: usd, start: date(2024, 2, 15), end: ptr(date(2024, 5, 20))},
+		{period: models.BillingYearly, price: rub, start: date(2020, 2, 29)},
+		{period: models.BillingYearly, price: usd, start: date(2023, 6, 1)},
+		{period: models.BillingOneOff, price: usd, start: date(2024, 3, 10)},
+		{period: models.BillingOneOff, price: rub, start: date(2023, 12, 1)},
+		{period: models.BillingWeekly, price: rub, start: date(2024, 2, 26), end: ptr(date(2024, 4, 2))},
+		{period: models.BillingMonthly, price: rub, start: date(2025, 1, 1)},
+	}
+
+	// the window starts and ends in the middle of a month
+	from, to := date(2024, 2, 10), date(2024, 6, 20)
+	type group struct {
+		month    string
+		currency models.Currency
+	}
+	type total struct {
+		amount  int64
+		charges int64
+	}
+	want := make(map[group]total)
+	for _, f := range fixtures {
+		sub := models.Subscription{
+			ID:          uuid.New(),
+			Tenant:      tenant,
+			Owner:       owner,
+			ServiceName: "Netflix",
+			Price:       f.price,
+			Period:      f.period,
+			StartedAt:   f.start,
+			CompletedAt: f.end,
+			Version:     1,
+		}
+		if err := subs.Add(ctx, sub); err != nil {
+			t.Fatalf("Add() error = %v", err)
+		}
+		for _, charge := range sub.ChargesWithin(from, to) {
+			g := group{month: charge.Format("2006-01"), currency: f.price.Currency}
+			w := want[g]
+			want[g] = total{amount: w.amount + f.price.Amount, charges: w.charges + 1}
+		}
+	}
+
+	rows, err := analytics.Spending(ctx, storage.SpendingQuery{Tenant: tenant, From: from, To: to, GroupBy: []storage.SpendingDimension{storage.SpendingByMonth}})
+	if err != nil {
+		t.Fatalf("Spending() error = %v", err)
+	}
+	got := make(map[group]total, len(rows))
+	for _, r := range rows {
+		got[group{month: r.Month.UTC().Format("2006-01"), currency: r.Amount.Currency}] = total{amount: r.Amount.Amount, charges: r.Charges}
+	}
+
+	if !maps.Equal(got, want) {
+		t.Errorf("Spending() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,214 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+func NewAPIKeysStorage(c pgsql.Client, log *slog.Logger) storage.APIKeysStorage {
+	log = log.With(slog.String("component", "APIKeysStorage"))
+	return &apiKeysStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type apiKeysStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+func (s *apiKeysStorage) logSqlQuery(sql string) {
+	pretty := strings.ReplaceAll(sql, "\t", "")
+	s.log.Info("performing query", slog.String("sql", pretty))
+}
+
+func (s *apiKeysStorage) Add(ctx context.Context, k models.APIKey) error {
+	const op = "storage.postgresql.apikeys.Add"
+	const sql = `
+		INSERT INTO api_keys (id, tenant_id, name, user_id, hint, key_hash, scopes, expires_at, created_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`
+
+	scopes := make([]string, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	s.logSqlQuery(sql)
+	_, err := s.client.Exec(ctx, sql, k.ID, k.Tenant, k.Name, k.Owner, k.Hint, k.Hash, scopes, k.ExpiresAt, k.CreatedAt.UTC())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == foreignKeyViolation {
+				s.log.Warn("api key owner not found", slog.String("op", op), slog.Any("user_id", k.Owner))
+				return storage.ErrUserNotFound
+			}
+			s.log.Error("database error during insert", sl.Err(pgErr), slog.String("op", op), slog.Any("api_key_id", k.ID))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute insert", sl.Err(err), slog.String("op", op), slog.Any("api_key_id", k.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully added api key", slog.Any("api_key_id", k.ID), slog.String("op", op))
+	return nil
+}
+
+func (s *apiKeysStorage) FindByID(ctx context.Context, tenant models.TenantID, id models.APIKeyID) (*models.APIKey, error) {
+	const op = "storage.postgresql.apikeys.FindByID"
+	const sql = `
+		SELECT id, tenant_id, name, user_id, hint, key_hash, scopes, expires_at, last_used_at, created_at, revoked_at
+		  FROM api_keys
+		 WHERE id = $1 AND ($2::TEXT = '' OR tenant_id = $2);`
+
+	return s.findAPIKey(ctx, op, sql, id, tenant)
+}
+
+func (s *apiKeysStorage) FindByHash(ctx context.Context, hash []byte) (*models.APIKey, error) {
+	const op = "storage.postgresql.apikeys.FindByHash"
+	const sql = `
+		SELECT id, tenant_id, name, user_id, hint, key_hash, scopes, expires_at, last_used_at, created_at, revoked_at
+		  FROM api_keys
+		 WHERE key_hash = $1;`
+
+	return s.findAPIKey(ctx, op, sql, hash)
+}
+
+func (s *apiKeysStorage) findAPIKey(ctx context.Context, op, sql string, args ...interface{}) (*models.APIKey, error) {
+	s.logSqlQuery(sql)
+	k, err := scanAPIKey(s.client.QueryRow(ctx, sql, args...))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during fetch", sl.Err(pgErr), slog.String("op", op))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warn("api key not found", slog.String("op", op))
+			return nil, storage.ErrAPIKeyNotFound
+		}
+		s.log.Error("failed to fetch api key", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched api key", slog.Any("api_key_id", k.ID), slog.String("op", op))
+	return k, nil
+}
+
+func (s *apiKeysStorage) FindAll(ctx context.Context, tenant models.TenantID) ([]*models.APIKey, error) {
+	const op = "storage.postgresql.apikeys.FindAll"
+	const sql = `
+		SELECT id, tenant_id, name, user_id, hint, key_hash, scopes, expires_at, last_used_at, created_at, revoked_at
+		  FROM api_keys
+		 WHERE $1::TEXT = '' OR tenant_id = $1
+		 ORDER BY id;`
+
+	s.logSqlQuery(sql)
+	rows, err := s.client.Query(ctx, sql, tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var keys []*models.APIKey
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		keys = append(keys, k)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched api keys", slog.String("op", op), slog.Int("count", len(keys)))
+	return keys, nil
+}
+
+func (s *apiKeysStorage) Revoke(ctx context.Context, tenant models.TenantID, id models.APIKeyID, at time.Time) error {
+	const op = "storage.postgresql.apikeys.Revoke"
+	const sql = `
+		UPDATE api_keys
+		   SET revoked_at = COALESCE(revoked_at, $2)
+		 WHERE id = $1 AND ($3::TEXT = '' OR tenant_id = $3);`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, id, at.UTC(), tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op), slog.Any("api_key_id", id))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op), slog.Any("api_key_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.log.Warn("api key not found", slog.String("op", op), slog.Any("api_key_id", id))
+		return storage.ErrAPIKeyNotFound
+	}
+
+	s.log.Info("successfully revoked api key", slog.Any("api_key_id", id), slog.String("op", op))
+	return nil
+}
+
+func (s *apiKeysStorage) TouchLastUsed(ctx context.Context, id models.APIKeyID, at time.Time) error {
+	const op = "storage.postgresql.apikeys.TouchLastUsed"
+	// a key used by a busy client would otherwise be written on every request
+	const sql = `
+		UPDATE api_keys
+		   SET last_used_at = $2
+		 WHERE id = $1
+		   AND (last_used_at IS NULL OR last_used_at < $2 - INTERVAL '1 minute');`
+
+	s.logSqlQuery(sql)
+	_, err := s.client.Exec(ctx, sql, id, at.UTC())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op), slog.Any("api_key_id", id))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op), slog.Any("api_key_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func scanAPIKey(row pgx.Row) (*models.APIKey, error) {
+	var k models.APIKey
+	var scopes []string
+	err := row.Scan(&k.ID, &k.Tenant, &k.Name, &k.Owner, &k.Hint, &k.Hash, &scopes, &k.ExpiresAt, &k.LastUsedAt, &k.CreatedAt, &k.RevokedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	k.Scopes = make([]models.APIKeyScope, len(scopes))
+	for i, scope := range scopes {
+		k.Scopes[i] = models.APIKeyScope(scope)
+	}
+
+	return &k, nil
+}
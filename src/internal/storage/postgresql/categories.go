@@ -0,0 +1,170 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+func NewCategoriesStorage(c pgsql.Client, log *slog.Logger) storage.CategoriesStorage {
+	log = log.With(slog.String("component", "CategoriesStorage"))
+	return &categoriesStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type categoriesStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+// execer is satisfied by both the client and an open transaction.
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+}
+
+func (s *categoriesStorage) logSqlQuery(sql string) {
+	pretty := strings.ReplaceAll(sql, "\t", "")
+	s.log.Info("performing query", slog.String("sql", pretty))
+}
+
+func (s *categoriesStorage) Set(ctx context.Context, c models.ServiceCategory) error {
+	const op = "storage.postgresql.categories.Set"
+
+	if err := setCategory(ctx, s.client, c.Tenant, c.ServiceName, c.Category); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during upsert", sl.Err(pgErr), slog.String("op", op), slog.String("service_name", c.ServiceName))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute upsert", sl.Err(err), slog.String("op", op), slog.String("service_name", c.ServiceName))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully set service category", slog.String("service_name", c.ServiceName), slog.String("op", op))
+	return nil
+}
+
+func (s *categoriesStorage) Remove(ctx context.Context, tenant models.TenantID, service models.ServiceName) error {
+	const op = "storage.postgresql.categories.Remove"
+	const sql = `
+		DELETE FROM service_categories
+		 WHERE service_name = $1 AND ($2::TEXT = '' OR tenant_id = $2);`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, service, tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during delete", sl.Err(pgErr), slog.String("op", op), slog.String("service_name", service))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute delete", sl.Err(err), slog.String("op", op), slog.String("service_name", service))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.log.Warn("service category not found", slog.String("op", op), slog.String("service_name", service))
+		return storage.ErrCategoryNotFound
+	}
+
+	s.log.Info("successfully removed service category", slog.String("service_name", service), slog.String("op", op))
+	return nil
+}
+
+func (s *categoriesStorage) Find(ctx context.Context, tenant models.TenantID, service models.ServiceName) (*models.ServiceCategory, error) {
+	const op = "storage.postgresql.categories.Find"
+	const sql = `
+		SELECT service_name, category, tenant_id
+		  FROM service_categories
+		 WHERE service_name = $1 AND ($2::TEXT = '' OR tenant_id = $2);`
+
+	var c models.ServiceCategory
+	s.logSqlQuery(sql)
+	err := s.client.QueryRow(ctx, sql, service, tenant).Scan(&c.ServiceName, &c.Category, &c.Tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during fetch", sl.Err(pgErr), slog.String("op", op), slog.String("service_name", service))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warn("service category not found", slog.String("op", op), slog.String("service_name", service))
+			return nil, storage.ErrCategoryNotFound
+		}
+		s.log.Error("failed to fetch service category", sl.Err(err), slog.String("op", op), slog.String("service_name", service))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched service category", slog.String("service_name", service), slog.String("op", op))
+	return &c, nil
+}
+
+func (s *categoriesStorage) FindAll(ctx context.Context, tenant models.TenantID) ([]*models.ServiceCategory, error) {
+	const op = "storage.postgresql.categories.FindAll"
+	const sql = `
+		SELECT service_name, category, tenant_id
+		  FROM service_categories
+		 WHERE $1::TEXT = '' OR tenant_id = $1
+		 ORDER BY category, service_name;`
+
+	s.logSqlQuery(sql)
+	rows, err := s.client.Query(ctx, sql, tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var categories []*models.ServiceCategory
+	for rows.Next() {
+		var c models.ServiceCategory
+		if err = rows.Scan(&c.ServiceName, &c.Category, &c.Tenant); err != nil {
+			s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		categories = append(categories, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched service categories", slog.String("op", op), slog.Int("count", len(categories)))
+	return categories, nil
+}
+
+// setCategory upserts the mapping of the tenant's service name, an empty category removes it.
+func setCategory(ctx context.Context, e execer, tenant models.TenantID, service models.ServiceName, category string) error {
+	const upsertSql = `
+		INSERT INTO service_categories (service_name, category, tenant_id)
+			 VALUES ($1, $2, $3)
+		ON CONFLICT (tenant_id, service_name) DO UPDATE
+				SET category = EXCLUDED.category;`
+	const deleteSql = `
+		DELETE FROM service_categories
+		 WHERE service_name = $1 AND tenant_id = $2;`
+
+	if category == "" {
+		_, err := e.Exec(ctx, deleteSql, service, tenant)
+		return err
+	}
+
+	_, err := e.Exec(ctx, upsertSql, service, category, tenant)
+	return err
+}
@@ -0,0 +1,3 @@
+UPDATE subscriptions SET price = price / 100;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS currency;
\ No newline at end of file
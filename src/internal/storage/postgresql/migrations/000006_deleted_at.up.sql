@@ -0,0 +1,5 @@
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+
+UPDATE subscriptions SET deleted_at = NOW() AT TIME ZONE 'UTC' WHERE is_deleted = 1::BIT;
+
+CREATE INDEX IF NOT EXISTS subscriptions_deleted_at_idx ON subscriptions (deleted_at) WHERE is_deleted = 1::BIT;
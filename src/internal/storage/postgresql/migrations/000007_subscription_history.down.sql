@@ -0,0 +1 @@
+DROP TABLE IF EXISTS subscription_history;
\ No newline at end of file
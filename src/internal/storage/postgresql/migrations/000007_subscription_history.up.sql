@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS subscription_history (
+    id BIGSERIAL PRIMARY KEY,
+    subscription_id UUID NOT NULL,
+    action TEXT NOT NULL,
+    changed_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    changed_by TEXT,
+    request_id TEXT,
+    old_value JSONB,
+    new_value JSONB
+);
+
+CREATE INDEX IF NOT EXISTS subscription_history_subscription_id_idx ON subscription_history (subscription_id, id);
@@ -0,0 +1,45 @@
+CREATE TABLE IF NOT EXISTS services (
+    id UUID PRIMARY KEY,
+    name TEXT NOT NULL,
+    aliases TEXT[] NOT NULL DEFAULT '{}',
+    default_price BIGINT CHECK (default_price >= 0),
+    default_currency CHAR(3) CHECK (default_currency ~ '^[A-Z]{3}$'),
+    CHECK ((default_price IS NULL) = (default_currency IS NULL))
+);
+
+-- every normalized spelling of a service, the name included, so no two services can share one
+CREATE TABLE IF NOT EXISTS service_names (
+    name_key TEXT PRIMARY KEY,
+    service_id UUID NOT NULL REFERENCES services (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS service_names_service_id_idx ON service_names (service_id);
+
+-- names already used by subscriptions become catalog entries, spellings differing in case or spacing are merged
+WITH names AS (
+    SELECT DISTINCT ON (name_key) name_key, name
+      FROM (
+        SELECT lower(regexp_replace(btrim(service_name), '\s+', ' ', 'g')) AS name_key
+             , regexp_replace(btrim(service_name), '\s+', ' ', 'g') AS name
+          FROM subscriptions
+      ) n
+     WHERE name_key <> ''
+     ORDER BY name_key, name
+),
+inserted AS (
+    INSERT INTO services (id, name)
+         SELECT gen_random_uuid(), name
+           FROM names
+      RETURNING id, name
+)
+INSERT INTO service_names (name_key, service_id)
+     SELECT lower(name), id
+       FROM inserted;
+
+-- subscriptions are stored under the canonical name of their service, so filters and totals match every spelling
+UPDATE subscriptions s
+   SET service_name = sv.name
+  FROM service_names n
+  JOIN services sv ON sv.id = n.service_id
+ WHERE n.name_key = lower(regexp_replace(btrim(s.service_name), '\s+', ' ', 'g'))
+   AND s.service_name <> sv.name;
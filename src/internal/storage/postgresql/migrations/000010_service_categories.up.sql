@@ -0,0 +1,7 @@
+-- categories are keyed by the service name stored on subscriptions, so spend can be grouped with a plain join
+CREATE TABLE IF NOT EXISTS service_categories (
+    service_name TEXT PRIMARY KEY,
+    category TEXT NOT NULL CHECK (category <> '')
+);
+
+CREATE INDEX IF NOT EXISTS service_categories_category_idx ON service_categories (category);
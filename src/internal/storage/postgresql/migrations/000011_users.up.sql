@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS users (
+    id UUID PRIMARY KEY,
+    display_name TEXT NOT NULL,
+    email TEXT,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    created_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC')
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_idx ON users (lower(email));
+
+-- owners of stored subscriptions become users without an email, so the foreign key holds
+INSERT INTO users (id, display_name)
+     SELECT DISTINCT owner_id, owner_id::TEXT
+       FROM subscriptions
+ON CONFLICT (id) DO NOTHING;
+
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES users (id);
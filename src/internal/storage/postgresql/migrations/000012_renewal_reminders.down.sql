@@ -0,0 +1 @@
+DROP TABLE IF EXISTS renewal_reminders;
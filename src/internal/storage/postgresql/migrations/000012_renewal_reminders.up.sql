@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS renewal_reminders (
+    subscription_id UUID NOT NULL REFERENCES subscriptions (id) ON DELETE CASCADE,
+    charge_at TIMESTAMP NOT NULL,
+    sent_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    PRIMARY KEY (subscription_id, charge_at)
+);
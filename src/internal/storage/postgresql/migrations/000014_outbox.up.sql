@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id UUID NOT NULL UNIQUE DEFAULT gen_random_uuid(),
+    event_type TEXT NOT NULL,
+    subscription_id UUID NOT NULL,
+    occurred_at TIMESTAMP NOT NULL DEFAULT (NOW() AT TIME ZONE 'UTC'),
+    -- subscription row after the change, NULL for deleted subscriptions
+    payload JSONB,
+    dispatched_at TIMESTAMP,
+    -- failed attempts to publish the event
+    attempts INTEGER NOT NULL DEFAULT 0,
+    -- the event is not published before this moment, NULL for new events; a claim pushes it to the end of the claim
+    next_attempt_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (id) WHERE dispatched_at IS NULL;
+
+-- only the oldest pending event of a subscription is published
+CREATE INDEX IF NOT EXISTS outbox_pending_subscription_idx ON outbox (subscription_id, id) WHERE dispatched_at IS NULL;
+
+-- dispatched events are purged once they are older than the retention
+CREATE INDEX IF NOT EXISTS outbox_dispatched_at_idx ON outbox (dispatched_at) WHERE dispatched_at IS NOT NULL;
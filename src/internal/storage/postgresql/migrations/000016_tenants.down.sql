@@ -0,0 +1,37 @@
+ALTER TABLE api_keys DROP CONSTRAINT IF EXISTS api_keys_user_id_fkey;
+ALTER TABLE api_keys
+    ADD CONSTRAINT api_keys_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_owner_id_fkey;
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_owner_id_fkey FOREIGN KEY (owner_id) REFERENCES users (id);
+
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_id_tenant_id_key;
+
+DROP INDEX IF EXISTS users_email_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_idx ON users (lower(email));
+
+ALTER TABLE service_categories DROP CONSTRAINT IF EXISTS service_categories_pkey;
+ALTER TABLE service_categories ADD PRIMARY KEY (service_name);
+
+ALTER TABLE service_names DROP CONSTRAINT IF EXISTS service_names_pkey;
+ALTER TABLE service_names ADD PRIMARY KEY (name_key);
+
+DROP INDEX IF EXISTS api_keys_tenant_id_idx;
+DROP INDEX IF EXISTS webhooks_tenant_id_idx;
+DROP INDEX IF EXISTS users_tenant_id_idx;
+DROP INDEX IF EXISTS services_tenant_id_idx;
+DROP INDEX IF EXISTS subscription_history_tenant_id_idx;
+
+ALTER TABLE outbox DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE webhooks DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE renewal_reminders DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE users DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE service_categories DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE service_names DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE services DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE subscription_history DROP COLUMN IF EXISTS tenant_id;
+
+DROP INDEX IF EXISTS subscriptions_tenant_id_idx;
+
+ALTER TABLE subscriptions DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,51 @@
+ALTER TABLE subscriptions ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+
+CREATE INDEX IF NOT EXISTS subscriptions_tenant_id_idx ON subscriptions (tenant_id, id);
+
+-- the rest of the data belongs to tenants as well, rows stored before tenants existed go to the default tenant
+ALTER TABLE subscription_history ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE services ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE service_names ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE service_categories ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE renewal_reminders ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS tenant_id TEXT NOT NULL DEFAULT 'default';
+
+-- new rows must name their tenant
+ALTER TABLE subscriptions ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE subscription_history ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE services ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE service_names ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE service_categories ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE users ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE renewal_reminders ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE webhooks ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE outbox ALTER COLUMN tenant_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS subscription_history_tenant_id_idx ON subscription_history (tenant_id, subscription_id, id);
+CREATE INDEX IF NOT EXISTS services_tenant_id_idx ON services (tenant_id, name);
+CREATE INDEX IF NOT EXISTS users_tenant_id_idx ON users (tenant_id, id);
+CREATE INDEX IF NOT EXISTS webhooks_tenant_id_idx ON webhooks (tenant_id, id);
+CREATE INDEX IF NOT EXISTS api_keys_tenant_id_idx ON api_keys (tenant_id, id);
+
+-- service names, categories and emails are unique within a tenant only
+ALTER TABLE service_names DROP CONSTRAINT IF EXISTS service_names_pkey;
+ALTER TABLE service_names ADD PRIMARY KEY (tenant_id, name_key);
+
+ALTER TABLE service_categories DROP CONSTRAINT IF EXISTS service_categories_pkey;
+ALTER TABLE service_categories ADD PRIMARY KEY (tenant_id, service_name);
+
+DROP INDEX IF EXISTS users_email_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS users_email_idx ON users (tenant_id, lower(email));
+
+-- subscriptions and api keys only belong to users of their own tenant
+ALTER TABLE users ADD CONSTRAINT users_id_tenant_id_key UNIQUE (id, tenant_id);
+
+ALTER TABLE subscriptions DROP CONSTRAINT IF EXISTS subscriptions_owner_id_fkey;
+ALTER TABLE subscriptions
+    ADD CONSTRAINT subscriptions_owner_id_fkey FOREIGN KEY (owner_id, tenant_id) REFERENCES users (id, tenant_id);
+
+ALTER TABLE api_keys DROP CONSTRAINT IF EXISTS api_keys_user_id_fkey;
+ALTER TABLE api_keys
+    ADD CONSTRAINT api_keys_user_id_fkey FOREIGN KEY (user_id, tenant_id) REFERENCES users (id, tenant_id) ON DELETE CASCADE;
@@ -0,0 +1,3 @@
+DROP POLICY IF EXISTS subscriptions_tenant_isolation ON subscriptions;
+
+ALTER TABLE subscriptions DISABLE ROW LEVEL SECURITY;
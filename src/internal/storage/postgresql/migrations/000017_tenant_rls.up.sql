@@ -0,0 +1,8 @@
+-- Roles other than the table owner only see the rows of the tenant set with
+-- SET app.tenant_id = '<tenant>'; the application itself filters by tenant explicitly.
+ALTER TABLE subscriptions ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS subscriptions_tenant_isolation ON subscriptions;
+CREATE POLICY subscriptions_tenant_isolation ON subscriptions
+    USING (tenant_id = current_setting('app.tenant_id', true))
+    WITH CHECK (tenant_id = current_setting('app.tenant_id', true));
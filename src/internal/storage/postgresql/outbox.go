@@ -0,0 +1,218 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgconn"
+)
+
+func NewOutboxStorage(c pgsql.Client, log *slog.Logger) storage.OutboxStorage {
+	log = log.With(slog.String("component", "OutboxStorage"))
+	return &outboxStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type outboxStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+func (s *outboxStorage) logSqlQuery(sql string) {
+	pretty := strings.ReplaceAll(sql, "\t", "")
+	s.log.Info("performing query", slog.String("sql", pretty))
+}
+
+func (s *outboxStorage) Claim(ctx context.Context, limit int, claimedUntil time.Time) ([]models.SubscriptionEvent, error) {
+	const op = "storage.postgresql.outbox.Claim"
+	// rows locked by a concurrent claim are skipped, the claim commits before the events are published
+	const sql = `
+		WITH claimed AS (
+			UPDATE outbox
+			   SET next_attempt_at = $3
+			 WHERE id IN (
+					SELECT p.id
+					  FROM outbox p
+					 WHERE p.dispatched_at IS NULL
+					   AND (p.next_attempt_at IS NULL OR p.next_attempt_at <= $2)
+					   AND NOT EXISTS (
+							SELECT 1
+							  FROM outbox e
+							 WHERE e.subscription_id = p.subscription_id
+							   AND e.dispatched_at IS NULL
+							   AND e.id < p.id)
+					 ORDER BY p.id
+					 LIMIT $1
+					   FOR UPDATE SKIP LOCKED)
+			 RETURNING id, event_id, event_type, tenant_id, subscription_id, occurred_at, payload, attempts
+		)
+		SELECT id, event_id, event_type, tenant_id, subscription_id, occurred_at, payload, attempts
+		  FROM claimed
+		 ORDER BY id;`
+
+	s.logSqlQuery(sql)
+	rows, err := s.client.Query(ctx, sql, limit, time.Now().UTC(), claimedUntil.UTC())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var events []models.SubscriptionEvent
+	for rows.Next() {
+		var id int64
+		var e models.SubscriptionEvent
+		var payload []byte
+		var attempts int
+		if err := rows.Scan(&id, &e.ID, &e.Type, &e.Tenant, &e.SubscriptionID, &e.OccurredAt, &payload, &attempts); err != nil {
+			s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		if payload != nil {
+			if e.Subscription, err = subscriptionFromRow(payload); err != nil {
+				s.log.Error("failed to decode event payload", sl.Err(err), slog.String("op", op), slog.Int64("outbox_id", id))
+				return nil, fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		e.Attempt = attempts + 1
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully claimed events", slog.String("op", op), slog.Int("count", len(events)))
+	return events, nil
+}
+
+func (s *outboxStorage) MarkDispatched(ctx context.Context, id uuid.UUID) error {
+	const op = "storage.postgresql.outbox.MarkDispatched"
+	const sql = `
+		UPDATE outbox
+		   SET dispatched_at = $2
+		 WHERE event_id = $1;`
+
+	s.logSqlQuery(sql)
+	if _, err := s.client.Exec(ctx, sql, id, time.Now().UTC()); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *outboxStorage) Retry(ctx context.Context, id uuid.UUID, at time.Time) error {
+	const op = "storage.postgresql.outbox.Retry"
+	const sql = `
+		UPDATE outbox
+		   SET attempts = attempts + 1,
+			   next_attempt_at = $2
+		 WHERE event_id = $1
+		   AND dispatched_at IS NULL;`
+
+	s.logSqlQuery(sql)
+	if _, err := s.client.Exec(ctx, sql, id, at.UTC()); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *outboxStorage) PurgeDispatchedBefore(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgresql.outbox.PurgeDispatchedBefore"
+	const sql = `
+		DELETE FROM outbox
+		 WHERE dispatched_at < $1;`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, before.UTC())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during delete", sl.Err(pgErr), slog.String("op", op))
+			return 0, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute delete", sl.Err(err), slog.String("op", op))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully purged dispatched events", slog.String("op", op), slog.Int64("count", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
+
+// subscriptionRow is a subscriptions row encoded by to_jsonb, timestamps come without a time zone.
+type subscriptionRow struct {
+	ID            uuid.UUID `json:"id"`
+	TenantID      string    `json:"tenant_id"`
+	OwnerID       uuid.UUID `json:"owner_id"`
+	ServiceName   string    `json:"service_name"`
+	Price         int64     `json:"price"`
+	Currency      string    `json:"currency"`
+	BillingPeriod string    `json:"billing_period"`
+	StartTime     string    `json:"start_time"`
+	EndTime       *string   `json:"end_time"`
+	Version       int64     `json:"version"`
+}
+
+func subscriptionFromRow(value []byte) (*models.Subscription, error) {
+	const layout = "2006-01-02T15:04:05.999999999"
+
+	var row subscriptionRow
+	if err := json.Unmarshal(value, &row); err != nil {
+		return nil, fmt.Errorf("can not decode subscription row: %w", err)
+	}
+
+	startTime, err := time.ParseInLocation(layout, row.StartTime, time.UTC)
+	if err != nil {
+		return nil, fmt.Errorf("can not decode subscription start time: %w", err)
+	}
+
+	sub := &models.Subscription{
+		ID:          row.ID,
+		Tenant:      row.TenantID,
+		Owner:       row.OwnerID,
+		ServiceName: row.ServiceName,
+		Price:       models.Money{Amount: row.Price, Currency: models.Currency(row.Currency)},
+		Period:      models.BillingPeriod(row.BillingPeriod),
+		StartedAt:   startTime,
+		Version:     row.Version,
+	}
+	if row.EndTime != nil {
+		endTime, err := time.ParseInLocation(layout, *row.EndTime, time.UTC)
+		if err != nil {
+			return nil, fmt.Errorf("can not decode subscription end time: %w", err)
+		}
+		sub.CompletedAt = &endTime
+	}
+
+	return sub, nil
+}
@@ -0,0 +1,73 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestOutboxClaimsOldestEventOfSubscription(t *testing.T) {
+	c, tenant := testDB(t)
+	s := NewOutboxStorage(c, testLogger())
+	ctx := context.Background()
+
+	// claims take the pending events of other tests as well, only the events of this test are checked
+	sub := uuid.New()
+	var ids []uuid.UUID
+	for range 2 {
+		var id uuid.UUID
+		err := c.QueryRow(ctx, `
+			INSERT INTO outbox (event_type, subscription_id, tenant_id)
+				 VALUES ($1, $2, $3)
+			  RETURNING event_id;`, models.EventSubscriptionUpdated, sub, tenant).Scan(&id)
+		if err != nil {
+			t.Fatalf("insert event error = %v", err)
+		}
+		ids = append(ids, id)
+	}
+	claimed := func() []uuid.UUID {
+		t.Helper()
+
+		events, err := s.Claim(ctx, 1000, time.Now().Add(time.Minute))
+		if err != nil {
+			t.Fatalf("Claim() error = %v", err)
+		}
+		var own []uuid.UUID
+		for _, e := range events {
+			if e.SubscriptionID == sub {
+				own = append(own, e.ID)
+			}
+		}
+		return own
+	}
+
+	if got := claimed(); !slices.Equal(got, ids[:1]) {
+		t.Fatalf("Claim() = %v, want only the oldest event %s", got, ids[0])
+	}
+	if got := claimed(); len(got) != 0 {
+		t.Errorf("Claim() = %v, want the claimed event left alone", got)
+	}
+
+	if err := s.Retry(ctx, ids[0], time.Now().Add(-time.Second)); err != nil {
+		t.Fatalf("Retry() error = %v", err)
+	}
+	events, err := s.Claim(ctx, 1000, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Claim() error = %v", err)
+	}
+	i := slices.IndexFunc(events, func(e models.SubscriptionEvent) bool { return e.ID == ids[0] })
+	if i < 0 || events[i].Attempt != 2 {
+		t.Fatalf("Claim() = %+v, want the retried event on its second attempt", events)
+	}
+
+	if err := s.MarkDispatched(ctx, ids[0]); err != nil {
+		t.Fatalf("MarkDispatched() error = %v", err)
+	}
+	if got := claimed(); !slices.Equal(got, ids[1:]) {
+		t.Errorf("Claim() = %v, want the next event %s", got, ids[1])
+	}
+}
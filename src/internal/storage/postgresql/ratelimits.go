@@ -0,0 +1,124 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	"effective-mobile/pkg/ratelimit"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+)
+
+func NewRateLimitStorage(c pgsql.Client, log *slog.Logger) storage.RateLimitStorage {
+	log = log.With(slog.String("component", "RateLimitStorage"))
+	return &rateLimitStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type rateLimitStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+// logSqlQuery logs at debug level, rate limiting queries run on every request.
+func (s *rateLimitStorage) logSqlQuery(sql string) {
+	pretty := strings.ReplaceAll(sql, "\t", "")
+	s.log.Debug("performing query", slog.String("sql", pretty))
+}
+
+func (s *rateLimitStorage) Take(ctx context.Context, key string, l ratelimit.Limit) (ratelimit.Decision, error) {
+	const op = "storage.postgresql.ratelimits.Take"
+	// the bucket is created full first, so concurrent requests of a new client queue on its row lock
+	const insertSql = `
+		INSERT INTO rate_limit_buckets (key, tokens, updated_at)
+			 VALUES ($1, $2, $3)
+		ON CONFLICT (key) DO NOTHING;`
+	const selectSql = `
+		SELECT tokens, updated_at
+		  FROM rate_limit_buckets
+		 WHERE key = $1
+		   FOR UPDATE;`
+	const updateSql = `
+		UPDATE rate_limit_buckets
+		   SET tokens = $2
+		     , updated_at = $3
+		 WHERE key = $1;`
+
+	tx, err := s.client.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", sl.Err(err), slog.String("op", op))
+		return ratelimit.Decision{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				s.log.Error("failed to rollback transaction", sl.Err(rollbackErr), slog.String("op", op))
+			}
+		}
+	}()
+
+	now := time.Now().UTC()
+	s.logSqlQuery(insertSql)
+	if _, err = tx.Exec(ctx, insertSql, key, l.Capacity(), now); err != nil {
+		return ratelimit.Decision{}, s.queryError(op, key, err)
+	}
+
+	var b ratelimit.Bucket
+	s.logSqlQuery(selectSql)
+	if err = tx.QueryRow(ctx, selectSql, key).Scan(&b.Tokens, &b.UpdatedAt); err != nil {
+		return ratelimit.Decision{}, s.queryError(op, key, err)
+	}
+
+	b, d := l.Take(b, now)
+	s.logSqlQuery(updateSql)
+	if _, err = tx.Exec(ctx, updateSql, key, b.Tokens, b.UpdatedAt); err != nil {
+		return ratelimit.Decision{}, s.queryError(op, key, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", sl.Err(err), slog.String("op", op), slog.String("key", key))
+		return ratelimit.Decision{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return d, nil
+}
+
+func (s *rateLimitStorage) queryError(op, key string, err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op), slog.String("key", key))
+		return fmt.Errorf("%s: database error: %w", op, pgErr)
+	}
+	s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op), slog.String("key", key))
+	return fmt.Errorf("%s: %w", op, err)
+}
+
+func (s *rateLimitStorage) PurgeIdleBefore(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgresql.ratelimits.PurgeIdleBefore"
+	const sql = `
+		DELETE FROM rate_limit_buckets
+		 WHERE updated_at < $1;`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, before.UTC())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during delete", sl.Err(pgErr), slog.String("op", op))
+			return 0, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute delete", sl.Err(err), slog.String("op", op))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully purged idle rate limit buckets", slog.String("op", op), slog.Int64("count", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
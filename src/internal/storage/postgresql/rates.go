@@ -0,0 +1,56 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+func NewExchangeRatesStorage(c pgsql.Client, log *slog.Logger) storage.ExchangeRatesStorage {
+	log = log.With(slog.String("component", "ExchangeRatesStorage"))
+	return &exchangeRatesStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type exchangeRatesStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+func (s *exchangeRatesStorage) FindRate(ctx context.Context, from, to models.Currency, on time.Time) (float64, error) {
+	const op = "storage.postgresql.rates.FindRate"
+	const sql = `
+		SELECT rate
+		  FROM exchange_rates
+		 WHERE base_currency = $1 AND quote_currency = $2 AND rate_date <= $3
+		 ORDER BY rate_date DESC
+		 LIMIT 1;`
+
+	var rate float64
+	err := s.client.QueryRow(ctx, sql, from, to, on.UTC()).Scan(&rate)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during fetch", sl.Err(pgErr), slog.String("op", op))
+			return 0, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, storage.ErrExchangeRateNotFound
+		}
+		s.log.Error("failed to fetch exchange rate", sl.Err(err), slog.String("op", op))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rate, nil
+}
@@ -0,0 +1,77 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgconn"
+)
+
+func NewRemindersStorage(c pgsql.Client, log *slog.Logger) storage.RemindersStorage {
+	log = log.With(slog.String("component", "RemindersStorage"))
+	return &remindersStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type remindersStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+func (s *remindersStorage) logSqlQuery(sql string) {
+	pretty := strings.ReplaceAll(sql, "\t", "")
+	s.log.Info("performing query", slog.String("sql", pretty))
+}
+
+func (s *remindersStorage) Claim(ctx context.Context, tenant models.TenantID, id models.SubscriptionID, chargeAt time.Time) (bool, error) {
+	const op = "storage.postgresql.reminders.Claim"
+	const sql = `
+		INSERT INTO renewal_reminders (subscription_id, charge_at, tenant_id)
+			 VALUES ($1, $2, $3)
+		ON CONFLICT (subscription_id, charge_at) DO NOTHING;`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, id, chargeAt.UTC(), tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during insert", sl.Err(pgErr), slog.String("op", op), slog.Any("subscription_id", id))
+			return false, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute insert", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (s *remindersStorage) Release(ctx context.Context, tenant models.TenantID, id models.SubscriptionID, chargeAt time.Time) error {
+	const op = "storage.postgresql.reminders.Release"
+	const sql = `
+		DELETE FROM renewal_reminders
+		 WHERE subscription_id = $1 AND charge_at = $2 AND tenant_id = $3;`
+
+	s.logSqlQuery(sql)
+	_, err := s.client.Exec(ctx, sql, id, chargeAt.UTC(), tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during delete", sl.Err(pgErr), slog.String("op", op), slog.Any("subscription_id", id))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute delete", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
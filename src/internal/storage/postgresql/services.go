@@ -0,0 +1,344 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// uniqueViolation is the SQLSTATE of a unique constraint violation.
+const uniqueViolation = "23505"
+
+func NewServicesStorage(c pgsql.Client, log *slog.Logger) storage.ServicesStorage {
+	log = log.With(slog.String("component", "ServicesStorage"))
+	return &servicesStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type servicesStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+func (s *servicesStorage) logSqlQuery(sql string) {
+	pretty := strings.ReplaceAll(sql, "\t", "")
+	s.log.Info("performing query", slog.String("sql", pretty))
+}
+
+func (s *servicesStorage) Add(ctx context.Context, svc models.Service) error {
+	const op = "storage.postgresql.services.Add"
+	const sql = `
+		INSERT INTO services (id, name, aliases, default_price, default_currency, tenant_id)
+			 VALUES ($1, $2, $3, $4, $5, $6);`
+
+	tx, err := s.client.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", sl.Err(err), slog.String("op", op))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				s.log.Error("failed to rollback transaction", sl.Err(rollbackErr), slog.String("op", op))
+			}
+		}
+	}()
+
+	price, currency := splitDefaultPrice(svc.DefaultPrice)
+	s.logSqlQuery(sql)
+	if _, err = tx.Exec(ctx, sql, svc.ID, svc.Name, svc.Aliases, price, currency, svc.Tenant); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during insert", sl.Err(pgErr), slog.String("op", op), slog.Any("service_id", svc.ID))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute insert", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.storeNames(ctx, tx, svc); err != nil {
+		s.log.Warn("failed to store service names", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if svc.Category != "" {
+		if err = setCategory(ctx, tx, svc.Tenant, svc.Name, svc.Category); err != nil {
+			s.log.Error("failed to store service category", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully added service", slog.Any("service_id", svc.ID), slog.String("op", op))
+	return nil
+}
+
+func (s *servicesStorage) Update(ctx context.Context, svc models.Service) error {
+	const op = "storage.postgresql.services.Update"
+	const sql = `
+		WITH old AS (
+			SELECT id, name
+			  FROM services
+			 WHERE id = $1 AND tenant_id = $6
+			   FOR UPDATE
+		)
+		UPDATE services
+		   SET name = $2
+		     , aliases = $3
+		     , default_price = $4
+		     , default_currency = $5
+		  FROM old
+		 WHERE services.id = old.id
+		RETURNING old.name;`
+	// the category is kept under the service name, so the mapping of the old name goes away on rename
+	const renameCategorySql = `
+		DELETE FROM service_categories
+		 WHERE service_name = $1 AND tenant_id = $2;`
+	const deleteNamesSql = `
+		DELETE FROM service_names
+		 WHERE service_id = $1;`
+
+	tx, err := s.client.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				s.log.Error("failed to rollback transaction", sl.Err(rollbackErr), slog.String("op", op))
+			}
+		}
+	}()
+
+	var oldName models.ServiceName
+	price, currency := splitDefaultPrice(svc.DefaultPrice)
+	s.logSqlQuery(sql)
+	err = tx.QueryRow(ctx, sql, svc.ID, svc.Name, svc.Aliases, price, currency, svc.Tenant).Scan(&oldName)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op), slog.Any("service_id", svc.ID))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warn("service not found", slog.String("op", op), slog.Any("service_id", svc.ID))
+			err = storage.ErrServiceNotFound
+			return err
+		}
+		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.logSqlQuery(deleteNamesSql)
+	if _, err = tx.Exec(ctx, deleteNamesSql, svc.ID); err != nil {
+		s.log.Error("failed to delete service names", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if err = s.storeNames(ctx, tx, svc); err != nil {
+		s.log.Warn("failed to store service names", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if oldName != svc.Name {
+		s.logSqlQuery(renameCategorySql)
+		if _, err = tx.Exec(ctx, renameCategorySql, oldName, svc.Tenant); err != nil {
+			s.log.Error("failed to drop category of old name", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = setCategory(ctx, tx, svc.Tenant, svc.Name, svc.Category); err != nil {
+		s.log.Error("failed to store service category", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", sl.Err(err), slog.String("op", op), slog.Any("service_id", svc.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully updated service", slog.Any("service_id", svc.ID), slog.String("op", op))
+	return nil
+}
+
+func (s *servicesStorage) RemoveByID(ctx context.Context, tenant models.TenantID, id models.ServiceID) error {
+	const op = "storage.postgresql.services.RemoveByID"
+	const sql = `
+		DELETE FROM services
+		 WHERE id = $1 AND ($2::TEXT = '' OR tenant_id = $2);`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, id, tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during delete", sl.Err(pgErr), slog.String("op", op), slog.Any("service_id", id))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute delete", sl.Err(err), slog.String("op", op), slog.Any("service_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.log.Warn("service not found", slog.String("op", op), slog.Any("service_id", id))
+		return storage.ErrServiceNotFound
+	}
+
+	s.log.Info("successfully removed service", slog.Any("service_id", id), slog.String("op", op))
+	return nil
+}
+
+func (s *servicesStorage) FindByID(ctx context.Context, tenant models.TenantID, id models.ServiceID) (*models.Service, error) {
+	const op = "storage.postgresql.services.FindByID"
+	const sql = `
+		SELECT s.id, s.name, s.aliases, s.default_price, s.default_currency, c.category, s.tenant_id
+		  FROM services s
+		  LEFT JOIN service_categories c ON c.tenant_id = s.tenant_id AND c.service_name = s.name
+		 WHERE s.id = $1 AND ($2::TEXT = '' OR s.tenant_id = $2);`
+
+	s.logSqlQuery(sql)
+	svc, err := scanService(s.client.QueryRow(ctx, sql, id, tenant))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during fetch", sl.Err(pgErr), slog.String("op", op), slog.Any("service_id", id))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warn("service not found", slog.String("op", op), slog.Any("service_id", id))
+			return nil, storage.ErrServiceNotFound
+		}
+		s.log.Error("failed to fetch service", sl.Err(err), slog.String("op", op), slog.Any("service_id", id))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched service", slog.Any("service_id", id), slog.String("op", op))
+	return svc, nil
+}
+
+func (s *servicesStorage) FindByName(ctx context.Context, tenant models.TenantID, nameKey string) (*models.Service, error) {
+	const op = "storage.postgresql.services.FindByName"
+	const sql = `
+		SELECT s.id, s.name, s.aliases, s.default_price, s.default_currency, c.category, s.tenant_id
+		  FROM service_names n
+		  JOIN services s ON s.id = n.service_id
+		  LEFT JOIN service_categories c ON c.tenant_id = s.tenant_id AND c.service_name = s.name
+		 WHERE n.tenant_id = $1 AND n.name_key = $2;`
+
+	s.logSqlQuery(sql)
+	svc, err := scanService(s.client.QueryRow(ctx, sql, tenant, nameKey))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during fetch", sl.Err(pgErr), slog.String("op", op))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Info("service name is not in catalog", slog.String("op", op), slog.String("name_key", nameKey))
+			return nil, storage.ErrServiceNotFound
+		}
+		s.log.Error("failed to fetch service", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully resolved service name", slog.Any("service_id", svc.ID), slog.String("op", op))
+	return svc, nil
+}
+
+func (s *servicesStorage) FindAll(ctx context.Context, tenant models.TenantID) ([]*models.Service, error) {
+	const op = "storage.postgresql.services.FindAll"
+	const sql = `
+		SELECT s.id, s.name, s.aliases, s.default_price, s.default_currency, c.category, s.tenant_id
+		  FROM services s
+		  LEFT JOIN service_categories c ON c.tenant_id = s.tenant_id AND c.service_name = s.name
+		 WHERE $1::TEXT = '' OR s.tenant_id = $1
+		 ORDER BY s.name, s.id;`
+
+	s.logSqlQuery(sql)
+	rows, err := s.client.Query(ctx, sql, tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var services []*models.Service
+	for rows.Next() {
+		svc, err := scanService(rows)
+		if err != nil {
+			s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		services = append(services, svc)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched services", slog.String("op", op), slog.Int("count", len(services)))
+	return services, nil
+}
+
+// storeNames registers every spelling of the service, a spelling owned by another service fails with ErrServiceNameTaken.
+func (s *servicesStorage) storeNames(ctx context.Context, tx pgx.Tx, svc models.Service) error {
+	const sql = `
+		INSERT INTO service_names (name_key, service_id, tenant_id)
+			 SELECT unnest($1::TEXT[]), $2, $3;`
+
+	s.logSqlQuery(sql)
+	_, err := tx.Exec(ctx, sql, svc.Keys(), svc.ID, svc.Tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return storage.ErrServiceNameTaken
+		}
+		return err
+	}
+
+	return nil
+}
+
+func scanService(row pgx.Row) (*models.Service, error) {
+	var svc models.Service
+	var price *int64
+	var currency, category *string
+
+	if err := row.Scan(&svc.ID, &svc.Name, &svc.Aliases, &price, &currency, &category, &svc.Tenant); err != nil {
+		return nil, err
+	}
+	if price != nil && currency != nil {
+		svc.DefaultPrice = &models.Money{Amount: *price, Currency: models.Currency(*currency)}
+	}
+	if category != nil {
+		svc.Category = *category
+	}
+
+	return &svc, nil
+}
+
+func splitDefaultPrice(price *models.Money) (*int64, *models.Currency) {
+	if price == nil {
+		return nil, nil
+	}
+
+	return &price.Amount, &price.Currency
+}
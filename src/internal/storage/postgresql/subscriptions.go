@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"log/slog"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgconn"
@@ -29,6 +30,11 @@ type subscriptionsStorage struct {
 	log    *slog.Logger
 }
 
+// rowQuerier is satisfied by both the client and an open transaction.
+type rowQuerier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
 func (s *subscriptionsStorage) logSqlQuery(sql string) {
 	pretty := strings.ReplaceAll(sql, "\t", "")
 	s.log.Info("performing query", slog.String("sql", pretty))
@@ -37,8 +43,9 @@ func (s *subscriptionsStorage) logSqlQuery(sql string) {
 func (s *subscriptionsStorage) Add(ctx context.Context, sub models.Subscription) error {
 	const op = "storage.postgresql.subscriptions.Add"
 	const sql = `
-		INSERT INTO subscriptions (id, owner_id, service_name, price, is_deleted, start_time, end_time)
-			 VALUES ($1, $2, $3, $4, 0::BIT, $5, $6);`
+		INSERT INTO subscriptions (id, owner_id, service_name, price, currency, billing_period, is_deleted, start_time, end_time, version, tenant_id)
+			 VALUES ($1, $2, $3, $4, $5, $6, 0::BIT, $7, $8, $9, $10)
+		  RETURNING to_jsonb(subscriptions.*);`
 
 	tx, err := s.client.Begin(ctx)
 	if err != nil {
@@ -53,15 +60,17 @@ func (s *subscriptionsStorage) Add(ctx context.Context, sub models.Subscription)
 		}
 	}()
 
-	args := []any{sub.ID, sub.Owner, sub.ServiceName, sub.PriceRUB, sub.StartedAt.UTC()}
+	args := []any{sub.ID, sub.Owner, sub.ServiceName, sub.Price.Amount, sub.Price.Currency, sub.Period, sub.StartedAt.UTC()}
 	if sub.IsCompleted() {
 		args = append(args, sub.CompletedAt.UTC())
 	} else {
 		args = append(args, nil)
 	}
+	args = append(args, sub.Version, sub.Tenant)
 
+	var newValue []byte
 	s.logSqlQuery(sql)
-	_, err = tx.Exec(ctx, sql, args...)
+	err = tx.QueryRow(ctx, sql, args...).Scan(&newValue)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -72,6 +81,16 @@ func (s *subscriptionsStorage) Add(ctx context.Context, sub models.Subscription)
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	if err = s.recordChange(ctx, tx, sub.ID, models.ChangeCreated, nil, newValue); err != nil {
+		s.log.Error("failed to record history", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", sub.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.recordEvent(ctx, tx, models.EventSubscriptionCreated, sub.ID, newValue); err != nil {
+		s.log.Error("failed to record event", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", sub.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		s.log.Error("failed to commit transaction", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", sub.ID))
 		return fmt.Errorf("%s: %w", op, err)
@@ -81,12 +100,89 @@ func (s *subscriptionsStorage) Add(ctx context.Context, sub models.Subscription)
 	return nil
 }
 
-func (s *subscriptionsStorage) RemoveByID(ctx context.Context, id models.SubscriptionID) error {
+func (s *subscriptionsStorage) AddMany(ctx context.Context, subs []models.Subscription) error {
+	const op = "storage.postgresql.subscriptions.AddMany"
+	const sql = `
+		WITH inserted AS (
+			INSERT INTO subscriptions (id, owner_id, service_name, price, currency, billing_period, is_deleted, start_time, end_time, version, tenant_id)
+				 VALUES ($1, $2, $3, $4, $5, $6, 0::BIT, $7, $8, $9, $10)
+			  RETURNING id, tenant_id, to_jsonb(subscriptions.*) AS value
+		), history AS (
+			INSERT INTO subscription_history (subscription_id, action, changed_at, changed_by, request_id, old_value, new_value, tenant_id)
+				 SELECT id, $11, $12, $13, $14, NULL, value, tenant_id
+				   FROM inserted
+		)
+		INSERT INTO outbox (event_type, subscription_id, occurred_at, payload, tenant_id)
+			 SELECT $15, id, $12, value, tenant_id
+			   FROM inserted;`
+
+	tx, err := s.client.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", sl.Err(err), slog.String("op", op))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				s.log.Error("failed to rollback transaction", sl.Err(rollbackErr), slog.String("op", op))
+			}
+		}
+	}()
+
+	meta := storage.ChangeMetaFromContext(ctx)
+	changedAt := time.Now().UTC()
+
+	batch := &pgx.Batch{}
+	for _, sub := range subs {
+		var endTime *time.Time
+		if sub.IsCompleted() {
+			utc := sub.CompletedAt.UTC()
+			endTime = &utc
+		}
+		batch.Queue(sql, sub.ID, sub.Owner, sub.ServiceName, sub.Price.Amount, sub.Price.Currency, sub.Period, sub.StartedAt.UTC(), endTime, sub.Version, sub.Tenant,
+			models.ChangeCreated, changedAt, nullIfEmpty(meta.Actor), nullIfEmpty(meta.RequestID), models.EventSubscriptionCreated)
+	}
+
+	s.logSqlQuery(sql)
+	results := tx.SendBatch(ctx, batch)
+	for i := range subs {
+		if _, err = results.Exec(); err != nil {
+			results.Close()
+			s.log.Error("failed to insert batch item", sl.Err(err), slog.String("op", op), slog.Int("index", i), slog.Any("subscription_id", subs[i].ID))
+			err = &storage.BatchItemError{Index: i, Err: err}
+			return fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	if err = results.Close(); err != nil {
+		s.log.Error("failed to close batch", sl.Err(err), slog.String("op", op))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", sl.Err(err), slog.String("op", op))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully added subscriptions", slog.Int("count", len(subs)), slog.String("op", op))
+	return nil
+}
+
+func (s *subscriptionsStorage) RemoveByID(ctx context.Context, tenant models.TenantID, id models.SubscriptionID, version int64) error {
 	const op = "storage.postgresql.subscriptions.RemoveByID"
 	const sql = `
+		WITH old AS (
+			SELECT id, to_jsonb(s.*) AS value
+			  FROM subscriptions s
+			 WHERE id = $1 AND ($4::TEXT = '' OR tenant_id = $4) AND is_deleted = 0::BIT AND ($3::BIGINT = 0 OR version = $3)
+			   FOR UPDATE
+		)
 		UPDATE subscriptions
 		   SET is_deleted = 1::BIT
-		 WHERE id = $1 AND is_deleted = 0::BIT;`
+		     , deleted_at = $2
+		     , version = subscriptions.version + 1
+		  FROM old
+		 WHERE subscriptions.id = old.id
+		RETURNING old.value, to_jsonb(subscriptions.*);`
 
 	tx, err := s.client.Begin(ctx)
 	if err != nil {
@@ -101,18 +197,34 @@ func (s *subscriptionsStorage) RemoveByID(ctx context.Context, id models.Subscri
 		}
 	}()
 
+	var oldValue, newValue []byte
 	s.logSqlQuery(sql)
-	_, err = tx.Exec(ctx, sql, id)
+	err = tx.QueryRow(ctx, sql, id, time.Now().UTC(), version, tenant).Scan(&oldValue, &newValue)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op), slog.Any("subscription_id", id))
 			return fmt.Errorf("%s: database error: %w", op, pgErr)
 		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = s.missingRowError(ctx, tx, tenant, id, false)
+			s.log.Warn("subscription was not removed", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+			return err
+		}
 		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	if err = s.recordChange(ctx, tx, id, models.ChangeDeleted, oldValue, newValue); err != nil {
+		s.log.Error("failed to record history", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.recordEvent(ctx, tx, models.EventSubscriptionDeleted, id, nil); err != nil {
+		s.log.Error("failed to record event", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		s.log.Error("failed to commit transaction", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
 		return fmt.Errorf("%s: %w", op, err)
@@ -122,17 +234,156 @@ func (s *subscriptionsStorage) RemoveByID(ctx context.Context, id models.Subscri
 	return nil
 }
 
+func (s *subscriptionsStorage) Restore(ctx context.Context, tenant models.TenantID, id models.SubscriptionID) error {
+	const op = "storage.postgresql.subscriptions.Restore"
+	const sql = `
+		WITH old AS (
+			SELECT id, to_jsonb(s.*) AS value
+			  FROM subscriptions s
+			 WHERE id = $1 AND ($2::TEXT = '' OR tenant_id = $2) AND is_deleted = 1::BIT
+			   FOR UPDATE
+		)
+		UPDATE subscriptions
+		   SET is_deleted = 0::BIT
+		     , deleted_at = NULL
+		     , version = subscriptions.version + 1
+		  FROM old
+		 WHERE subscriptions.id = old.id
+		RETURNING old.value, to_jsonb(subscriptions.*);`
+
+	tx, err := s.client.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+				s.log.Error("failed to rollback transaction", sl.Err(rollbackErr), slog.String("op", op))
+			}
+		}
+	}()
+
+	var oldValue, newValue []byte
+	s.logSqlQuery(sql)
+	err = tx.QueryRow(ctx, sql, id, tenant).Scan(&oldValue, &newValue)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op), slog.Any("subscription_id", id))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warn("deleted subscription not found", slog.String("op", op), slog.Any("subscription_id", id))
+			err = storage.ErrSubscriptionNotFound
+			return err
+		}
+		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.recordChange(ctx, tx, id, models.ChangeRestored, oldValue, newValue); err != nil {
+		s.log.Error("failed to record history", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.recordEvent(ctx, tx, models.EventSubscriptionUpdated, id, newValue); err != nil {
+		s.log.Error("failed to record event", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		s.log.Error("failed to commit transaction", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully restored subscription", slog.Any("subscription_id", id), slog.String("op", op))
+	return nil
+}
+
+func (s *subscriptionsStorage) Purge(ctx context.Context, tenant models.TenantID, id models.SubscriptionID, version int64) error {
+	const op = "storage.postgresql.subscriptions.Purge"
+	const sql = `
+		WITH purged AS (
+			DELETE FROM subscriptions
+			 WHERE id = $1 AND ($5::TEXT = '' OR tenant_id = $5) AND ($2::BIGINT = 0 OR version = $2)
+			 RETURNING id, tenant_id
+		)
+		INSERT INTO outbox (event_type, subscription_id, occurred_at, payload, tenant_id)
+			 SELECT $3, id, $4, NULL, tenant_id
+			   FROM purged;`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, id, version, models.EventSubscriptionDeleted, time.Now().UTC(), tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during delete", sl.Err(pgErr), slog.String("op", op), slog.Any("subscription_id", id))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute delete", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		err = s.missingRowError(ctx, s.client, tenant, id, true)
+		s.log.Warn("subscription was not purged", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return err
+	}
+
+	s.log.Info("successfully purged subscription", slog.Any("subscription_id", id), slog.String("op", op))
+	return nil
+}
+
+func (s *subscriptionsStorage) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	const op = "storage.postgresql.subscriptions.PurgeDeletedBefore"
+	const sql = `
+		WITH purged AS (
+			DELETE FROM subscriptions
+			 WHERE is_deleted = 1::BIT AND deleted_at < $1
+			 RETURNING id, tenant_id
+		)
+		INSERT INTO outbox (event_type, subscription_id, occurred_at, payload, tenant_id)
+			 SELECT $2, id, $3, NULL, tenant_id
+			   FROM purged;`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, before.UTC(), models.EventSubscriptionDeleted, time.Now().UTC())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during delete", sl.Err(pgErr), slog.String("op", op))
+			return 0, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute delete", sl.Err(err), slog.String("op", op))
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully purged deleted subscriptions", slog.String("op", op), slog.Int64("count", tag.RowsAffected()))
+	return tag.RowsAffected(), nil
+}
+
 func (s *subscriptionsStorage) Update(ctx context.Context, sub models.Subscription) error {
 	const op = "storage.postgresql.subscriptions.Update"
 	const sql = `
+		WITH old AS (
+			SELECT id, to_jsonb(s.*) AS value
+			  FROM subscriptions s
+			 WHERE id = $8 AND tenant_id = $10 AND version = $9 AND is_deleted = 0::BIT
+			   FOR UPDATE
+		)
 		UPDATE subscriptions
 		   SET 	 
 		   		  owner_id = $1
 		   		, service_name = $2
 				, price = $3
-				, start_time = $4
-				, end_time = $5
-		 WHERE id = $6 AND is_deleted = 0::BIT;`
+				, currency = $4
+				, billing_period = $5
+				, start_time = $6
+				, end_time = $7
+				, version = subscriptions.version + 1
+		  FROM old
+		 WHERE subscriptions.id = old.id
+		RETURNING old.value, to_jsonb(subscriptions.*);`
 
 	tx, err := s.client.Begin(ctx)
 	if err != nil {
@@ -147,26 +398,42 @@ func (s *subscriptionsStorage) Update(ctx context.Context, sub models.Subscripti
 		}
 	}()
 
-	args := []any{sub.Owner, sub.ServiceName, sub.PriceRUB, sub.StartedAt.UTC()}
+	args := []any{sub.Owner, sub.ServiceName, sub.Price.Amount, sub.Price.Currency, sub.Period, sub.StartedAt.UTC()}
 	if sub.IsCompleted() {
 		args = append(args, sub.CompletedAt.UTC())
 	} else {
 		args = append(args, nil)
 	}
-	args = append(args, sub.ID)
+	args = append(args, sub.ID, sub.Version, sub.Tenant)
 
+	var oldValue, newValue []byte
 	s.logSqlQuery(sql)
-	_, err = tx.Exec(ctx, sql, args...)
+	err = tx.QueryRow(ctx, sql, args...).Scan(&oldValue, &newValue)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
 			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op), slog.Any("subscription_id", sub.ID))
 			return fmt.Errorf("%s: database error: %w", op, pgErr)
 		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = s.missingRowError(ctx, tx, sub.Tenant, sub.ID, false)
+			s.log.Warn("subscription was not updated", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", sub.ID))
+			return err
+		}
 		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", sub.ID))
 		return fmt.Errorf("%s: %w", op, err)
 	}
 
+	if err = s.recordChange(ctx, tx, sub.ID, models.ChangeUpdated, oldValue, newValue); err != nil {
+		s.log.Error("failed to record history", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", sub.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.recordEvent(ctx, tx, models.EventSubscriptionUpdated, sub.ID, newValue); err != nil {
+		s.log.Error("failed to record event", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", sub.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		s.log.Error("Failed to commit transaction", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", sub.ID))
 		return fmt.Errorf("%s: %w", op, err)
@@ -176,7 +443,117 @@ func (s *subscriptionsStorage) Update(ctx context.Context, sub models.Subscripti
 	return nil
 }
 
-func (s *subscriptionsStorage) FindByID(ctx context.Context, id models.SubscriptionID) (*models.Subscription, error) {
+func (s *subscriptionsStorage) History(ctx context.Context, tenant models.TenantID, id models.SubscriptionID) ([]*models.SubscriptionChange, error) {
+	const op = "storage.postgresql.subscriptions.History"
+	const sql = `
+		SELECT
+				  id
+				, subscription_id
+				, action
+				, changed_at
+				, changed_by
+				, request_id
+				, old_value
+				, new_value
+		  FROM subscription_history
+		 WHERE subscription_id = $1 AND ($2::TEXT = '' OR tenant_id = $2)
+		 ORDER BY id;`
+
+	s.logSqlQuery(sql)
+	rows, err := s.client.Query(ctx, sql, id, tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op), slog.Any("subscription_id", id))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op), slog.Any("subscription_id", id))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var changes []*models.SubscriptionChange
+	for rows.Next() {
+		var c models.SubscriptionChange
+		err = rows.Scan(&c.ID, &c.SubscriptionID, &c.Action, &c.ChangedAt, &c.ChangedBy, &c.RequestID, (*[]byte)(&c.OldValue), (*[]byte)(&c.NewValue))
+		if err != nil {
+			s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		changes = append(changes, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched subscription history", slog.String("op", op), slog.Any("subscription_id", id), slog.Int("count", len(changes)))
+	return changes, nil
+}
+
+// recordChange appends a history entry inside the transaction that performed the change,
+// the entry belongs to the tenant of the subscription.
+func (s *subscriptionsStorage) recordChange(ctx context.Context, tx pgx.Tx, id models.SubscriptionID, action models.ChangeAction, oldValue, newValue []byte) error {
+	const sql = `
+		INSERT INTO subscription_history (subscription_id, action, changed_at, changed_by, request_id, old_value, new_value, tenant_id)
+			 SELECT $1, $2, $3, $4, $5, $6, $7, tenant_id
+			   FROM subscriptions
+			  WHERE id = $1;`
+
+	meta := storage.ChangeMetaFromContext(ctx)
+
+	s.logSqlQuery(sql)
+	_, err := tx.Exec(ctx, sql, id, action, time.Now().UTC(), nullIfEmpty(meta.Actor), nullIfEmpty(meta.RequestID), oldValue, newValue)
+	return err
+}
+
+// recordEvent writes a lifecycle event to the outbox inside the transaction that performed the change,
+// so the event is published if and only if the change is committed.
+func (s *subscriptionsStorage) recordEvent(ctx context.Context, tx pgx.Tx, t models.EventType, id models.SubscriptionID, payload []byte) error {
+	const sql = `
+		INSERT INTO outbox (event_type, subscription_id, occurred_at, payload, tenant_id)
+			 SELECT $1, $2, $3, $4, tenant_id
+			   FROM subscriptions
+			  WHERE id = $2;`
+
+	s.logSqlQuery(sql)
+	_, err := tx.Exec(ctx, sql, t, id, time.Now().UTC(), payload)
+	return err
+}
+
+// missingRowError tells apart a subscription that does not exist from one whose version has moved on,
+// after a conditional statement affected no rows.
+func (s *subscriptionsStorage) missingRowError(ctx context.Context, q rowQuerier, tenant models.TenantID, id models.SubscriptionID, includeDeleted bool) error {
+	const sql = `
+		SELECT EXISTS (
+			SELECT 1
+			  FROM subscriptions
+			 WHERE id = $1 AND ($3::TEXT = '' OR tenant_id = $3) AND ($2 OR is_deleted = 0::BIT)
+		);`
+
+	var exists bool
+	s.logSqlQuery(sql)
+	if err := q.QueryRow(ctx, sql, id, includeDeleted, tenant).Scan(&exists); err != nil {
+		return fmt.Errorf("can not check subscription existence: %w", err)
+	}
+
+	if exists {
+		return storage.ErrVersionConflict
+	}
+
+	return storage.ErrSubscriptionNotFound
+}
+
+func nullIfEmpty(v string) *string {
+	if v == "" {
+		return nil
+	}
+
+	return &v
+}
+
+func (s *subscriptionsStorage) FindByID(ctx context.Context, tenant models.TenantID, id models.SubscriptionID) (*models.Subscription, error) {
 	const op = "storage.postgresql.subscriptions.FindByID"
 	const sql = `
 		SELECT 
@@ -184,15 +561,20 @@ func (s *subscriptionsStorage) FindByID(ctx context.Context, id models.Subscript
 				, owner_id
 				, service_name
 				, price
+				, currency
+				, billing_period
 				, start_time
 				, end_time
+				, deleted_at
+				, version
+				, tenant_id
 		  FROM subscriptions
-		 WHERE id = $1 AND is_deleted = 0::BIT;`
+		 WHERE id = $1 AND ($2::TEXT = '' OR tenant_id = $2) AND is_deleted = 0::BIT;`
 
 	var sub models.Subscription
 
 	s.logSqlQuery(sql)
-	err := s.client.QueryRow(ctx, sql, id).Scan(&sub.ID, &sub.Owner, &sub.ServiceName, &sub.PriceRUB, &sub.StartedAt, &sub.CompletedAt)
+	err := s.client.QueryRow(ctx, sql, id, tenant).Scan(&sub.ID, &sub.Owner, &sub.ServiceName, &sub.Price.Amount, &sub.Price.Currency, &sub.Period, &sub.StartedAt, &sub.CompletedAt, &sub.DeletedAt, &sub.Version, &sub.Tenant)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) {
@@ -213,20 +595,146 @@ func (s *subscriptionsStorage) FindByID(ctx context.Context, id models.Subscript
 
 func (s *subscriptionsStorage) Find(ctx context.Context, f storage.SubscriptionsFilter) ([]*models.Subscription, error) {
 	const op = "storage.postgresql.subscriptions.Find"
+
+	sql, args := buildFindQuery(f)
+	s.logSqlQuery(sql)
+	rows, err := s.client.Query(ctx, sql+";", args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op), slog.Any("owner_id", f.OwnerID))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op), slog.Any("owner_id", f.OwnerID))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var subs []*models.Subscription
+	for rows.Next() {
+		var sub models.Subscription
+		err = rows.Scan(&sub.ID, &sub.Owner, &sub.ServiceName, &sub.Price.Amount, &sub.Price.Currency, &sub.Period, &sub.StartedAt, &sub.CompletedAt, &sub.DeletedAt, &sub.Version, &sub.Tenant)
+		if err != nil {
+			s.log.Warn("failed to scan row, continuing", sl.Err(err), slog.String("op", op))
+			continue
+		}
+		subs = append(subs, &sub)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched subscriptions", slog.String("op", op), slog.Any("owner_id", f.OwnerID), slog.Int("count", len(subs)))
+	return subs, nil
+}
+
+func (s *subscriptionsStorage) Stream(ctx context.Context, f storage.SubscriptionsFilter, fn func(*models.Subscription) error) error {
+	const op = "storage.postgresql.subscriptions.Stream"
+	const fetchSql = `FETCH FORWARD 500 FROM subscriptions_stream;`
+
+	tx, err := s.client.Begin(ctx)
+	if err != nil {
+		s.log.Error("failed to begin transaction", sl.Err(err), slog.String("op", op))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	// the transaction only holds the cursor, nothing has to be committed
+	defer func() {
+		if rollbackErr := tx.Rollback(ctx); rollbackErr != nil && !errors.Is(rollbackErr, pgx.ErrTxClosed) {
+			s.log.Error("failed to rollback transaction", sl.Err(rollbackErr), slog.String("op", op))
+		}
+	}()
+
+	query, args := buildFindQuery(f)
+	declareSql := "DECLARE subscriptions_stream NO SCROLL CURSOR FOR " + query + ";"
+	s.logSqlQuery(declareSql)
+	if _, err = tx.Exec(ctx, declareSql, args...); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during declare", sl.Err(pgErr), slog.String("op", op))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to declare cursor", sl.Err(err), slog.String("op", op))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	total := 0
+	for {
+		rows, err := tx.Query(ctx, fetchSql)
+		if err != nil {
+			s.log.Error("failed to fetch from cursor", sl.Err(err), slog.String("op", op))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		fetched := 0
+		for rows.Next() {
+			var sub models.Subscription
+			err = rows.Scan(&sub.ID, &sub.Owner, &sub.ServiceName, &sub.Price.Amount, &sub.Price.Currency, &sub.Period, &sub.StartedAt, &sub.CompletedAt, &sub.DeletedAt, &sub.Version, &sub.Tenant)
+			if err != nil {
+				rows.Close()
+				s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+				return fmt.Errorf("%s: %w", op, err)
+			}
+			fetched++
+			if err = fn(&sub); err != nil {
+				rows.Close()
+				return fmt.Errorf("%s: %w", op, err)
+			}
+		}
+		rows.Close()
+		if err = rows.Err(); err != nil {
+			s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		total += fetched
+		if fetched == 0 {
+			break
+		}
+	}
+
+	s.log.Info("successfully streamed subscriptions", slog.String("op", op), slog.Int("count", total))
+	return nil
+}
+
+// buildFindQuery renders the filtered and ordered select used by Find and Stream, without a terminating semicolon.
+func buildFindQuery(f storage.SubscriptionsFilter) (string, []interface{}) {
 	const sqlBase = `
 		SELECT 
 				  id
 				, owner_id
 				, service_name
 				, price
+				, currency
+				, billing_period
 				, start_time
 				, end_time
+				, deleted_at
+				, version
+				, tenant_id
 		FROM subscriptions
-		WHERE is_deleted = 0::BIT`
+		WHERE is_deleted = `
 
 	sqlB := strings.Builder{}
 	sqlB.WriteString(sqlBase)
-	args := make([]interface{}, 0, 4)
+	if f.Deleted {
+		sqlB.WriteString("1::BIT")
+	} else {
+		sqlB.WriteString("0::BIT")
+	}
+	args := writeFilterPredicates(&sqlB, f, make([]interface{}, 0, 8))
+	args = writeKeysetOrdering(&sqlB, f, args)
+
+	return sqlB.String(), args
+}
+
+// writeFilterPredicates appends the filter conditions to a query that already has a WHERE clause.
+func writeFilterPredicates(sqlB *strings.Builder, f storage.SubscriptionsFilter, args []interface{}) []interface{} {
+	if f.Tenant != "" {
+		sqlB.WriteString(fmt.Sprintf(" AND tenant_id = $%d", len(args)+1))
+		args = append(args, f.Tenant)
+	}
 
 	if f.OwnerID != uuid.Nil {
 		sqlB.WriteString(fmt.Sprintf(" AND owner_id = $%d", len(args)+1))
@@ -238,47 +746,115 @@ func (s *subscriptionsStorage) Find(ctx context.Context, f storage.Subscriptions
 		args = append(args, f.ServiceName)
 	}
 
-	if f.StartTime != nil {
-		sqlB.WriteString(fmt.Sprintf(" AND start_time >= $%d", len(args)+1))
-		args = append(args, f.StartTime.UTC())
+	if f.Category != "" {
+		sqlB.WriteString(fmt.Sprintf(" AND (tenant_id, service_name) IN (SELECT tenant_id, service_name FROM service_categories WHERE category = $%d)", len(args)+1))
+		args = append(args, f.Category)
+	}
+
+	args = writeRangePredicates(sqlB, f, args)
+
+	if f.ActiveAt != nil {
+		sqlB.WriteString(fmt.Sprintf(" AND start_time <= $%d AND (end_time IS NULL OR end_time >= $%d)", len(args)+1, len(args)+1))
+		args = append(args, f.ActiveAt.UTC())
 	}
 
-	if f.EndTime != nil {
-		sqlB.WriteString(fmt.Sprintf(" AND start_time <= $%d", len(args)+1))
-		args = append(args, f.EndTime.UTC())
+	if f.Currency != "" {
+		sqlB.WriteString(fmt.Sprintf(" AND currency = $%d", len(args)+1))
+		args = append(args, f.Currency)
 	}
 
-	sqlB.WriteString((" ORDER BY id;"))
-	sql := sqlB.String()
-	s.logSqlQuery(sql)
-	rows, err := s.client.Query(ctx, sql, args...)
-	if err != nil {
-		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) {
-			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op), slog.Any("owner_id", f.OwnerID))
-			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+	if f.MinPrice != nil {
+		sqlB.WriteString(fmt.Sprintf(" AND price >= $%d", len(args)+1))
+		args = append(args, *f.MinPrice)
+	}
+
+	if f.MaxPrice != nil {
+		sqlB.WriteString(fmt.Sprintf(" AND price <= $%d", len(args)+1))
+		args = append(args, *f.MaxPrice)
+	}
+
+	return args
+}
+
+// writeRangePredicates appends the conditions matching the StartTime/EndTime window in the filter's range mode.
+func writeRangePredicates(sqlB *strings.Builder, f storage.SubscriptionsFilter, args []interface{}) []interface{} {
+	switch f.Range {
+	case storage.RangeActiveDuring:
+		if f.StartTime != nil {
+			sqlB.WriteString(fmt.Sprintf(" AND (end_time IS NULL OR end_time >= $%d)", len(args)+1))
+			args = append(args, f.StartTime.UTC())
+		}
+		if f.EndTime != nil {
+			sqlB.WriteString(fmt.Sprintf(" AND start_time <= $%d", len(args)+1))
+			args = append(args, f.EndTime.UTC())
+		}
+	case storage.RangeEndedWithin:
+		sqlB.WriteString(" AND end_time IS NOT NULL")
+		if f.StartTime != nil {
+			sqlB.WriteString(fmt.Sprintf(" AND end_time >= $%d", len(args)+1))
+			args = append(args, f.StartTime.UTC())
+		}
+		if f.EndTime != nil {
+			sqlB.WriteString(fmt.Sprintf(" AND end_time <= $%d", len(args)+1))
+			args = append(args, f.EndTime.UTC())
+		}
+	default:
+		if f.StartTime != nil {
+			sqlB.WriteString(fmt.Sprintf(" AND start_time >= $%d", len(args)+1))
+			args = append(args, f.StartTime.UTC())
+		}
+		if f.EndTime != nil {
+			sqlB.WriteString(fmt.Sprintf(" AND start_time <= $%d", len(args)+1))
+			args = append(args, f.EndTime.UTC())
 		}
-		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op), slog.Any("owner_id", f.OwnerID))
-		return nil, fmt.Errorf("%s: %w", op, err)
 	}
-	defer rows.Close()
 
-	var subs []*models.Subscription
-	for rows.Next() {
-		var sub models.Subscription
-		err = rows.Scan(&sub.ID, &sub.Owner, &sub.ServiceName, &sub.PriceRUB, &sub.StartedAt, &sub.CompletedAt)
-		if err != nil {
-			s.log.Warn("failed to scan row, continuing", sl.Err(err), slog.String("op", op))
-			continue
+	return args
+}
+
+// writeKeysetOrdering appends the cursor condition, ORDER BY and LIMIT clauses.
+// The id is always the last sort key, so rows with equal sort values keep a stable order.
+func writeKeysetOrdering(sqlB *strings.Builder, f storage.SubscriptionsFilter, args []interface{}) []interface{} {
+	cmp, dir := ">", "ASC"
+	if f.Sort.Desc {
+		cmp, dir = "<", "DESC"
+	}
+
+	var column string
+	var cursorValue any
+	switch f.Sort.Field {
+	case storage.SortByStartTime:
+		column = "start_time"
+		if f.After != nil {
+			cursorValue = f.After.StartTime.UTC()
+		}
+	case storage.SortByPrice:
+		column = "price"
+		if f.After != nil {
+			cursorValue = f.After.Price
 		}
-		subs = append(subs, &sub)
 	}
 
-	if err = rows.Err(); err != nil {
-		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
-		return nil, fmt.Errorf("%s: %w", op, err)
+	if f.After != nil {
+		if column == "" {
+			sqlB.WriteString(fmt.Sprintf(" AND id %s $%d", cmp, len(args)+1))
+			args = append(args, f.After.ID)
+		} else {
+			sqlB.WriteString(fmt.Sprintf(" AND (%s, id) %s ($%d, $%d)", column, cmp, len(args)+1, len(args)+2))
+			args = append(args, cursorValue, f.After.ID)
+		}
 	}
 
-	s.log.Info("successfully fetched subscriptions", slog.String("op", op), slog.Any("owner_id", f.OwnerID), slog.Int("count", len(subs)))
-	return subs, nil
+	if column == "" {
+		sqlB.WriteString(fmt.Sprintf(" ORDER BY id %s", dir))
+	} else {
+		sqlB.WriteString(fmt.Sprintf(" ORDER BY %s %s, id %s", column, dir, dir))
+	}
+
+	if f.Limit > 0 {
+		sqlB.WriteString(fmt.Sprintf(" LIMIT $%d", len(args)+1))
+		args = append(args, f.Limit)
+	}
+
+	return args
 }
@@ -0,0 +1,211 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func addTestSubscription(t *testing.T, s storage.SubscriptionsStorage, tenant models.TenantID, owner models.PersonID, price models.Money, start time.Time, end *time.Time) models.Subscription {
+	t.Helper()
+
+	sub := models.Subscription{
+		ID:          uuid.New(),
+		Tenant:      tenant,
+		ServiceName: "Netflix",
+		Price:       price,
+		StartedAt:   start,
+		CompletedAt: end,
+		Owner:       owner,
+		Period:      models.BillingMonthly,
+		Version:     1,
+	}
+	if err := s.Add(context.Background(), sub); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	return sub
+}
+
+func findIDs(t *testing.T, s storage.SubscriptionsStorage, f storage.SubscriptionsFilter) []models.SubscriptionID {
+	t.Helper()
+
+	subs, err := s.Find(context.Background(), f)
+	if err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+
+	ids := make([]models.SubscriptionID, 0, len(subs))
+	for _, sub := range subs {
+		ids = append(ids, sub.ID)
+	}
+	return ids
+}
+
+func sameIDs(got, want []models.SubscriptionID) bool {
+	if len(got) != len(want) {
+		return false
+	}
+
+	less := func(ids []models.SubscriptionID) func(i, j int) bool {
+		return func(i, j int) bool { return ids[i].String() < ids[j].String() }
+	}
+	sort.Slice(got, less(got))
+	sort.Slice(want, less(want))
+	for i := range got {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestSubscriptionsFindRangeModes(t *testing.T) {
+	c, tenant := testDB(t)
+	s := NewSubscriptionStorage(c, testLogger())
+	owner := testUser(t, c, tenant)
+	rub := models.Money{Amount: 10000, Currency: "RUB"}
+
+	// the window is March 2024, the first and the last subscription never match it
+	addTestSubscription(t, s, tenant, owner, rub, date(2024, 1, 1), ptr(date(2024, 2, 1)))
+	spanning := addTestSubscription(t, s, tenant, owner, rub, date(2024, 1, 1), nil)
+	endedWithin := addTestSubscription(t, s, tenant, owner, rub, date(2024, 2, 1), ptr(date(2024, 3, 15)))
+	startedWithin := addTestSubscription(t, s, tenant, owner, rub, date(2024, 3, 10), ptr(date(2024, 6, 1)))
+	addTestSubscription(t, s, tenant, owner, rub, date(2024, 5, 1), nil)
+
+	from, to := date(2024, 3, 1), date(2024, 3, 31)
+	tests := []struct {
+		mode storage.RangeMode
+		want []models.SubscriptionID
+	}{
+		{mode: storage.RangeStartedWithin, want: []models.SubscriptionID{startedWithin.ID}},
+		{mode: storage.RangeActiveDuring, want: []models.SubscriptionID{spanning.ID, endedWithin.ID, startedWithin.ID}},
+		{mode: storage.RangeEndedWithin, want: []models.SubscriptionID{endedWithin.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			got := findIDs(t, s, storage.SubscriptionsFilter{Tenant: tenant, StartTime: &from, EndTime: &to, Range: tt.mode})
+			if !sameIDs(got, tt.want) {
+				t.Errorf("Find() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionsFindPriceWithinCurrency(t *testing.T) {
+	c, tenant := testDB(t)
+	s := NewSubscriptionStorage(c, testLogger())
+	owner := testUser(t, c, tenant)
+
+	rub := addTestSubscription(t, s, tenant, owner, models.Money{Amount: 50000, Currency: "RUB"}, date(2024, 1, 1), nil)
+	addTestSubscription(t, s, tenant, owner, models.Money{Amount: 1000, Currency: "USD"}, date(2024, 1, 1), nil)
+
+	minPrice := int64(500)
+	got := findIDs(t, s, storage.SubscriptionsFilter{Tenant: tenant, MinPrice: &minPrice, Currency: "RUB"})
+	if !sameIDs(got, []models.SubscriptionID{rub.ID}) {
+		t.Errorf("Find() = %v, want only the RUB subscription %v", got, rub.ID)
+	}
+}
+
+func TestSubscriptionsTenantIsolation(t *testing.T) {
+	c, tenant := testDB(t)
+	_, other := testDB(t)
+	s := NewSubscriptionStorage(c, testLogger())
+	owner := testUser(t, c, tenant)
+
+	sub := addTestSubscription(t, s, tenant, owner, models.Money{Amount: 100, Currency: "RUB"}, date(2024, 1, 1), nil)
+
+	if _, err := s.FindByID(context.Background(), other, sub.ID); !errors.Is(err, storage.ErrSubscriptionNotFound) {
+		t.Errorf("FindByID(other tenant) error = %v, want %v", err, storage.ErrSubscriptionNotFound)
+	}
+	if got := findIDs(t, s, storage.SubscriptionsFilter{Tenant: other}); len(got) != 0 {
+		t.Errorf("Find(other tenant) = %v, want none", got)
+	}
+	if changes, err := s.History(context.Background(), other, sub.ID); err != nil || len(changes) != 0 {
+		t.Errorf("History(other tenant) = %v, %v, want no changes", changes, err)
+	}
+}
+
+func TestSubscriptionsLifecycle(t *testing.T) {
+	c, tenant := testDB(t)
+	s := NewSubscriptionStorage(c, testLogger())
+	owner := testUser(t, c, tenant)
+	ctx := context.Background()
+
+	sub := addTestSubscription(t, s, tenant, owner, models.Money{Amount: 100, Currency: "RUB"}, date(2024, 1, 1), nil)
+
+	changed := sub
+	changed.Price.Amount = 200
+	if err := s.Update(ctx, changed); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	if err := s.Update(ctx, changed); !errors.Is(err, storage.ErrVersionConflict) {
+		t.Fatalf("Update(stale version) error = %v, want %v", err, storage.ErrVersionConflict)
+	}
+
+	stored := mustFindByID(t, c, tenant, sub.ID)
+	if stored.Price.Amount != 200 || stored.Version != 2 {
+		t.Errorf("stored price %d version %d, want price 200 version 2", stored.Price.Amount, stored.Version)
+	}
+
+	if err := s.RemoveByID(ctx, tenant, sub.ID, 1); !errors.Is(err, storage.ErrVersionConflict) {
+		t.Fatalf("RemoveByID(stale version) error = %v, want %v", err, storage.ErrVersionConflict)
+	}
+	if err := s.RemoveByID(ctx, tenant, sub.ID, 2); err != nil {
+		t.Fatalf("RemoveByID() error = %v", err)
+	}
+	if _, err := s.FindByID(ctx, tenant, sub.ID); !errors.Is(err, storage.ErrSubscriptionNotFound) {
+		t.Errorf("FindByID(deleted) error = %v, want %v", err, storage.ErrSubscriptionNotFound)
+	}
+	if got := findIDs(t, s, storage.SubscriptionsFilter{Tenant: tenant, Deleted: true}); !sameIDs(got, []models.SubscriptionID{sub.ID}) {
+		t.Errorf("Find(deleted) = %v, want %v", got, sub.ID)
+	}
+
+	if err := s.Restore(ctx, tenant, sub.ID); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	mustFindByID(t, c, tenant, sub.ID)
+
+	history, err := s.History(ctx, tenant, sub.ID)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	wantActions := []models.ChangeAction{models.ChangeCreated, models.ChangeUpdated, models.ChangeDeleted, models.ChangeRestored}
+	if len(history) != len(wantActions) {
+		t.Fatalf("History() returned %d changes, want %d", len(history), len(wantActions))
+	}
+	for i, change := range history {
+		if change.Action != wantActions[i] {
+			t.Errorf("change %d action = %q, want %q", i, change.Action, wantActions[i])
+		}
+	}
+
+	if err := s.Purge(ctx, tenant, sub.ID, 0); err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+	if _, err := s.FindByID(ctx, tenant, sub.ID); !errors.Is(err, storage.ErrSubscriptionNotFound) {
+		t.Errorf("FindByID(purged) error = %v, want %v", err, storage.ErrSubscriptionNotFound)
+	}
+}
+
+func mustFindByID(t *testing.T, c pgsql.Client, tenant models.TenantID, id models.SubscriptionID) *models.Subscription {
+	t.Helper()
+
+	sub, err := NewSubscriptionStorage(c, testLogger()).FindByID(context.Background(), tenant, id)
+	if err != nil {
+		t.Fatalf("FindByID() error = %v", err)
+	}
+	return sub
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,96 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"errors"
+	"testing"
+)
+
+func TestUsersTenantIsolation(t *testing.T) {
+	c, tenant := testDB(t)
+	_, other := testDB(t)
+	s := NewUsersStorage(c, testLogger())
+	ctx := context.Background()
+
+	id := testUser(t, c, tenant)
+
+	if _, err := s.FindByID(ctx, other, id); !errors.Is(err, storage.ErrUserNotFound) {
+		t.Errorf("FindByID(other tenant) error = %v, want %v", err, storage.ErrUserNotFound)
+	}
+	if users, err := s.Find(ctx, storage.UsersFilter{Tenant: other, Limit: 10}); err != nil || len(users) != 0 {
+		t.Errorf("Find(other tenant) = %v, %v, want no users", users, err)
+	}
+	if err := s.RemoveByID(ctx, other, id); !errors.Is(err, storage.ErrUserNotFound) {
+		t.Errorf("RemoveByID(other tenant) error = %v, want %v", err, storage.ErrUserNotFound)
+	}
+	if _, err := s.FindByID(ctx, tenant, id); err != nil {
+		t.Errorf("FindByID() error = %v, want the user kept", err)
+	}
+}
+
+func TestWebhooksTenantIsolation(t *testing.T) {
+	c, tenant := testDB(t)
+	_, other := testDB(t)
+	s := NewWebhooksStorage(c, testLogger())
+	ctx := context.Background()
+
+	w, err := models.NewWebhook("https://example.com/hook", "s3cret", []models.EventType{models.EventSubscriptionCreated})
+	if err != nil {
+		t.Fatalf("NewWebhook() error = %v", err)
+	}
+	w.Tenant = tenant
+	if err := s.Add(ctx, *w); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	found, err := s.FindByEvent(ctx, other, models.EventSubscriptionCreated)
+	if err != nil || len(found) != 0 {
+		t.Errorf("FindByEvent(other tenant) = %v, %v, want no webhooks", found, err)
+	}
+	found, err = s.FindByEvent(ctx, tenant, models.EventSubscriptionCreated)
+	if err != nil || len(found) != 1 || found[0].ID != w.ID {
+		t.Errorf("FindByEvent() = %v, %v, want webhook %s", found, err, w.ID)
+	}
+	if _, err := s.FindByID(ctx, other, w.ID); !errors.Is(err, storage.ErrWebhookNotFound) {
+		t.Errorf("FindByID(other tenant) error = %v, want %v", err, storage.ErrWebhookNotFound)
+	}
+}
+
+func TestServicesTenantIsolation(t *testing.T) {
+	c, tenant := testDB(t)
+	_, other := testDB(t)
+	services := NewServicesStorage(c, testLogger())
+	categories := NewCategoriesStorage(c, testLogger())
+	ctx := context.Background()
+
+	// the same name is free in the catalog of every tenant
+	for _, tn := range []models.TenantID{tenant, other} {
+		svc, err := models.NewService("Netflix", nil, nil, "")
+		if err != nil {
+			t.Fatalf("NewService() error = %v", err)
+		}
+		svc.Tenant = tn
+		if err := services.Add(ctx, *svc); err != nil {
+			t.Fatalf("Add(%s) error = %v", tn, err)
+		}
+	}
+	if err := categories.Set(ctx, models.ServiceCategory{Tenant: tenant, ServiceName: "Netflix", Category: "video"}); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	svc, err := services.FindByName(ctx, other, "netflix")
+	if err != nil {
+		t.Fatalf("FindByName() error = %v", err)
+	}
+	if svc.Tenant != other || svc.Category != "" {
+		t.Errorf("FindByName(other tenant) = %+v, want the uncategorized service of the tenant", svc)
+	}
+	if _, err := categories.Find(ctx, other, "Netflix"); !errors.Is(err, storage.ErrCategoryNotFound) {
+		t.Errorf("Find(other tenant) error = %v, want %v", err, storage.ErrCategoryNotFound)
+	}
+	if all, err := services.FindAll(ctx, tenant); err != nil || len(all) != 1 || all[0].Category != "video" {
+		t.Errorf("FindAll() = %v, %v, want the categorized service of the tenant only", all, err)
+	}
+}
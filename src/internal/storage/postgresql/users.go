@@ -0,0 +1,225 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+// foreignKeyViolation is the SQLSTATE of a foreign key constraint violation.
+const foreignKeyViolation = "23503"
+
+func NewUsersStorage(c pgsql.Client, log *slog.Logger) storage.UsersStorage {
+	log = log.With(slog.String("component", "UsersStorage"))
+	return &usersStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type usersStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+func (s *usersStorage) logSqlQuery(sql string) {
+	pretty := strings.ReplaceAll(sql, "\t", "")
+	s.log.Info("performing query", slog.String("sql", pretty))
+}
+
+func (s *usersStorage) Add(ctx context.Context, u models.User) error {
+	const op = "storage.postgresql.users.Add"
+	const sql = `
+		INSERT INTO users (id, display_name, email, timezone, created_at, tenant_id)
+			 VALUES ($1, $2, $3, $4, $5, $6);`
+
+	s.logSqlQuery(sql)
+	_, err := s.client.Exec(ctx, sql, u.ID, u.DisplayName, nullIfEmpty(u.Email), u.Timezone, u.CreatedAt.UTC(), u.Tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == uniqueViolation {
+				s.log.Warn("user email is taken", slog.String("op", op), slog.Any("user_id", u.ID))
+				return storage.ErrUserEmailTaken
+			}
+			s.log.Error("database error during insert", sl.Err(pgErr), slog.String("op", op), slog.Any("user_id", u.ID))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute insert", sl.Err(err), slog.String("op", op), slog.Any("user_id", u.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully added user", slog.Any("user_id", u.ID), slog.String("op", op))
+	return nil
+}
+
+func (s *usersStorage) Update(ctx context.Context, u models.User) error {
+	const op = "storage.postgresql.users.Update"
+	const sql = `
+		UPDATE users
+		   SET display_name = $2
+		     , email = $3
+		     , timezone = $4
+		 WHERE id = $1 AND tenant_id = $5;`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, u.ID, u.DisplayName, nullIfEmpty(u.Email), u.Timezone, u.Tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == uniqueViolation {
+				s.log.Warn("user email is taken", slog.String("op", op), slog.Any("user_id", u.ID))
+				return storage.ErrUserEmailTaken
+			}
+			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op), slog.Any("user_id", u.ID))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op), slog.Any("user_id", u.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.log.Warn("user not found", slog.String("op", op), slog.Any("user_id", u.ID))
+		return storage.ErrUserNotFound
+	}
+
+	s.log.Info("successfully updated user", slog.Any("user_id", u.ID), slog.String("op", op))
+	return nil
+}
+
+func (s *usersStorage) RemoveByID(ctx context.Context, tenant models.TenantID, id models.PersonID) error {
+	const op = "storage.postgresql.users.RemoveByID"
+	const sql = `
+		DELETE FROM users
+		 WHERE id = $1 AND ($2::TEXT = '' OR tenant_id = $2);`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, id, tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			if pgErr.Code == foreignKeyViolation {
+				s.log.Warn("user still has subscriptions", slog.String("op", op), slog.Any("user_id", id))
+				return storage.ErrUserHasSubscriptions
+			}
+			s.log.Error("database error during delete", sl.Err(pgErr), slog.String("op", op), slog.Any("user_id", id))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute delete", sl.Err(err), slog.String("op", op), slog.Any("user_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.log.Warn("user not found", slog.String("op", op), slog.Any("user_id", id))
+		return storage.ErrUserNotFound
+	}
+
+	s.log.Info("successfully removed user", slog.Any("user_id", id), slog.String("op", op))
+	return nil
+}
+
+func (s *usersStorage) FindByID(ctx context.Context, tenant models.TenantID, id models.PersonID) (*models.User, error) {
+	const op = "storage.postgresql.users.FindByID"
+	const sql = `
+		SELECT id, display_name, email, timezone, created_at, tenant_id
+		  FROM users
+		 WHERE id = $1 AND ($2::TEXT = '' OR tenant_id = $2);`
+
+	s.logSqlQuery(sql)
+	u, err := scanUser(s.client.QueryRow(ctx, sql, id, tenant))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during fetch", sl.Err(pgErr), slog.String("op", op), slog.Any("user_id", id))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warn("user not found", slog.String("op", op), slog.Any("user_id", id))
+			return nil, storage.ErrUserNotFound
+		}
+		s.log.Error("failed to fetch user", sl.Err(err), slog.String("op", op), slog.Any("user_id", id))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched user", slog.Any("user_id", id), slog.String("op", op))
+	return u, nil
+}
+
+func (s *usersStorage) Find(ctx context.Context, f storage.UsersFilter) ([]*models.User, error) {
+	const op = "storage.postgresql.users.Find"
+	const sqlBase = `
+		SELECT id, display_name, email, timezone, created_at, tenant_id
+		  FROM users
+		 WHERE TRUE`
+
+	sqlB := strings.Builder{}
+	sqlB.WriteString(sqlBase)
+	args := make([]interface{}, 0, 3)
+	if f.Tenant != "" {
+		sqlB.WriteString(fmt.Sprintf(" AND tenant_id = $%d", len(args)+1))
+		args = append(args, f.Tenant)
+	}
+	if f.After != nil {
+		sqlB.WriteString(fmt.Sprintf(" AND id > $%d", len(args)+1))
+		args = append(args, *f.After)
+	}
+	sqlB.WriteString(" ORDER BY id")
+	if f.Limit > 0 {
+		sqlB.WriteString(fmt.Sprintf(" LIMIT $%d", len(args)+1))
+		args = append(args, f.Limit)
+	}
+	sqlB.WriteString(";")
+
+	sql := sqlB.String()
+	s.logSqlQuery(sql)
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var users []*models.User
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		users = append(users, u)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched users", slog.String("op", op), slog.Int("count", len(users)))
+	return users, nil
+}
+
+func scanUser(row pgx.Row) (*models.User, error) {
+	var u models.User
+	var email *string
+
+	if err := row.Scan(&u.ID, &u.DisplayName, &email, &u.Timezone, &u.CreatedAt, &u.Tenant); err != nil {
+		return nil, err
+	}
+	if email != nil {
+		u.Email = *email
+	}
+
+	return &u, nil
+}
@@ -0,0 +1,282 @@
+package postgresql
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"effective-mobile/internal/storage"
+	"effective-mobile/pkg/logger/sl"
+	pgsql "effective-mobile/pkg/storage/postgresql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+func NewWebhooksStorage(c pgsql.Client, log *slog.Logger) storage.WebhooksStorage {
+	log = log.With(slog.String("component", "WebhooksStorage"))
+	return &webhooksStorage{
+		client: c,
+		log:    log,
+	}
+}
+
+type webhooksStorage struct {
+	client pgsql.Client
+	log    *slog.Logger
+}
+
+func (s *webhooksStorage) logSqlQuery(sql string) {
+	pretty := strings.ReplaceAll(sql, "\t", "")
+	s.log.Info("performing query", slog.String("sql", pretty))
+}
+
+func (s *webhooksStorage) Add(ctx context.Context, w models.Webhook) error {
+	const op = "storage.postgresql.webhooks.Add"
+	const sql = `
+		INSERT INTO webhooks (id, url, secret, events, created_at, tenant_id)
+			 VALUES ($1, $2, $3, $4, $5, $6);`
+
+	s.logSqlQuery(sql)
+	_, err := s.client.Exec(ctx, sql, w.ID, w.URL, w.Secret, eventTypesToStrings(w.Events), w.CreatedAt.UTC(), w.Tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during insert", sl.Err(pgErr), slog.String("op", op), slog.Any("webhook_id", w.ID))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute insert", sl.Err(err), slog.String("op", op), slog.Any("webhook_id", w.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully added webhook", slog.Any("webhook_id", w.ID), slog.String("op", op))
+	return nil
+}
+
+func (s *webhooksStorage) Update(ctx context.Context, w models.Webhook) error {
+	const op = "storage.postgresql.webhooks.Update"
+	const sql = `
+		UPDATE webhooks
+		   SET url = $2
+		     , secret = $3
+		     , events = $4
+		 WHERE id = $1 AND tenant_id = $5;`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, w.ID, w.URL, w.Secret, eventTypesToStrings(w.Events), w.Tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during update", sl.Err(pgErr), slog.String("op", op), slog.Any("webhook_id", w.ID))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute update", sl.Err(err), slog.String("op", op), slog.Any("webhook_id", w.ID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.log.Warn("webhook not found", slog.String("op", op), slog.Any("webhook_id", w.ID))
+		return storage.ErrWebhookNotFound
+	}
+
+	s.log.Info("successfully updated webhook", slog.Any("webhook_id", w.ID), slog.String("op", op))
+	return nil
+}
+
+func (s *webhooksStorage) RemoveByID(ctx context.Context, tenant models.TenantID, id models.WebhookID) error {
+	const op = "storage.postgresql.webhooks.RemoveByID"
+	const sql = `
+		DELETE FROM webhooks
+		 WHERE id = $1 AND ($2::TEXT = '' OR tenant_id = $2);`
+
+	s.logSqlQuery(sql)
+	tag, err := s.client.Exec(ctx, sql, id, tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during delete", sl.Err(pgErr), slog.String("op", op), slog.Any("webhook_id", id))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute delete", sl.Err(err), slog.String("op", op), slog.Any("webhook_id", id))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if tag.RowsAffected() == 0 {
+		s.log.Warn("webhook not found", slog.String("op", op), slog.Any("webhook_id", id))
+		return storage.ErrWebhookNotFound
+	}
+
+	s.log.Info("successfully removed webhook", slog.Any("webhook_id", id), slog.String("op", op))
+	return nil
+}
+
+func (s *webhooksStorage) FindByID(ctx context.Context, tenant models.TenantID, id models.WebhookID) (*models.Webhook, error) {
+	const op = "storage.postgresql.webhooks.FindByID"
+	const sql = `
+		SELECT id, url, secret, events, created_at, tenant_id
+		  FROM webhooks
+		 WHERE id = $1 AND ($2::TEXT = '' OR tenant_id = $2);`
+
+	s.logSqlQuery(sql)
+	w, err := scanWebhook(s.client.QueryRow(ctx, sql, id, tenant))
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during fetch", sl.Err(pgErr), slog.String("op", op), slog.Any("webhook_id", id))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.log.Warn("webhook not found", slog.String("op", op), slog.Any("webhook_id", id))
+			return nil, storage.ErrWebhookNotFound
+		}
+		s.log.Error("failed to fetch webhook", sl.Err(err), slog.String("op", op), slog.Any("webhook_id", id))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched webhook", slog.Any("webhook_id", id), slog.String("op", op))
+	return w, nil
+}
+
+func (s *webhooksStorage) FindAll(ctx context.Context, tenant models.TenantID) ([]*models.Webhook, error) {
+	const op = "storage.postgresql.webhooks.FindAll"
+	const sql = `
+		SELECT id, url, secret, events, created_at, tenant_id
+		  FROM webhooks
+		 WHERE $1::TEXT = '' OR tenant_id = $1
+		 ORDER BY id;`
+
+	return s.findWebhooks(ctx, op, sql, tenant)
+}
+
+func (s *webhooksStorage) FindByEvent(ctx context.Context, tenant models.TenantID, t models.EventType) ([]*models.Webhook, error) {
+	const op = "storage.postgresql.webhooks.FindByEvent"
+	// an event always belongs to a tenant, so an empty tenant matches no webhook rather than every one
+	const sql = `
+		SELECT id, url, secret, events, created_at, tenant_id
+		  FROM webhooks
+		 WHERE tenant_id = $1 AND $2 = ANY (events)
+		 ORDER BY id;`
+
+	return s.findWebhooks(ctx, op, sql, tenant, string(t))
+}
+
+func (s *webhooksStorage) findWebhooks(ctx context.Context, op, sql string, args ...interface{}) ([]*models.Webhook, error) {
+	s.logSqlQuery(sql)
+	rows, err := s.client.Query(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var webhooks []*models.Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		webhooks = append(webhooks, w)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched webhooks", slog.String("op", op), slog.Int("count", len(webhooks)))
+	return webhooks, nil
+}
+
+func (s *webhooksStorage) AddDeadLetter(ctx context.Context, d models.DeadLetter) error {
+	const op = "storage.postgresql.webhooks.AddDeadLetter"
+	const sql = `
+		INSERT INTO webhook_dead_letters (webhook_id, event_id, event_type, payload, attempts, last_error, failed_at)
+			 VALUES ($1, $2, $3, $4, $5, $6, $7);`
+
+	s.logSqlQuery(sql)
+	_, err := s.client.Exec(ctx, sql, d.WebhookID, d.EventID, d.EventType, []byte(d.Payload), d.Attempts, d.LastError, d.FailedAt.UTC())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during insert", sl.Err(pgErr), slog.String("op", op), slog.Any("webhook_id", d.WebhookID))
+			return fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute insert", sl.Err(err), slog.String("op", op), slog.Any("webhook_id", d.WebhookID))
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully added dead letter", slog.Any("webhook_id", d.WebhookID), slog.Any("event_id", d.EventID), slog.String("op", op))
+	return nil
+}
+
+func (s *webhooksStorage) DeadLetters(ctx context.Context, tenant models.TenantID, id models.WebhookID) ([]*models.DeadLetter, error) {
+	const op = "storage.postgresql.webhooks.DeadLetters"
+	const sql = `
+		SELECT d.id, d.webhook_id, d.event_id, d.event_type, d.payload, d.attempts, d.last_error, d.failed_at
+		  FROM webhook_dead_letters d
+		  JOIN webhooks w ON w.id = d.webhook_id
+		 WHERE d.webhook_id = $1 AND ($2::TEXT = '' OR w.tenant_id = $2)
+		 ORDER BY d.id DESC;`
+
+	s.logSqlQuery(sql)
+	rows, err := s.client.Query(ctx, sql, id, tenant)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			s.log.Error("database error during query", sl.Err(pgErr), slog.String("op", op), slog.Any("webhook_id", id))
+			return nil, fmt.Errorf("%s: database error: %w", op, pgErr)
+		}
+		s.log.Error("failed to execute query", sl.Err(err), slog.String("op", op), slog.Any("webhook_id", id))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var letters []*models.DeadLetter
+	for rows.Next() {
+		var d models.DeadLetter
+		err = rows.Scan(&d.ID, &d.WebhookID, &d.EventID, &d.EventType, (*[]byte)(&d.Payload), &d.Attempts, &d.LastError, &d.FailedAt)
+		if err != nil {
+			s.log.Error("failed to scan row", sl.Err(err), slog.String("op", op))
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		letters = append(letters, &d)
+	}
+
+	if err = rows.Err(); err != nil {
+		s.log.Error("error iterating rows", sl.Err(err), slog.String("op", op))
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	s.log.Info("successfully fetched dead letters", slog.String("op", op), slog.Any("webhook_id", id), slog.Int("count", len(letters)))
+	return letters, nil
+}
+
+func scanWebhook(row pgx.Row) (*models.Webhook, error) {
+	var w models.Webhook
+	var events []string
+
+	if err := row.Scan(&w.ID, &w.URL, &w.Secret, &events, &w.CreatedAt, &w.Tenant); err != nil {
+		return nil, err
+	}
+	w.Events = make([]models.EventType, len(events))
+	for i, e := range events {
+		w.Events[i] = models.EventType(e)
+	}
+
+	return &w, nil
+}
+
+func eventTypesToStrings(events []models.EventType) []string {
+	out := make([]string, len(events))
+	for i, e := range events {
+		out[i] = string(e)
+	}
+
+	return out
+}
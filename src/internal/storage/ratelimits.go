@@ -0,0 +1,15 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/pkg/ratelimit"
+	"time"
+)
+
+// RateLimitStorage shares the rate limit buckets between all instances of the application.
+type RateLimitStorage interface {
+	// Take spends a token of the bucket under key, a missing bucket starts full.
+	Take(ctx context.Context, key string, l ratelimit.Limit) (ratelimit.Decision, error)
+	// PurgeIdleBefore removes buckets not used since the given moment.
+	PurgeIdleBefore(ctx context.Context, before time.Time) (int64, error)
+}
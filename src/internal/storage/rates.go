@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"errors"
+	"time"
+)
+
+// ErrExchangeRateNotFound is returned when no rate is known for a currency pair on or before the requested date.
+var ErrExchangeRateNotFound = errors.New("exchange rate not found")
+
+type ExchangeRatesStorage interface {
+	// FindRate returns the latest daily rate from one currency to another published on or before the given date.
+	FindRate(ctx context.Context, from, to models.Currency, on time.Time) (float64, error)
+}
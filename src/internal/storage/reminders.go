@@ -0,0 +1,16 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"time"
+)
+
+// RemindersStorage remembers which upcoming charges users were already reminded of.
+type RemindersStorage interface {
+	// Claim records the reminder of the charge of the tenant's subscription as sent, it returns false
+	// when it was claimed before.
+	Claim(ctx context.Context, tenant models.TenantID, id models.SubscriptionID, chargeAt time.Time) (bool, error)
+	// Release forgets the claim, so the reminder is sent again.
+	Release(ctx context.Context, tenant models.TenantID, id models.SubscriptionID, chargeAt time.Time) error
+}
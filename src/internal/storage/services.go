@@ -0,0 +1,29 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"errors"
+)
+
+// ErrServiceNotFound is returned when no catalog entry has the requested id or name.
+var ErrServiceNotFound = errors.New("service not found")
+
+// ErrServiceNameTaken is returned when the name or an alias of a service is already used by another one.
+var ErrServiceNameTaken = errors.New("service name is already taken")
+
+// ServicesStorage keeps a catalog per tenant, names and aliases are unique within the catalog of a tenant.
+// Methods addressing a service only see it when it belongs to the given tenant, an empty tenant matches
+// every tenant.
+type ServicesStorage interface {
+	Add(ctx context.Context, s models.Service) error
+	// Update changes the service of the tenant the service belongs to.
+	Update(ctx context.Context, s models.Service) error
+	RemoveByID(ctx context.Context, tenant models.TenantID, id models.ServiceID) error
+	FindByID(ctx context.Context, tenant models.TenantID, id models.ServiceID) (*models.Service, error)
+	// FindByName looks the service up by the normalized spelling of its name or one of its aliases,
+	// the tenant must not be empty.
+	FindByName(ctx context.Context, tenant models.TenantID, nameKey string) (*models.Service, error)
+	// FindAll returns the catalog of the tenant ordered by name.
+	FindAll(ctx context.Context, tenant models.TenantID) ([]*models.Service, error)
+}
@@ -4,23 +4,112 @@ import (
 	"context"
 	"effective-mobile/internal/models"
 	"errors"
+	"fmt"
 	"time"
 )
 
 // ErrSubscriptionNotFound is returned when a subscription is not found in the database.
 var ErrSubscriptionNotFound = errors.New("subscription not found")
 
+// ErrVersionConflict is returned when a subscription exists but its version differs from the expected one.
+var ErrVersionConflict = errors.New("subscription version conflict")
+
+// BatchItemError points at the record of a batch that was refused by the database.
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("batch item %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
+
+// SubscriptionsStorage keeps the subscriptions of every tenant. Methods addressing a subscription by id
+// only see it when it belongs to the given tenant, an empty tenant matches every tenant.
 type SubscriptionsStorage interface {
 	Add(ctx context.Context, s models.Subscription) error
-	RemoveByID(ctx context.Context, id models.SubscriptionID) error
+	// AddMany stores all subscriptions in one transaction, a refused record is reported as *BatchItemError.
+	AddMany(ctx context.Context, subs []models.Subscription) error
+	// RemoveByID soft-deletes the subscription, it can be brought back with Restore until purged.
+	// A non-zero version makes the removal conditional on the current version.
+	RemoveByID(ctx context.Context, tenant models.TenantID, id models.SubscriptionID, version int64) error
+	Restore(ctx context.Context, tenant models.TenantID, id models.SubscriptionID) error
+	// Purge physically removes the subscription whether it is soft-deleted or not.
+	// A non-zero version makes the removal conditional on the current version.
+	Purge(ctx context.Context, tenant models.TenantID, id models.SubscriptionID, version int64) error
+	// PurgeDeletedBefore physically removes subscriptions soft-deleted before the given moment
+	// and records a deletion event for each of them.
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+	// Update stores the subscription if its stored version still equals s.Version and increments the version.
 	Update(ctx context.Context, s models.Subscription) error
-	FindByID(ctx context.Context, id models.SubscriptionID) (*models.Subscription, error)
+	FindByID(ctx context.Context, tenant models.TenantID, id models.SubscriptionID) (*models.Subscription, error)
 	Find(ctx context.Context, f SubscriptionsFilter) ([]*models.Subscription, error)
+	// Stream feeds every subscription matching the filter to fn without loading them all at once,
+	// an error returned by fn stops the iteration.
+	Stream(ctx context.Context, f SubscriptionsFilter, fn func(*models.Subscription) error) error
+	// History returns the changes of the subscription, oldest first.
+	History(ctx context.Context, tenant models.TenantID, id models.SubscriptionID) ([]*models.SubscriptionChange, error)
 }
 
 type SubscriptionsFilter struct {
+	// Tenant keeps subscriptions of the given tenant, empty matches every tenant.
+	Tenant      models.TenantID
 	ServiceName models.ServiceName
 	OwnerID     models.PersonID
-	StartTime   *time.Time
-	EndTime     *time.Time
+	// Category keeps subscriptions to services mapped to the given category.
+	Category string
+	// StartTime and EndTime bound the window matched according to Range, either of them may be omitted.
+	StartTime *time.Time
+	EndTime   *time.Time
+	Range     RangeMode
+	// ActiveAt keeps subscriptions that have started and not yet ended at the given moment.
+	ActiveAt *time.Time
+	MinPrice *int64
+	MaxPrice *int64
+	// Currency keeps subscriptions priced in the given currency, prices are only comparable within one.
+	Currency models.Currency
+	// Deleted lists soft-deleted subscriptions instead of live ones.
+	Deleted bool
+
+	Sort SubscriptionsSort
+	// After continues the listing right after the given row in Sort order.
+	After *SubscriptionsCursor
+	// Limit caps the number of returned rows, zero means no limit.
+	Limit int
+}
+
+// RangeMode tells how a subscription has to relate to the StartTime/EndTime window to match it.
+type RangeMode string
+
+const (
+	// RangeStartedWithin matches subscriptions started within the window, it is the default.
+	RangeStartedWithin RangeMode = "started_within"
+	// RangeActiveDuring matches subscriptions whose lifetime overlaps the window, an open end never ends.
+	RangeActiveDuring RangeMode = "active_during"
+	// RangeEndedWithin matches subscriptions ended within the window, open-ended ones never match.
+	RangeEndedWithin RangeMode = "ended_within"
+)
+
+type SortField string
+
+const (
+	SortByID        SortField = "id"
+	SortByStartTime SortField = "start_time"
+	SortByPrice     SortField = "price"
+)
+
+type SubscriptionsSort struct {
+	Field SortField
+	Desc  bool
+}
+
+// SubscriptionsCursor holds the sort keys of the last row of a page.
+type SubscriptionsCursor struct {
+	ID        models.SubscriptionID
+	StartTime time.Time
+	Price     int64
 }
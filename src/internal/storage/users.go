@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"errors"
+)
+
+// ErrUserNotFound is returned when a user is not found in the database.
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrUserEmailTaken is returned when another user already has the email.
+var ErrUserEmailTaken = errors.New("user email is already taken")
+
+// ErrUserHasSubscriptions is returned when a user still owning subscriptions is removed.
+var ErrUserHasSubscriptions = errors.New("user has subscriptions")
+
+// UsersStorage keeps the users of every tenant. Methods addressing a user by id only see it when it belongs
+// to the given tenant, an empty tenant matches every tenant.
+type UsersStorage interface {
+	Add(ctx context.Context, u models.User) error
+	// Update changes the user of the tenant the user belongs to.
+	Update(ctx context.Context, u models.User) error
+	// RemoveByID fails with ErrUserHasSubscriptions while any subscription, deleted or not, belongs to the user.
+	RemoveByID(ctx context.Context, tenant models.TenantID, id models.PersonID) error
+	FindByID(ctx context.Context, tenant models.TenantID, id models.PersonID) (*models.User, error)
+	Find(ctx context.Context, f UsersFilter) ([]*models.User, error)
+}
+
+type UsersFilter struct {
+	// Tenant keeps users of the given tenant, empty matches every tenant.
+	Tenant models.TenantID
+	// After continues the listing right after the user with the given id, users are ordered by id.
+	After *models.PersonID
+	// Limit caps the number of returned rows, zero means no limit.
+	Limit int
+}
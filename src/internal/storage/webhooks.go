@@ -0,0 +1,27 @@
+package storage
+
+import (
+	"context"
+	"effective-mobile/internal/models"
+	"errors"
+)
+
+// ErrWebhookNotFound is returned when a webhook is not found in the database.
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// WebhooksStorage keeps the webhooks of every tenant. Methods addressing a webhook by id only see it when it
+// belongs to the given tenant, an empty tenant matches every tenant.
+type WebhooksStorage interface {
+	Add(ctx context.Context, w models.Webhook) error
+	// Update changes the webhook of the tenant the webhook belongs to.
+	Update(ctx context.Context, w models.Webhook) error
+	RemoveByID(ctx context.Context, tenant models.TenantID, id models.WebhookID) error
+	FindByID(ctx context.Context, tenant models.TenantID, id models.WebhookID) (*models.Webhook, error)
+	// FindAll returns every webhook of the tenant ordered by id.
+	FindAll(ctx context.Context, tenant models.TenantID) ([]*models.Webhook, error)
+	// FindByEvent returns the webhooks of the tenant subscribed to the event type, the tenant must not be empty.
+	FindByEvent(ctx context.Context, tenant models.TenantID, t models.EventType) ([]*models.Webhook, error)
+	AddDeadLetter(ctx context.Context, d models.DeadLetter) error
+	// DeadLetters returns the failed deliveries to the webhook of the tenant, the latest first.
+	DeadLetters(ctx context.Context, tenant models.TenantID, id models.WebhookID) ([]*models.DeadLetter, error)
+}
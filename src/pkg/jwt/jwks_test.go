@@ -0,0 +1,72 @@
+package jwt
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"testing"
+)
+
+func TestParseJWKS(t *testing.T) {
+	key := testRSAKey(t)
+	n := base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	rsaKey := func(kid string) jwk { return jwk{Kty: "RSA", Kid: kid, Use: "sig", Alg: AlgRS256, N: n, E: e} }
+
+	tests := []struct {
+		name     string
+		keys     []jwk
+		wantKids []string
+		wantErr  bool
+	}{
+		{name: "signing keys", keys: []jwk{rsaKey("k1"), rsaKey("k2")}, wantKids: []string{"k1", "k2"}},
+		{name: "use and alg omitted", keys: []jwk{{Kty: "RSA", Kid: "k1", N: n, E: e}}, wantKids: []string{"k1"}},
+		{
+			name: "other keys skipped",
+			keys: []jwk{
+				rsaKey("k1"),
+				{Kty: "EC", Kid: "ec", Use: "sig"},
+				{Kty: "RSA", Kid: "enc", Use: "enc", N: n, E: e},
+				{Kty: "RSA", Kid: "ps", Alg: "PS256", N: n, E: e},
+			},
+			wantKids: []string{"k1"},
+		},
+		{name: "no signing keys", keys: []jwk{{Kty: "RSA", Kid: "enc", Use: "enc", N: n, E: e}}, wantErr: true},
+		{name: "duplicate kid", keys: []jwk{rsaKey("k1"), rsaKey("k1")}, wantErr: true},
+		{name: "invalid modulus", keys: []jwk{{Kty: "RSA", Kid: "k1", N: "not base64!", E: e}}, wantErr: true},
+		{name: "empty exponent", keys: []jwk{{Kty: "RSA", Kid: "k1", N: n}}, wantErr: true},
+		{name: "oversized exponent", keys: []jwk{{Kty: "RSA", Kid: "k1", N: n, E: "AQABAQAB"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(jwks{Keys: tt.keys})
+			if err != nil {
+				t.Fatalf("can not encode jwks: %v", err)
+			}
+
+			keys, err := ParseJWKS(data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseJWKS() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(keys) != len(tt.wantKids) {
+				t.Fatalf("ParseJWKS() returned %d keys, want %v", len(keys), tt.wantKids)
+			}
+			for _, kid := range tt.wantKids {
+				got, ok := keys[kid]
+				if !ok {
+					t.Fatalf("ParseJWKS() has no key %q", kid)
+				}
+				if got.N.Cmp(key.N) != 0 || got.E != key.E {
+					t.Errorf("key %q does not match the encoded key", kid)
+				}
+			}
+		})
+	}
+}
+
+func TestParseJWKSRejectsInvalidJSON(t *testing.T) {
+	if _, err := ParseJWKS([]byte(`{"keys": [`)); err == nil {
+		t.Error("ParseJWKS() succeeded, want an error")
+	}
+}
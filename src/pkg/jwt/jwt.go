@@ -0,0 +1,267 @@
+// Package jwt verifies compact JSON Web Tokens signed with HS256 or RS256 and checks
+// their registered time, issuer and audience claims. Tokens without an expiry are rejected.
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+)
+
+var (
+	ErrMalformed            = errors.New("malformed token")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrUnknownKey           = errors.New("unknown signing key")
+	ErrInvalidSignature     = errors.New("invalid signature")
+	ErrExpired              = errors.New("token is expired")
+	ErrNotYetValid          = errors.New("token is not valid yet")
+	ErrInvalidIssuer        = errors.New("unexpected issuer")
+	ErrInvalidAudience      = errors.New("unexpected audience")
+)
+
+// Claims holds the registered claims of a verified token, all other claims are kept in Extra.
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	NotBefore time.Time
+	IssuedAt  time.Time
+	Extra     map[string]any
+}
+
+// String returns the extra claim key if it is a string.
+func (c *Claims) String(key string) (string, bool) {
+	v, ok := c.Extra[key].(string)
+	return v, ok
+}
+
+// Options restrict the tokens accepted by a Verifier. Empty Issuer or Audience accept any value,
+// Leeway tolerates clock skew when checking exp and nbf.
+type Options struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+// Verifier checks signatures and claims of tokens signed with a single algorithm.
+type Verifier struct {
+	alg     string
+	secret  []byte
+	keys    map[string]*rsa.PublicKey
+	options Options
+	now     func() time.Time
+}
+
+// NewHS256Verifier accepts tokens signed with the shared secret.
+func NewHS256Verifier(secret []byte, options Options) (*Verifier, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("hs256 secret is empty")
+	}
+
+	return &Verifier{alg: AlgHS256, secret: secret, options: options, now: time.Now}, nil
+}
+
+// NewRS256Verifier accepts tokens signed by one of keys, picked by the kid header.
+// A token without kid is accepted only when keys holds exactly one key.
+func NewRS256Verifier(keys map[string]*rsa.PublicKey, options Options) (*Verifier, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("rs256 key set is empty")
+	}
+
+	return &Verifier{alg: AlgRS256, keys: keys, options: options, now: time.Now}, nil
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// Verify parses token and returns its claims if the signature and the claims are valid.
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, err
+	}
+	if h.Alg != v.alg {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlgorithm, h.Alg)
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+	if err := v.verifySignature(h, parts[0]+"."+parts[1], sig); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, err
+	}
+	claims, err := parseClaims(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) verifySignature(h header, signed string, sig []byte) error {
+	switch v.alg {
+	case AlgHS256:
+		mac := hmac.New(sha256.New, v.secret)
+		mac.Write([]byte(signed))
+		if !hmac.Equal(mac.Sum(nil), sig) {
+			return ErrInvalidSignature
+		}
+		return nil
+	case AlgRS256:
+		key, err := v.rsaKey(h.Kid)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sig); err != nil {
+			return ErrInvalidSignature
+		}
+		return nil
+	default:
+		return ErrUnsupportedAlgorithm
+	}
+}
+
+func (v *Verifier) rsaKey(kid string) (*rsa.PublicKey, error) {
+	if kid == "" {
+		if len(v.keys) != 1 {
+			return nil, ErrUnknownKey
+		}
+		for _, key := range v.keys {
+			return key, nil
+		}
+	}
+
+	key, ok := v.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, kid)
+	}
+
+	return key, nil
+}
+
+func (v *Verifier) validate(c *Claims) error {
+	now := v.now()
+	leeway := v.options.Leeway
+
+	if c.ExpiresAt.IsZero() {
+		return fmt.Errorf("%w: missing \"exp\" claim", ErrMalformed)
+	}
+	if !now.Before(c.ExpiresAt.Add(leeway)) {
+		return ErrExpired
+	}
+	if !c.NotBefore.IsZero() && now.Add(leeway).Before(c.NotBefore) {
+		return ErrNotYetValid
+	}
+	if v.options.Issuer != "" && c.Issuer != v.options.Issuer {
+		return ErrInvalidIssuer
+	}
+	if v.options.Audience != "" {
+		for _, aud := range c.Audience {
+			if aud == v.options.Audience {
+				return nil
+			}
+		}
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
+
+func decodeSegment(seg string, dst any) error {
+	b, err := base64.RawURLEncoding.DecodeString(seg)
+	if err != nil {
+		return ErrMalformed
+	}
+	if err := json.Unmarshal(b, dst); err != nil {
+		return ErrMalformed
+	}
+
+	return nil
+}
+
+func parseClaims(raw map[string]any) (*Claims, error) {
+	c := &Claims{Extra: make(map[string]any)}
+	for key, value := range raw {
+		var ok bool
+		switch key {
+		case "sub":
+			c.Subject, ok = value.(string)
+		case "iss":
+			c.Issuer, ok = value.(string)
+		case "aud":
+			c.Audience, ok = parseAudience(value)
+		case "exp":
+			c.ExpiresAt, ok = parseNumericDate(value)
+		case "nbf":
+			c.NotBefore, ok = parseNumericDate(value)
+		case "iat":
+			c.IssuedAt, ok = parseNumericDate(value)
+		default:
+			c.Extra[key], ok = value, true
+		}
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid %q claim", ErrMalformed, key)
+		}
+	}
+
+	return c, nil
+}
+
+func parseAudience(value any) ([]string, bool) {
+	switch aud := value.(type) {
+	case string:
+		return []string{aud}, true
+	case []any:
+		res := make([]string, 0, len(aud))
+		for _, a := range aud {
+			s, ok := a.(string)
+			if !ok {
+				return nil, false
+			}
+			res = append(res, s)
+		}
+		return res, true
+	default:
+		return nil, false
+	}
+}
+
+func parseNumericDate(value any) (time.Time, bool) {
+	seconds, ok := value.(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second))), true
+}
@@ -0,0 +1,186 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	testNow    = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	testSecret = []byte("s3cret")
+)
+
+func testRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("can not generate rsa key: %v", err)
+	}
+	return key
+}
+
+func encodeSegment(t *testing.T, v any) string {
+	t.Helper()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("can not encode segment: %v", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func signHS256(t *testing.T, secret []byte, h header, claims map[string]any) string {
+	t.Helper()
+
+	signed := encodeSegment(t, h) + "." + encodeSegment(t, claims)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(signed))
+	return signed + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, h header, claims map[string]any) string {
+	t.Helper()
+
+	signed := encodeSegment(t, h) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(signed))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatalf("can not sign token: %v", err)
+	}
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+// validClaims returns claims accepted by a verifier expecting issuer "auth" and audience "api" at testNow.
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":  "4f1c1e1a-3c5e-4d6a-9a51-7d8f2b8c1e00",
+		"iss":  "auth",
+		"aud":  "api",
+		"exp":  testNow.Add(time.Hour).Unix(),
+		"role": "admin",
+	}
+}
+
+func with(claims map[string]any, key string, value any) map[string]any {
+	if value == nil {
+		delete(claims, key)
+	} else {
+		claims[key] = value
+	}
+	return claims
+}
+
+func TestHS256Verifier(t *testing.T) {
+	v, err := NewHS256Verifier(testSecret, Options{Issuer: "auth", Audience: "api", Leeway: time.Minute})
+	if err != nil {
+		t.Fatalf("NewHS256Verifier() error = %v", err)
+	}
+	v.now = func() time.Time { return testNow }
+
+	hs256 := header{Alg: AlgHS256}
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: signHS256(t, testSecret, hs256, validClaims())},
+		{name: "audience list", token: signHS256(t, testSecret, hs256, with(validClaims(), "aud", []string{"web", "api"}))},
+		{name: "expired within leeway", token: signHS256(t, testSecret, hs256, with(validClaims(), "exp", testNow.Add(-30*time.Second).Unix()))},
+		{name: "expired", token: signHS256(t, testSecret, hs256, with(validClaims(), "exp", testNow.Add(-2*time.Minute).Unix())), wantErr: ErrExpired},
+		{name: "no expiry", token: signHS256(t, testSecret, hs256, with(validClaims(), "exp", nil)), wantErr: ErrMalformed},
+		{name: "not valid yet", token: signHS256(t, testSecret, hs256, with(validClaims(), "nbf", testNow.Add(2*time.Minute).Unix())), wantErr: ErrNotYetValid},
+		{name: "not valid yet within leeway", token: signHS256(t, testSecret, hs256, with(validClaims(), "nbf", testNow.Add(30*time.Second).Unix()))},
+		{name: "wrong issuer", token: signHS256(t, testSecret, hs256, with(validClaims(), "iss", "other")), wantErr: ErrInvalidIssuer},
+		{name: "no issuer", token: signHS256(t, testSecret, hs256, with(validClaims(), "iss", nil)), wantErr: ErrInvalidIssuer},
+		{name: "wrong audience", token: signHS256(t, testSecret, hs256, with(validClaims(), "aud", "web")), wantErr: ErrInvalidAudience},
+		{name: "no audience", token: signHS256(t, testSecret, hs256, with(validClaims(), "aud", nil)), wantErr: ErrInvalidAudience},
+		{name: "invalid claim type", token: signHS256(t, testSecret, hs256, with(validClaims(), "exp", "tomorrow")), wantErr: ErrMalformed},
+		{name: "other secret", token: signHS256(t, []byte("other"), hs256, validClaims()), wantErr: ErrInvalidSignature},
+		{name: "alg none", token: encodeSegment(t, header{Alg: "none"}) + "." + encodeSegment(t, validClaims()) + ".", wantErr: ErrUnsupportedAlgorithm},
+		{name: "alg rs256", token: signHS256(t, testSecret, header{Alg: AlgRS256}, validClaims()), wantErr: ErrUnsupportedAlgorithm},
+		{name: "two segments", token: encodeSegment(t, hs256) + "." + encodeSegment(t, validClaims()), wantErr: ErrMalformed},
+		{name: "invalid header", token: "not-base64!." + encodeSegment(t, validClaims()) + ".sig", wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := v.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if claims.Subject != "4f1c1e1a-3c5e-4d6a-9a51-7d8f2b8c1e00" {
+				t.Errorf("Verify() subject = %q, want the token subject", claims.Subject)
+			}
+			if role, ok := claims.String("role"); !ok || role != "admin" {
+				t.Errorf("Verify() role = %q, want %q", role, "admin")
+			}
+		})
+	}
+}
+
+func TestRS256Verifier(t *testing.T) {
+	key, other := testRSAKey(t), testRSAKey(t)
+	v, err := NewRS256Verifier(map[string]*rsa.PublicKey{"k1": &key.PublicKey, "k2": &other.PublicKey}, Options{})
+	if err != nil {
+		t.Fatalf("NewRS256Verifier() error = %v", err)
+	}
+	v.now = func() time.Time { return testNow }
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "valid", token: signRS256(t, key, header{Alg: AlgRS256, Kid: "k1"}, validClaims())},
+		{name: "second key", token: signRS256(t, other, header{Alg: AlgRS256, Kid: "k2"}, validClaims())},
+		{name: "key of other kid", token: signRS256(t, other, header{Alg: AlgRS256, Kid: "k1"}, validClaims()), wantErr: ErrInvalidSignature},
+		{name: "unknown kid", token: signRS256(t, key, header{Alg: AlgRS256, Kid: "k3"}, validClaims()), wantErr: ErrUnknownKey},
+		{name: "no kid with several keys", token: signRS256(t, key, header{Alg: AlgRS256}, validClaims()), wantErr: ErrUnknownKey},
+		// a public key must not be usable as an hmac secret
+		{name: "alg hs256", token: signHS256(t, key.PublicKey.N.Bytes(), header{Alg: AlgHS256, Kid: "k1"}, validClaims()), wantErr: ErrUnsupportedAlgorithm},
+		{name: "no expiry", token: signRS256(t, key, header{Alg: AlgRS256, Kid: "k1"}, with(validClaims(), "exp", nil)), wantErr: ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRS256VerifierSingleKeyWithoutKid(t *testing.T) {
+	key := testRSAKey(t)
+	v, err := NewRS256Verifier(map[string]*rsa.PublicKey{"k1": &key.PublicKey}, Options{})
+	if err != nil {
+		t.Fatalf("NewRS256Verifier() error = %v", err)
+	}
+	v.now = func() time.Time { return testNow }
+
+	if _, err := v.Verify(signRS256(t, key, header{Alg: AlgRS256}, validClaims())); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+}
+
+func TestNewVerifierRequiresKeys(t *testing.T) {
+	if _, err := NewHS256Verifier(nil, Options{}); err == nil {
+		t.Error("NewHS256Verifier() succeeded without a secret, want an error")
+	}
+	if _, err := NewRS256Verifier(nil, Options{}); err == nil {
+		t.Error("NewRS256Verifier() succeeded without keys, want an error")
+	}
+}
@@ -0,0 +1,93 @@
+// Package ratelimit implements token buckets: a bucket holds up to Burst tokens, every request
+// spends one and the bucket refills at a steady rate, so short bursts pass while sustained load is capped.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit allows Requests per Per on average and up to Burst requests at once.
+type Limit struct {
+	Requests int
+	Per      time.Duration
+	Burst    int
+}
+
+// IsZero reports whether the limit is unset, an unset limit does not restrict anything.
+func (l Limit) IsZero() bool {
+	return l.Requests <= 0 || l.Per <= 0
+}
+
+// Capacity is the number of tokens in a full bucket, it is never smaller than a single request.
+func (l Limit) Capacity() float64 {
+	if l.Burst < 1 {
+		return 1
+	}
+
+	return float64(l.Burst)
+}
+
+// rate is the refill rate in tokens per second.
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Per.Seconds()
+}
+
+// Bucket is the state of a client's bucket, a zero Bucket is full.
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// Decision tells whether a request may pass and describes the bucket after it.
+type Decision struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// RetryAfter is the wait until the next request passes, it is zero for allowed requests.
+	RetryAfter time.Duration
+	// ResetAfter is the wait until the bucket is full again.
+	ResetAfter time.Duration
+}
+
+// Take refills the bucket up to now and spends a token if there is one.
+func (l Limit) Take(b Bucket, now time.Time) (Bucket, Decision) {
+	capacity, rate := l.Capacity(), l.rate()
+	tokens := l.refill(b, now)
+
+	d := Decision{Limit: int(capacity)}
+	if tokens >= 1 {
+		tokens--
+		d.Allowed = true
+	} else {
+		d.RetryAfter = seconds((1 - tokens) / rate)
+	}
+	d.Remaining = int(math.Floor(tokens))
+	d.ResetAfter = seconds((capacity - tokens) / rate)
+
+	return Bucket{Tokens: tokens, UpdatedAt: now}, d
+}
+
+// IsFull reports whether the bucket has refilled completely by now, a full bucket equals a missing one.
+func (l Limit) IsFull(b Bucket, now time.Time) bool {
+	return l.refill(b, now) >= l.Capacity()
+}
+
+func (l Limit) refill(b Bucket, now time.Time) float64 {
+	if b.UpdatedAt.IsZero() {
+		return l.Capacity()
+	}
+	elapsed := math.Max(0, now.Sub(b.UpdatedAt).Seconds())
+
+	return math.Min(l.Capacity(), b.Tokens+elapsed*l.rate())
+}
+
+func seconds(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
+
+// Store keeps the buckets of all clients, key identifies the client and the limited route.
+type Store interface {
+	Take(ctx context.Context, key string, l Limit) (Decision, error)
+}
@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimitTake(t *testing.T) {
+	start := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	// 2 requests per second with bursts of 3
+	l := Limit{Requests: 2, Per: time.Second, Burst: 3}
+
+	tests := []struct {
+		name   string
+		bucket Bucket
+		at     time.Time
+		want   Decision
+	}{
+		{
+			name: "full bucket",
+			at:   start,
+			want: Decision{Allowed: true, Limit: 3, Remaining: 2, ResetAfter: 500 * time.Millisecond},
+		},
+		{
+			name:   "last token",
+			bucket: Bucket{Tokens: 1, UpdatedAt: start},
+			at:     start,
+			want:   Decision{Allowed: true, Limit: 3, Remaining: 0, ResetAfter: 1500 * time.Millisecond},
+		},
+		{
+			name:   "empty bucket",
+			bucket: Bucket{Tokens: 0, UpdatedAt: start},
+			at:     start,
+			want:   Decision{Allowed: false, Limit: 3, Remaining: 0, RetryAfter: 500 * time.Millisecond, ResetAfter: 1500 * time.Millisecond},
+		},
+		{
+			name:   "refilled partly",
+			bucket: Bucket{Tokens: 0, UpdatedAt: start},
+			at:     start.Add(750 * time.Millisecond),
+			want:   Decision{Allowed: true, Limit: 3, Remaining: 0, ResetAfter: 1250 * time.Millisecond},
+		},
+		{
+			name:   "refill stops at burst",
+			bucket: Bucket{Tokens: 0, UpdatedAt: start},
+			at:     start.Add(time.Hour),
+			want:   Decision{Allowed: true, Limit: 3, Remaining: 2, ResetAfter: 500 * time.Millisecond},
+		},
+		{
+			name:   "clock going back refills nothing",
+			bucket: Bucket{Tokens: 0.5, UpdatedAt: start},
+			at:     start.Add(-time.Second),
+			want:   Decision{Allowed: false, Limit: 3, Remaining: 0, RetryAfter: 250 * time.Millisecond, ResetAfter: 1250 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, d := l.Take(tt.bucket, tt.at)
+			if d != tt.want {
+				t.Errorf("Take() decision = %+v, want %+v", d, tt.want)
+			}
+			if !b.UpdatedAt.Equal(tt.at) {
+				t.Errorf("Take() bucket updated at %s, want %s", b.UpdatedAt, tt.at)
+			}
+		})
+	}
+}
+
+func TestLimitWithoutBurstAllowsSingleRequests(t *testing.T) {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	l := Limit{Requests: 1, Per: time.Minute}
+
+	b, d := l.Take(Bucket{}, now)
+	if !d.Allowed || d.Limit != 1 {
+		t.Fatalf("first Take() = %+v, want a single request allowed", d)
+	}
+	if _, d = l.Take(b, now); d.Allowed || d.RetryAfter != time.Minute {
+		t.Errorf("second Take() = %+v, want it rejected for a minute", d)
+	}
+}
+
+func TestLimitIsZero(t *testing.T) {
+	tests := []struct {
+		limit Limit
+		want  bool
+	}{
+		{limit: Limit{}, want: true},
+		{limit: Limit{Requests: 5}, want: true},
+		{limit: Limit{Per: time.Second}, want: true},
+		{limit: Limit{Requests: 5, Per: time.Second}, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.limit.IsZero(); got != tt.want {
+			t.Errorf("%+v.IsZero() = %v, want %v", tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestMemoryStoreKeepsBucketsApart(t *testing.T) {
+	s := NewMemoryStore()
+	l := Limit{Requests: 1, Per: time.Hour}
+	ctx := context.Background()
+
+	for _, key := range []string{"a", "b"} {
+		if d, err := s.Take(ctx, key, l); err != nil || !d.Allowed {
+			t.Fatalf("Take(%s) = %+v, %v, want the first request allowed", key, d, err)
+		}
+	}
+	if d, err := s.Take(ctx, "a", l); err != nil || d.Allowed {
+		t.Errorf("Take(a) = %+v, %v, want the second request rejected", d, err)
+	}
+}
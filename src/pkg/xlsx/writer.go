@@ -0,0 +1,136 @@
+// Package xlsx writes single-sheet Office Open XML workbooks row by row, so large tables
+// can be streamed without keeping them in memory.
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const contentTypesXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+</Types>`
+
+const rootRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+
+const workbookXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets>
+</workbook>`
+
+const workbookRelsXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>
+</Relationships>`
+
+const sheetHeaderXML = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+const sheetFooterXML = `</sheetData></worksheet>`
+
+// Writer appends rows to the only sheet of a workbook. Close must be called to finish the file.
+type Writer struct {
+	zip   *zip.Writer
+	sheet io.Writer
+	rows  int
+}
+
+// NewWriter starts a workbook with one sheet called sheetName.
+func NewWriter(w io.Writer, sheetName string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+
+	var escapedName xmlText
+	if err := xml.EscapeText(&escapedName, []byte(sheetName)); err != nil {
+		return nil, fmt.Errorf("can not escape sheet name: %w", err)
+	}
+
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", contentTypesXML},
+		{"_rels/.rels", rootRelsXML},
+		{"xl/workbook.xml", fmt.Sprintf(workbookXML, escapedName)},
+		{"xl/_rels/workbook.xml.rels", workbookRelsXML},
+	}
+	for _, p := range parts {
+		f, err := zw.Create(p.name)
+		if err != nil {
+			return nil, fmt.Errorf("can not create %s: %w", p.name, err)
+		}
+		if _, err := io.WriteString(f, p.content); err != nil {
+			return nil, fmt.Errorf("can not write %s: %w", p.name, err)
+		}
+	}
+
+	sheet, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, fmt.Errorf("can not create sheet: %w", err)
+	}
+	if _, err := io.WriteString(sheet, sheetHeaderXML); err != nil {
+		return nil, fmt.Errorf("can not write sheet: %w", err)
+	}
+
+	return &Writer{zip: zw, sheet: sheet}, nil
+}
+
+// WriteRow appends a row. Integers and floats become numeric cells, nil an empty cell,
+// anything else is written as text.
+func (w *Writer) WriteRow(cells ...any) error {
+	w.rows++
+
+	var row xmlText
+	row = append(row, `<row r="`+strconv.Itoa(w.rows)+`">`...)
+	for _, cell := range cells {
+		switch v := cell.(type) {
+		case nil:
+			row = append(row, `<c/>`...)
+		case int:
+			row = append(row, `<c><v>`+strconv.Itoa(v)+`</v></c>`...)
+		case int64:
+			row = append(row, `<c><v>`+strconv.FormatInt(v, 10)+`</v></c>`...)
+		case float64:
+			row = append(row, `<c><v>`+strconv.FormatFloat(v, 'f', -1, 64)+`</v></c>`...)
+		default:
+			row = append(row, `<c t="inlineStr"><is><t xml:space="preserve">`...)
+			if err := xml.EscapeText(&row, []byte(fmt.Sprint(v))); err != nil {
+				return fmt.Errorf("can not escape cell: %w", err)
+			}
+			row = append(row, `</t></is></c>`...)
+		}
+	}
+	row = append(row, `</row>`...)
+
+	if _, err := w.sheet.Write(row); err != nil {
+		return fmt.Errorf("can not write row %d: %w", w.rows, err)
+	}
+
+	return nil
+}
+
+// Close finishes the sheet and writes the zip directory, it does not close the underlying writer.
+func (w *Writer) Close() error {
+	if _, err := io.WriteString(w.sheet, sheetFooterXML); err != nil {
+		return fmt.Errorf("can not finish sheet: %w", err)
+	}
+
+	return w.zip.Close()
+}
+
+// xmlText collects escaped xml without an intermediate buffer type.
+type xmlText []byte
+
+func (t *xmlText) Write(p []byte) (int, error) {
+	*t = append(*t, p...)
+	return len(p), nil
+}